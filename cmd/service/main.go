@@ -10,19 +10,29 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 
+	"bank/internal/application/approvals"
+	"bank/internal/application/idempotency"
 	appservice "bank/internal/application/service"
 	appusecase "bank/internal/application/usecase"
+	"bank/internal/domain/entity"
 	"bank/internal/domain/repository"
 	"bank/internal/domain/service"
 	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
 	"bank/internal/infrastructure/database"
 	infrahttp "bank/internal/infrastructure/http"
+	"bank/internal/infrastructure/jwt"
+	"bank/internal/infrastructure/memory"
 	"bank/internal/infrastructure/persistence"
+	"bank/internal/infrastructure/ratelimit"
+	"bank/internal/infrastructure/rates"
+	"bank/internal/infrastructure/signing"
 )
 
 const (
@@ -31,6 +41,16 @@ const (
 	DefaultServerHost = "0.0.0.0"
 
 	ShutdownTimeout = 30 * time.Second
+
+	IdempotencyPurgeInterval = time.Hour
+
+	DefaultHoldSweepInterval = time.Minute
+
+	DefaultJWTLeeway = 30 * time.Second
+
+	StoragePostgres = "postgres"
+
+	StorageMemory = "memory"
 )
 
 // AppConfig holds the application configuration
@@ -38,17 +58,53 @@ type AppConfig struct {
 	ServerHost             string
 	ServerPort             string
 	Debug                  bool
-	FailFastOnDBConnection bool // If true, app fails to start if DB is not connected
+	FailFastOnDBConnection bool          // If true, app fails to start if DB is not connected
+	IdempotencyTTL         time.Duration // How long Idempotency-Key responses are replayed
+	Storage                string        // Either StoragePostgres or StorageMemory
+	AutoMigrate            bool          // If true, pending migrations are applied on startup
+	RatesFile              string        // Exchange rate table; the built-in example rates when empty
+	ExchangeSpreadBps      int64         // Share of each exchange kept as revenue, in basis points
+	QuoteTTL               time.Duration // How long an exchange quote can be redeemed
+	HoldTTL                time.Duration // How long a hold lasts when the request sets no expiry
+	HoldSweepInterval      time.Duration // How often expired holds are released
+	JWTSecret              string        // HS256 signing secret; read from the environment only
+	JWTJWKSFile            string        // JSON Web Key Set with the RS256 public keys
+	JWTPublicKeyFile       string        // PEM encoded RS256 public key
+	JWTIssuer              string        // Required "iss" claim, if set
+	JWTAudience            string        // Required "aud" claim value, if set
+	JWTLeeway              time.Duration // Clock skew allowed when checking token lifetimes
+	InsecureNoAuth         bool          // If true, the service may run without verification keys
+	SignatureWindow        time.Duration // How far a signed request's timestamp may be from now
+	APIKeyEncryptionKey    string        // Hex encoded key sealing API key signing keys at rest; read from the environment only
+	ApprovalThresholds     string        // Withdrawal amounts per currency above which approval is needed, e.g. "10000.00 USD"
+	ApprovalTTL            time.Duration // How long a withdrawal request waits for a decision
+	RateLimitDefault       string        // Per-client limit on routes without a rule, e.g. "600/1m", or "off"
+	RateLimitRules         string        // Per-route client and wallet limits, or "off"
 }
 
 // Container holds all application dependencies
 type Container struct {
-	DB              *sql.DB
-	WalletRepo      repository.WalletRepository
-	TransactionRepo repository.TransactionRepository
-	WithdrawUseCase usecase.WithdrawUseCase
-	BalanceService  service.BalanceService
-	Server          *infrahttp.Server
+	DB                *sql.DB
+	WalletRepo        repository.WalletRepository
+	TransactionRepo   repository.TransactionRepository
+	Idempotency       *idempotency.Guard
+	WithdrawUseCase   usecase.WithdrawUseCase
+	DepositUseCase    usecase.DepositUseCase
+	TransferUseCase   usecase.TransferUseCase
+	ExchangeUseCase   usecase.ExchangeUseCase
+	HoldUseCase       usecase.HoldUseCase
+	ReversalUseCase   usecase.ReversalUseCase
+	AdjustmentUseCase usecase.AdjustmentUseCase
+	ApprovalUseCase   usecase.WithdrawalApprovalUseCase
+	BalanceService    service.BalanceService
+	HistoryService    service.TransactionHistoryService
+	LedgerService     service.LedgerService
+	WalletService     service.WalletService
+	LimitService      service.LimitService
+	UserService       service.UserService
+	APIKeyService     service.APIKeyService
+	AdminService      service.AdminService
+	Server            *infrahttp.Server
 }
 
 func main() {
@@ -61,9 +117,17 @@ func main() {
 
 	setupLogging(config.Debug)
 
-	container := setupContainer()
+	if args := flag.Args(); len(args) > 0 {
+		if args[0] != "migrate" {
+			log.Fatalf("❌ Unknown command %q; %s", args[0], migrateUsage)
+		}
+		if err := runMigrateCommand(args[1:]); err != nil {
+			log.Fatalf("❌ Migration failed: %v", err)
+		}
+		return
+	}
 
-	log.Printf("✅ Database connection established and migrations completed")
+	container := setupContainer(config)
 
 	if err := runApplication(container, config); err != nil {
 		log.Fatalf("Failed to run application: %v", err)
@@ -77,6 +141,25 @@ func parseFlags() *AppConfig {
 	portFlag := flag.String("port", "", "Server port")
 	debugFlag := flag.Bool("debug", false, "Enable debug logging")
 	failFastFlag := flag.Bool("fail-fast-db", true, "Fail to start if database connection fails")
+	idempotencyTTLFlag := flag.Duration("idempotency-ttl", 0, "How long idempotency keys are remembered")
+	storageFlag := flag.String("storage", "", "Storage backend: postgres or memory")
+	autoMigrateFlag := flag.Bool("auto-migrate", true, "Apply pending database migrations on startup")
+	ratesFileFlag := flag.String("rates-file", "", "JSON file of exchange rates")
+	spreadFlag := flag.Int64("fx-spread-bps", -1, "Spread kept on currency exchanges, in basis points")
+	quoteTTLFlag := flag.Duration("quote-ttl", 0, "How long exchange quotes can be redeemed")
+	holdTTLFlag := flag.Duration("hold-ttl", 0, "How long holds last when the request sets no expiry")
+	holdSweepFlag := flag.Duration("hold-sweep-interval", 0, "How often expired holds are released")
+	jwksFileFlag := flag.String("jwt-jwks-file", "", "JSON Web Key Set file with the RS256 token verification keys")
+	publicKeyFileFlag := flag.String("jwt-public-key-file", "", "PEM file with the RS256 token verification key")
+	issuerFlag := flag.String("jwt-issuer", "", "Required issuer of bearer tokens")
+	audienceFlag := flag.String("jwt-audience", "", "Required audience of bearer tokens")
+	leewayFlag := flag.Duration("jwt-leeway", 0, "Clock skew allowed when checking bearer token lifetimes")
+	insecureNoAuthFlag := flag.Bool("insecure-no-auth", false, "Run without bearer authentication when no verification key is configured; local development only")
+	signatureWindowFlag := flag.Duration("api-key-signature-window", 0, "How far the timestamp of a request signed with an API key may be from now")
+	approvalThresholdsFlag := flag.String("approval-thresholds", "", "Comma separated withdrawal amounts above which a second person must approve, e.g. \"10000.00 USD,5000.00 EUR\"")
+	approvalTTLFlag := flag.Duration("approval-ttl", 0, "How long a withdrawal request waits for approval before it expires")
+	rateLimitDefaultFlag := flag.String("rate-limit-default", "", "Requests per period each client may make on routes without a rule, e.g. 600/1m, or off")
+	rateLimitRulesFlag := flag.String("rate-limit-rules", "", "Semicolon separated per-route limits, e.g. \"POST /withdraw client=60/1m wallet=10/1m\", or off")
 
 	flag.Parse()
 
@@ -84,6 +167,31 @@ func parseFlags() *AppConfig {
 	config.ServerPort = getStringValue(*portFlag, "SERVER_PORT", DefaultServerPort)
 	config.Debug = *debugFlag || getEnvBool("DEBUG", false)
 	config.FailFastOnDBConnection = *failFastFlag || getEnvBool("FAIL_FAST_DB", true)
+	config.IdempotencyTTL = getDurationValue(*idempotencyTTLFlag, "IDEMPOTENCY_TTL", idempotency.DefaultTTL)
+	config.Storage = getStringValue(*storageFlag, "STORAGE", StoragePostgres)
+	config.AutoMigrate = *autoMigrateFlag && getEnvBool("AUTO_MIGRATE", true)
+	config.RatesFile = getStringValue(*ratesFileFlag, "RATES_FILE", "")
+	config.ExchangeSpreadBps = getInt64Value(*spreadFlag, "FX_SPREAD_BPS", appusecase.DefaultSpreadBps)
+	config.QuoteTTL = getDurationValue(*quoteTTLFlag, "QUOTE_TTL", appusecase.DefaultQuoteTTL)
+	config.HoldTTL = getDurationValue(*holdTTLFlag, "HOLD_TTL", appusecase.DefaultHoldTTL)
+	config.HoldSweepInterval = getDurationValue(*holdSweepFlag, "HOLD_SWEEP_INTERVAL", DefaultHoldSweepInterval)
+	config.JWTSecret = os.Getenv("JWT_HS256_SECRET")
+	config.JWTJWKSFile = getStringValue(*jwksFileFlag, "JWT_JWKS_FILE", "")
+	config.JWTPublicKeyFile = getStringValue(*publicKeyFileFlag, "JWT_PUBLIC_KEY_FILE", "")
+	config.JWTIssuer = getStringValue(*issuerFlag, "JWT_ISSUER", "")
+	config.JWTAudience = getStringValue(*audienceFlag, "JWT_AUDIENCE", "")
+	config.JWTLeeway = getDurationValue(*leewayFlag, "JWT_LEEWAY", DefaultJWTLeeway)
+	config.InsecureNoAuth = *insecureNoAuthFlag || getEnvBool("INSECURE_NO_AUTH", false)
+	config.SignatureWindow = getDurationValue(*signatureWindowFlag, "API_KEY_SIGNATURE_WINDOW", signing.DefaultWindow)
+	config.APIKeyEncryptionKey = os.Getenv("API_KEY_ENCRYPTION_KEY")
+	config.ApprovalThresholds = getStringValue(*approvalThresholdsFlag, "APPROVAL_THRESHOLDS", "")
+	config.ApprovalTTL = getDurationValue(*approvalTTLFlag, "APPROVAL_TTL", approvals.DefaultTTL)
+	config.RateLimitDefault = getStringValue(*rateLimitDefaultFlag, "RATE_LIMIT_DEFAULT", ratelimit.DefaultClientLimit)
+	config.RateLimitRules = getStringValue(*rateLimitRulesFlag, "RATE_LIMIT_RULES", ratelimit.DefaultRules)
+
+	if config.Storage != StoragePostgres && config.Storage != StorageMemory {
+		log.Fatalf("❌ Unknown storage backend %q, expected %q or %q", config.Storage, StoragePostgres, StorageMemory)
+	}
 
 	return config
 }
@@ -107,6 +215,32 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getInt64Value treats a negative flag value as unset, since zero is a valid
+// setting.
+func getInt64Value(flagValue int64, envKey string, defaultValue int64) int64 {
+	if flagValue >= 0 {
+		return flagValue
+	}
+	if value := os.Getenv(envKey); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed >= 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getDurationValue(flagValue time.Duration, envKey string, defaultValue time.Duration) time.Duration {
+	if flagValue > 0 {
+		return flagValue
+	}
+	if value := os.Getenv(envKey); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func setupLogging(debug bool) {
 	if debug {
 		log.SetFlags(log.LstdFlags | log.Lshortfile)
@@ -115,7 +249,188 @@ func setupLogging(debug bool) {
 	}
 }
 
-func setupContainer() *Container {
+// storage holds the repositories of one storage backend. db is nil for the
+// in-memory backend.
+type storage struct {
+	db              *sql.DB
+	unitOfWork      repository.UnitOfWork
+	userRepo        repository.UserRepository
+	apiKeyRepo      repository.APIKeyRepository
+	walletRepo      repository.WalletRepository
+	transactionRepo repository.TransactionRepository
+	idempotencyRepo repository.IdempotencyRepository
+	ledgerRepo      repository.LedgerRepository
+	limitRepo       repository.LimitRepository
+	auditRepo       repository.AuditRepository
+	approvalRepo    repository.WithdrawalRequestRepository
+}
+
+func setupContainer(config *AppConfig) *Container {
+	var store *storage
+	if config.Storage == StorageMemory {
+		store = setupMemoryStorage()
+	} else {
+		store = setupPostgresStorage(config)
+	}
+
+	idempotencyGuard := idempotency.NewGuard(store.idempotencyRepo, config.IdempotencyTTL)
+
+	approvalPolicy := setupApprovalPolicy(config)
+
+	withdrawUseCase := appusecase.NewWithdrawUseCase(store.unitOfWork, store.transactionRepo, idempotencyGuard, approvalPolicy)
+	depositUseCase := appusecase.NewDepositUseCase(store.unitOfWork, idempotencyGuard)
+	transferUseCase := appusecase.NewTransferUseCase(store.unitOfWork, idempotencyGuard, approvalPolicy)
+	exchangeUseCase := appusecase.NewExchangeUseCase(store.unitOfWork, setupRateProvider(config), idempotencyGuard, config.ExchangeSpreadBps, config.QuoteTTL, approvalPolicy)
+	holdUseCase := appusecase.NewHoldUseCase(store.unitOfWork, idempotencyGuard, config.HoldTTL, approvalPolicy)
+	reversalUseCase := appusecase.NewReversalUseCase(store.unitOfWork, idempotencyGuard)
+	adjustmentUseCase := appusecase.NewAdjustmentUseCase(store.unitOfWork, idempotencyGuard)
+	approvalUseCase := appusecase.NewWithdrawalApprovalUseCase(store.unitOfWork, idempotencyGuard)
+	BalanceService := appservice.NewBalanceUseCase(store.walletRepo)
+	historyService := appservice.NewTransactionHistoryService(store.walletRepo, store.transactionRepo)
+	ledgerService := appservice.NewLedgerService(store.unitOfWork, store.ledgerRepo)
+	walletService := appservice.NewWalletService(store.unitOfWork, store.walletRepo)
+	limitService := appservice.NewLimitService(store.unitOfWork, store.limitRepo, store.walletRepo, store.transactionRepo)
+	userService := appservice.NewUserService(store.unitOfWork, store.userRepo, store.walletRepo)
+	apiKeyService := appservice.NewAPIKeyService(store.unitOfWork, store.apiKeyRepo)
+	adminService := appservice.NewAdminService(store.walletRepo, store.transactionRepo, store.auditRepo, store.approvalRepo)
+	requestVerifier := signing.NewVerifier(store.apiKeyRepo, config.SignatureWindow)
+
+	server := infrahttp.NewServer(withdrawUseCase, depositUseCase, transferUseCase, BalanceService, historyService, ledgerService, walletService, exchangeUseCase, holdUseCase, reversalUseCase, limitService, userService, apiKeyService, adminService, adjustmentUseCase, approvalUseCase, setupAuthenticator(config), requestVerifier, setupRateLimiter(config))
+
+	return &Container{
+		DB:                store.db,
+		WalletRepo:        store.walletRepo,
+		TransactionRepo:   store.transactionRepo,
+		Idempotency:       idempotencyGuard,
+		WithdrawUseCase:   withdrawUseCase,
+		DepositUseCase:    depositUseCase,
+		TransferUseCase:   transferUseCase,
+		ExchangeUseCase:   exchangeUseCase,
+		HoldUseCase:       holdUseCase,
+		ReversalUseCase:   reversalUseCase,
+		AdjustmentUseCase: adjustmentUseCase,
+		ApprovalUseCase:   approvalUseCase,
+		BalanceService:    BalanceService,
+		HistoryService:    historyService,
+		LedgerService:     ledgerService,
+		WalletService:     walletService,
+		LimitService:      limitService,
+		UserService:       userService,
+		APIKeyService:     apiKeyService,
+		AdminService:      adminService,
+		Server:            server,
+	}
+}
+
+// setupApprovalPolicy parses the approval thresholds, such as
+// "10000.00 USD,5000.00 EUR". Without thresholds no withdrawal needs approval.
+func setupApprovalPolicy(config *AppConfig) approvals.Policy {
+	policy := approvals.Policy{Thresholds: map[string]int64{}, TTL: config.ApprovalTTL}
+
+	for _, raw := range strings.Split(config.ApprovalThresholds, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		threshold, err := valueobject.ParseMoney(raw)
+		if err != nil {
+			log.Fatalf("❌ Invalid approval threshold %q: %v", raw, err)
+		}
+		policy.Thresholds[threshold.Currency().Code()] = threshold.Amount()
+		log.Printf("✅ Withdrawals above %s need approval", threshold)
+	}
+
+	return policy
+}
+
+func setupRateProvider(config *AppConfig) service.RateProvider {
+	if config.RatesFile == "" {
+		provider, err := rates.NewDefaultProvider()
+		if err != nil {
+			log.Fatalf("❌ Failed to load built-in exchange rates: %v", err)
+		}
+		log.Printf("⚠️ Using built-in example exchange rates; set RATES_FILE for real rates")
+		return provider
+	}
+
+	provider, err := rates.NewFileProvider(config.RatesFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load exchange rates: %v", err)
+	}
+	log.Printf("💱 Loaded exchange rates from %s", config.RatesFile)
+	return provider
+}
+
+// setupRateLimiter builds the request throttle from the configured limits,
+// keeping its buckets in process memory. With every limit off, requests are
+// not throttled.
+func setupRateLimiter(config *AppConfig) infrahttp.RateLimiter {
+	var defaultLimit *ratelimit.Limit
+	if config.RateLimitDefault != ratelimit.Off {
+		limit, err := ratelimit.ParseLimit(config.RateLimitDefault)
+		if err != nil {
+			log.Fatalf("❌ Invalid RATE_LIMIT_DEFAULT: %v", err)
+		}
+		defaultLimit = &limit
+	}
+
+	rules, err := ratelimit.ParseRules(config.RateLimitRules)
+	if err != nil {
+		log.Fatalf("❌ Invalid RATE_LIMIT_RULES: %v", err)
+	}
+
+	if defaultLimit == nil && len(rules) == 0 {
+		log.Printf("⚠️ Rate limiting is disabled")
+		return nil
+	}
+
+	for _, rule := range rules {
+		log.Printf("✅ Rate limit on %s: client %v, wallet %v", rule.Route, rule.Client, rule.Wallet)
+	}
+	return ratelimit.NewLimiter(ratelimit.NewMemoryStore(), defaultLimit, rules)
+}
+
+// setupAuthenticator builds the bearer token verifier from the configured
+// keys. Without any key the service refuses to start, unless InsecureNoAuth
+// disables authentication; unsigned requests then run as auth.Anonymous,
+// which holds no privileges.
+func setupAuthenticator(config *AppConfig) infrahttp.Authenticator {
+	keys := jwt.NewKeySet()
+
+	if config.JWTSecret != "" {
+		if err := keys.AddSecret("", []byte(config.JWTSecret)); err != nil {
+			log.Fatalf("❌ Invalid JWT_HS256_SECRET: %v", err)
+		}
+	}
+	if config.JWTJWKSFile != "" {
+		if err := keys.LoadJWKSFile(config.JWTJWKSFile); err != nil {
+			log.Fatalf("❌ Failed to load token verification keys: %v", err)
+		}
+	}
+	if config.JWTPublicKeyFile != "" {
+		if err := keys.LoadPublicKeyFile("", config.JWTPublicKeyFile); err != nil {
+			log.Fatalf("❌ Failed to load token verification key: %v", err)
+		}
+	}
+
+	if keys.Empty() {
+		if !config.InsecureNoAuth {
+			log.Fatalf("❌ No token verification key; set JWT_HS256_SECRET, JWT_JWKS_FILE or JWT_PUBLIC_KEY_FILE, or INSECURE_NO_AUTH=true for local development")
+		}
+		log.Printf("⚠️ Authentication is disabled by INSECURE_NO_AUTH; unsigned requests run without privileges")
+		return nil
+	}
+
+	log.Printf("🔐 Requiring bearer tokens")
+	return jwt.NewVerifier(keys, jwt.Options{
+		Issuer:   config.JWTIssuer,
+		Audience: config.JWTAudience,
+		Leeway:   config.JWTLeeway,
+	})
+}
+
+func setupPostgresStorage(config *AppConfig) *storage {
+	keyCipher := setupKeyCipher(config)
+
 	// Connect to real database
 	dbConfig := database.NewDatabaseConfig()
 
@@ -125,22 +440,102 @@ func setupContainer() *Container {
 		log.Fatalf("❌ Failed to connect to database: %v", err)
 	}
 
+	if config.AutoMigrate {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			log.Fatalf("❌ Failed to load migrations: %v", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			log.Fatalf("❌ Failed to apply migrations: %v", err)
+		}
+		log.Printf("✅ Database connection established and migrations completed")
+	} else {
+		log.Printf("✅ Database connection established; automatic migrations are disabled")
+	}
+
+	apiKeyRepo := persistence.NewAPIKeyRepository(db, keyCipher)
+
+	sealed, err := apiKeyRepo.SealClearSigningKeys(context.Background())
+	if err != nil {
+		log.Fatalf("❌ Failed to seal the API key signing keys stored in clear; are migrations applied? %v", err)
+	}
+	if sealed > 0 {
+		log.Printf("🔐 Sealed %d API key signing keys stored in clear", sealed)
+	}
+
 	// Use real database repositories with SQL query execution
-	walletRepo := persistence.NewWalletRepository(db)
-	transactionRepo := persistence.NewTransactionRepository(db)
+	return &storage{
+		db:              db,
+		unitOfWork:      persistence.NewUnitOfWork(db, keyCipher),
+		userRepo:        persistence.NewUserRepository(db),
+		apiKeyRepo:      apiKeyRepo,
+		walletRepo:      persistence.NewWalletRepository(db),
+		transactionRepo: persistence.NewTransactionRepository(db),
+		idempotencyRepo: persistence.NewIdempotencyRepository(db),
+		ledgerRepo:      persistence.NewLedgerRepository(db),
+		limitRepo:       persistence.NewLimitRepository(db),
+		auditRepo:       persistence.NewAuditRepository(db),
+		approvalRepo:    persistence.NewWithdrawalRequestRepository(db),
+	}
+}
 
-	withdrawUseCase := appusecase.NewWithdrawUseCase(walletRepo, transactionRepo, db)
-	BalanceService := appservice.NewBalanceUseCase(walletRepo)
+// setupKeyCipher reads the key the signing keys of API keys are sealed with
+// in the database. There is no default: a signing key stored in clear would
+// let anyone reading the database sign requests.
+func setupKeyCipher(config *AppConfig) *signing.KeyCipher {
+	if config.APIKeyEncryptionKey == "" {
+		log.Fatalf("❌ No API key encryption key; set API_KEY_ENCRYPTION_KEY to 32 hex encoded bytes, e.g. the output of openssl rand -hex 32")
+	}
 
-	server := infrahttp.NewServer(withdrawUseCase, BalanceService)
+	keyCipher, err := signing.ParseKeyCipher(config.APIKeyEncryptionKey)
+	if err != nil {
+		log.Fatalf("❌ Invalid API_KEY_ENCRYPTION_KEY: %v", err)
+	}
+	return keyCipher
+}
 
-	return &Container{
-		DB:              db,
-		WalletRepo:      walletRepo,
-		TransactionRepo: transactionRepo,
-		WithdrawUseCase: withdrawUseCase,
-		BalanceService:  BalanceService,
-		Server:          server,
+// demoWallets are created when running with in-memory storage, matching the
+// users and wallets inserted by database/seed.sql.
+var demoWallets = []struct {
+	userID  string
+	name    string
+	balance string
+}{
+	{"550e8400-e29b-41d4-a716-446655440000", "rio", "1000.00 USD"},
+	{"550e8400-e29b-41d4-a716-446655440001", "raihan", "500.00 USD"},
+}
+
+func setupMemoryStorage() *storage {
+	store := memory.NewStore()
+
+	for _, demo := range demoWallets {
+		userID, err := valueobject.NewUserID(demo.userID)
+		if err != nil {
+			log.Fatalf("❌ Invalid demo wallet user ID %s: %v", demo.userID, err)
+		}
+		balance, err := valueobject.ParseMoney(demo.balance)
+		if err != nil {
+			log.Fatalf("❌ Invalid demo wallet balance %s: %v", demo.balance, err)
+		}
+
+		store.AddUser(entity.ReconstructUser(userID, demo.name, "", time.Now().UTC()))
+		store.AddWallet(userID, balance)
+		log.Printf("👛 Seeded in-memory wallet for user %s with balance %s", demo.userID, demo.balance)
+	}
+
+	log.Printf("⚠️ Using in-memory storage; all data is lost on shutdown")
+
+	return &storage{
+		unitOfWork:      memory.NewUnitOfWork(store),
+		userRepo:        memory.NewUserRepository(store),
+		apiKeyRepo:      memory.NewAPIKeyRepository(store),
+		walletRepo:      memory.NewWalletRepository(store),
+		transactionRepo: memory.NewTransactionRepository(store),
+		idempotencyRepo: memory.NewIdempotencyRepository(store),
+		ledgerRepo:      memory.NewLedgerRepository(store),
+		limitRepo:       memory.NewLimitRepository(store),
+		auditRepo:       memory.NewAuditRepository(store),
+		approvalRepo:    memory.NewWithdrawalRequestRepository(store),
 	}
 }
 
@@ -156,10 +551,31 @@ func runApplication(container *Container, config *AppConfig) error {
 
 	serverErrors := make(chan error, 1)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go purgeIdempotencyKeys(jobsCtx, container.Idempotency)
+	go releaseExpiredHolds(jobsCtx, container.HoldUseCase, config.HoldSweepInterval)
+	go expireWithdrawalRequests(jobsCtx, container.ApprovalUseCase, config.HoldSweepInterval)
+
 	go func() {
 		log.Printf("Starting wallet service on %s", serverAddr)
 		log.Printf("  Withdraw: POST http://%s/withdraw", serverAddr)
-		log.Printf("  Balance:  GET  http://%s/balance?user_id=<uuid>", serverAddr)
+		log.Printf("  Deposit:  POST http://%s/deposit", serverAddr)
+		log.Printf("  Transfer: POST http://%s/transfers", serverAddr)
+		log.Printf("  Exchange: POST http://%s/exchange/quotes, POST http://%s/exchange", serverAddr, serverAddr)
+		log.Printf("  Holds:    POST http://%s/holds, POST http://%s/holds/<hold_id>/capture|release", serverAddr, serverAddr)
+		log.Printf("  Reverse:  POST http://%s/transactions/<transaction_id>/reverse", serverAddr)
+		log.Printf("  Balance:  GET  http://%s/balance?wallet_id=<uuid>", serverAddr)
+		log.Printf("  Users:    POST http://%s/users, GET http://%s/users/<user_id>", serverAddr, serverAddr)
+		log.Printf("  Wallets:  GET  http://%s/users/<user_id>/wallets", serverAddr)
+		log.Printf("  History:  GET  http://%s/wallets/<wallet_id>/transactions", serverAddr)
+		log.Printf("  Ledger:   GET  http://%s/wallets/<wallet_id>/ledger/verify", serverAddr)
+		log.Printf("  Limits:   GET  http://%s/admin/limits/tiers, GET|PUT http://%s/admin/wallets/<wallet_id>/limits", serverAddr, serverAddr)
+		log.Printf("  API keys: POST http://%s/admin/api-keys, POST http://%s/admin/api-keys/<key_id>/revoke", serverAddr, serverAddr)
+		log.Printf("  Admin:    GET  http://%s/admin/wallets, POST http://%s/admin/wallets/<wallet_id>/adjustments", serverAddr, serverAddr)
+		log.Printf("  Audit:    GET  http://%s/admin/transactions/<transaction_id>, GET http://%s/admin/audit", serverAddr, serverAddr)
+		log.Printf("  Approve:  GET  http://%s/admin/withdrawal-requests, POST http://%s/admin/withdrawal-requests/<request_id>/approve|reject", serverAddr, serverAddr)
 
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			serverErrors <- fmt.Errorf("server failed to start: %w", err)
@@ -179,6 +595,73 @@ func runApplication(container *Container, config *AppConfig) error {
 	}
 }
 
+// purgeIdempotencyKeys periodically removes idempotency keys whose replay
+// window has passed.
+func purgeIdempotencyKeys(ctx context.Context, guard *idempotency.Guard) {
+	ticker := time.NewTicker(IdempotencyPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := guard.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("❌ Failed to purge expired idempotency keys: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("🧹 Purged %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+// releaseExpiredHolds periodically returns the funds of holds that were
+// neither captured nor released before they expired.
+func releaseExpiredHolds(ctx context.Context, holdUseCase usecase.HoldUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := holdUseCase.ReleaseExpiredHolds(ctx, time.Now())
+			if err != nil {
+				log.Printf("❌ Failed to release expired holds: %v", err)
+			}
+			if released > 0 {
+				log.Printf("🧹 Released %d expired holds", released)
+			}
+		}
+	}
+}
+
+// expireWithdrawalRequests periodically expires the withdrawal requests nobody
+// decided in time and releases their amounts.
+func expireWithdrawalRequests(ctx context.Context, approvalUseCase usecase.WithdrawalApprovalUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := approvalUseCase.ExpireRequests(ctx, time.Now())
+			if err != nil {
+				log.Printf("❌ Failed to expire withdrawal requests: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("🧹 Expired %d withdrawal requests", expired)
+			}
+		}
+	}
+}
+
 func gracefulShutdown(server *http.Server) error {
 	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
 	defer cancel()
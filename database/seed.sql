@@ -0,0 +1,11 @@
+-- Sample data for local development. Apply after the migrations:
+--   psql -d wallet_db -f database/seed.sql
+-- The same wallets are created by `service -storage=memory`.
+
+INSERT INTO users (id, name) VALUES
+    ('550e8400-e29b-41d4-a716-446655440000', 'rio'),
+    ('550e8400-e29b-41d4-a716-446655440001', 'raihan');
+
+INSERT INTO wallets (user_id, balance, currency) VALUES
+    ('550e8400-e29b-41d4-a716-446655440000', 100000, 'USD'), -- $1000.00
+    ('550e8400-e29b-41d4-a716-446655440001', 50000, 'USD');   -- $500.00
@@ -0,0 +1,69 @@
+// Package approvals decides which withdrawals wait for a second person's
+// approval and describes the requests that do.
+package approvals
+
+import (
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// DefaultTTL is how long a withdrawal request waits for a decision when the
+// configuration sets no expiry.
+const DefaultTTL = 24 * time.Hour
+
+// Policy holds the approval thresholds, in minor units keyed by currency
+// code, and how long a request waits before it expires. Withdrawals in a
+// currency without a threshold never need approval.
+type Policy struct {
+	Thresholds map[string]int64
+	TTL        time.Duration
+}
+
+// Requires reports whether a withdrawal of amount is above its currency's
+// threshold.
+func (p Policy) Requires(amount valueobject.Money) bool {
+	threshold, ok := p.Thresholds[amount.Currency().Code()]
+	return ok && amount.Amount() > threshold
+}
+
+// RequestTTL returns the configured expiry, or DefaultTTL.
+func (p Policy) RequestTTL() time.Duration {
+	if p.TTL <= 0 {
+		return DefaultTTL
+	}
+	return p.TTL
+}
+
+// Response describes request. wallet adds the owner and events the history;
+// either may be nil.
+func Response(request *entity.WithdrawalRequest, wallet *entity.Wallet, events []*entity.WithdrawalRequestEvent) dto.WithdrawalRequestResponse {
+	response := dto.WithdrawalRequestResponse{
+		RequestID:   request.ID().String(),
+		WalletID:    request.WalletID().String(),
+		Amount:      request.Amount().Amount(),
+		Currency:    request.Amount().Currency().Code(),
+		Status:      string(request.Status()),
+		RequestedBy: request.RequestedBy(),
+		DecidedBy:   request.DecidedBy(),
+		ExpiresAt:   request.ExpiresAt().UTC().Format(time.RFC3339Nano),
+		CreatedAt:   request.CreatedAt().UTC().Format(time.RFC3339Nano),
+	}
+	if wallet != nil {
+		response.UserID = wallet.UserID().String()
+	}
+	if transactionID := request.TransactionID(); transactionID != nil {
+		response.TransactionID = transactionID.String()
+	}
+	for _, event := range events {
+		response.History = append(response.History, dto.WithdrawalRequestEventResponse{
+			Action:     string(event.Action()),
+			Actor:      event.Actor(),
+			Reason:     event.Reason(),
+			OccurredAt: event.OccurredAt().UTC().Format(time.RFC3339Nano),
+		})
+	}
+	return response
+}
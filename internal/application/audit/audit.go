@@ -0,0 +1,32 @@
+// Package audit records the changes made through the admin API. Entries are
+// written through the audit repository of the change's own unit of work, so
+// a change and its entry commit or roll back together. Reads are not
+// audited.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/application/auth"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+)
+
+// Record appends an entry for action on the target, attributed to the caller
+// in ctx. It fails with domain.ErrUnauthenticated when ctx carries no caller,
+// so that no change goes unattributed.
+func Record(ctx context.Context, repo repository.AuditRepository, action entity.AuditAction, targetType entity.AuditTargetType, targetID, reason string, details map[string]string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+
+	entry, err := entity.NewAuditEntry(principal.Subject, action, targetType, targetID, reason, details, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return repo.InsertAuditEntry(ctx, entry)
+}
@@ -0,0 +1,186 @@
+// Package auth carries the authenticated caller through a request and decides
+// what the caller may act on. Authentication itself, verifying the bearer
+// token, happens in the HTTP layer.
+package auth
+
+import (
+	"context"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	// ScopeAdmin allows changing configuration, such as limits and wallet
+	// statuses, and acting on any wallet.
+	ScopeAdmin = "admin"
+	// ScopeOperator allows acting on any wallet, for example on behalf of a
+	// customer, without access to configuration.
+	ScopeOperator = "operator"
+	// ScopeViewer and ScopeSupervisor are, with ScopeOperator, the roles of
+	// back-office staff; see HasRole.
+	ScopeViewer     = "viewer"
+	ScopeSupervisor = "supervisor"
+	// ScopeBalanceRead, ScopeWithdraw and ScopeDeposit are granted to API
+	// keys, which may only make the calls their scopes name. ScopeDeposit may
+	// also be granted to the bearer tokens of services.
+	ScopeBalanceRead = entity.APIKeyScopeBalanceRead
+	ScopeWithdraw    = entity.APIKeyScopeWithdraw
+	ScopeDeposit     = entity.APIKeyScopeDeposit
+)
+
+// Principal is the authenticated caller. Subject is the user ID of a
+// customer; the subjects of staff and services need not be user IDs. KeyID is
+// set when the caller signed the request with an API key, whose Subject is the
+// key's owner.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	KeyID   string
+}
+
+// Anonymous is the principal of every unsigned request when authentication
+// is disabled for local development. It holds no scopes and owns no wallet,
+// so it may only reach what needs no authorization.
+func Anonymous() Principal {
+	return Principal{
+		Subject: "anonymous",
+	}
+}
+
+// IsAnonymous reports whether the principal is Anonymous.
+func (p Principal) IsAnonymous() bool {
+	return p.Subject == Anonymous().Subject && p.KeyID == ""
+}
+
+func (p Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// roleRanks orders the back-office roles. Each role includes the ones ranked
+// below it, and the admin scope includes them all.
+var roleRanks = map[string]int{
+	ScopeViewer:     1,
+	ScopeOperator:   2,
+	ScopeSupervisor: 3,
+	ScopeAdmin:      4,
+}
+
+// HasRole reports whether the principal holds role or a role ranked above it:
+// viewers may look up wallets, transactions and configuration, operators may
+// also adjust balances and freeze wallets, and supervisors may also unfreeze
+// and close wallets, change limits and read the audit trail.
+func (p Principal) HasRole(role string) bool {
+	required, ok := roleRanks[role]
+	if !ok {
+		return false
+	}
+	for _, granted := range p.Scopes {
+		if rank, ok := roleRanks[granted]; ok && rank >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// Privileged reports whether the principal may act on wallets it does not
+// own. Viewers may only read through the admin routes.
+func (p Principal) Privileged() bool {
+	return p.HasRole(ScopeOperator)
+}
+
+// CanActFor reports whether the principal may act on the wallets of userID.
+func (p Principal) CanActFor(userID valueobject.UserID) bool {
+	return p.Privileged() || p.Subject == userID.String()
+}
+
+type contextKey struct{}
+
+// WithPrincipal attaches the authenticated caller to ctx.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the caller attached to ctx, if any.
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+	return principal, ok
+}
+
+// Authorize checks that the caller in ctx may act on the wallets of owner. It
+// fails with domain.ErrUnauthenticated when ctx carries no caller and with
+// domain.ErrForbidden when the caller is someone else without a privileged
+// scope.
+func Authorize(ctx context.Context, owner valueobject.UserID) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+	if !principal.CanActFor(owner) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// Identify returns the caller in ctx when it can be told apart from other
+// callers, as the maker-checker rule needs. It fails with
+// domain.ErrUnauthenticated when ctx carries no caller or only Anonymous, which
+// every unsigned request shares.
+func Identify(ctx context.Context) (Principal, error) {
+	principal, ok := FromContext(ctx)
+	if !ok || principal.IsAnonymous() {
+		return Principal{}, domain.ErrUnauthenticated
+	}
+	return principal, nil
+}
+
+// RequireRole checks that the caller in ctx holds role or a role ranked above
+// it.
+func RequireRole(ctx context.Context, role string) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+	if !principal.HasRole(role) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// RequireRoleOrScope checks that the caller in ctx holds role, a role ranked
+// above it, or one of scopes.
+func RequireRoleOrScope(ctx context.Context, role string, scopes ...string) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+	if principal.HasRole(role) {
+		return nil
+	}
+	for _, scope := range scopes {
+		if principal.HasScope(scope) {
+			return nil
+		}
+	}
+	return domain.ErrForbidden
+}
+
+// RequireScope checks that the caller in ctx holds at least one of scopes.
+func RequireScope(ctx context.Context, scopes ...string) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+	for _, scope := range scopes {
+		if principal.HasScope(scope) {
+			return nil
+		}
+	}
+	return domain.ErrForbidden
+}
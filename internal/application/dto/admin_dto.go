@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// AdjustmentRequest credits or debits a wallet by hand. Every adjustment needs
+// a reason code; OTHER also needs a note.
+type AdjustmentRequest struct {
+	Direction  string `json:"direction" validate:"required,oneof=CREDIT DEBIT"`
+	Amount     int64  `json:"amount" validate:"required,gt=0"`
+	Currency   string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	ReasonCode string `json:"reason_code" validate:"required,oneof=CORRECTION GOODWILL FEE_REFUND CHARGEBACK FRAUD_RECOVERY OTHER"`
+	Note       string `json:"note,omitempty" validate:"max=200"`
+}
+
+type AdjustmentResponse struct {
+	TransactionID string `json:"transaction_id,omitempty"`
+	WalletID      string `json:"wallet_id"`
+	UserID        string `json:"user_id,omitempty"`
+	Direction     string `json:"direction"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	ReasonCode    string `json:"reason_code"`
+	Note          string `json:"note,omitempty"`
+	NewBalance    int64  `json:"new_balance"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message,omitempty"`
+}
+
+type WalletSearchQuery struct {
+	UserID   string
+	Statuses []string
+	Tiers    []string
+	Currency string
+	Name     string
+	Cursor   string
+	Limit    int
+}
+
+type WalletSearchResponse struct {
+	Wallets    []WalletResponse `json:"wallets"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type AuditLogQuery struct {
+	Actor    string
+	Actions  []string
+	TargetID string
+	From     *time.Time
+	To       *time.Time
+	Cursor   string
+	Limit    int
+}
+
+type AuditEntryResponse struct {
+	EntryID    string            `json:"entry_id"`
+	Actor      string            `json:"actor"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+	Reason     string            `json:"reason,omitempty"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  string            `json:"created_at"`
+}
+
+type AuditLogResponse struct {
+	Entries    []AuditEntryResponse `json:"entries"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
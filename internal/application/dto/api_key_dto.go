@@ -0,0 +1,29 @@
+package dto
+
+type CreateAPIKeyRequest struct {
+	UserID string   `json:"user_id" validate:"required,uuid"`
+	Name   string   `json:"name" validate:"required,max=50"`
+	Scopes []string `json:"scopes" validate:"required,dive,oneof=balance:read withdraw deposit admin"`
+}
+
+// APIKeyResponse describes an API key. The secret is never part of it.
+type APIKeyResponse struct {
+	KeyID     string   `json:"key_id"`
+	UserID    string   `json:"user_id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	RevokedAt string   `json:"revoked_at,omitempty"`
+}
+
+// IssuedAPIKeyResponse is returned once, when a key is issued; it is the only
+// time the secret is shown.
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Secret string `json:"secret"`
+}
+
+type APIKeyListResponse struct {
+	UserID string           `json:"user_id"`
+	Keys   []APIKeyResponse `json:"keys"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+type ExchangeQuoteRequest struct {
+	FromWalletID string `json:"from_wallet_id" validate:"required,uuid"`
+	ToWalletID   string `json:"to_wallet_id" validate:"required,uuid,nefield=FromWalletID"`
+	Amount       int64  `json:"amount" validate:"required,gt=0"`
+	Currency     string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+// ExchangeQuoteResponse prices an exchange: source_amount is debited from the
+// source wallet, fee included, and target_amount is credited to the target
+// wallet. The quote can be redeemed once, until expires_at.
+type ExchangeQuoteResponse struct {
+	QuoteID        string `json:"quote_id"`
+	UserID         string `json:"user_id"`
+	FromWalletID   string `json:"from_wallet_id"`
+	ToWalletID     string `json:"to_wallet_id"`
+	Rate           string `json:"rate"`
+	SourceAmount   int64  `json:"source_amount"`
+	SourceCurrency string `json:"source_currency"`
+	Fee            int64  `json:"fee"`
+	TargetAmount   int64  `json:"target_amount"`
+	TargetCurrency string `json:"target_currency"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+type ExchangeRequest struct {
+	QuoteID string `json:"quote_id" validate:"required,uuid"`
+}
+
+type ExchangeResponse struct {
+	QuoteID        string `json:"quote_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+	FromWalletID   string `json:"from_wallet_id,omitempty"`
+	ToWalletID     string `json:"to_wallet_id,omitempty"`
+	Rate           string `json:"rate,omitempty"`
+	AmountDebited  int64  `json:"amount_debited"`
+	SourceCurrency string `json:"source_currency,omitempty"`
+	Fee            int64  `json:"fee"`
+	AmountCredited int64  `json:"amount_credited"`
+	TargetCurrency string `json:"target_currency,omitempty"`
+	NewBalance     int64  `json:"new_balance"`
+	Success        bool   `json:"success"`
+	Message        string `json:"message,omitempty"`
+}
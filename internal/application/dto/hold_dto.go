@@ -0,0 +1,33 @@
+package dto
+
+type HoldRequest struct {
+	UserID           string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID         string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount           int64  `json:"amount" validate:"required,gt=0"`
+	Currency         string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds,omitempty" validate:"omitempty,gt=0"`
+}
+
+// CaptureHoldRequest captures part of a hold; without an amount the whole
+// hold is captured.
+type CaptureHoldRequest struct {
+	Amount   int64  `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+// HoldResponse describes a hold and the balances of its wallet. Once captured,
+// transaction_id is the withdrawal that settled captured_amount.
+type HoldResponse struct {
+	HoldID           string `json:"hold_id"`
+	WalletID         string `json:"wallet_id"`
+	UserID           string `json:"user_id,omitempty"`
+	Amount           int64  `json:"amount"`
+	CapturedAmount   int64  `json:"captured_amount"`
+	Currency         string `json:"currency"`
+	Status           string `json:"status"`
+	TransactionID    string `json:"transaction_id,omitempty"`
+	ExpiresAt        string `json:"expires_at"`
+	Balance          int64  `json:"balance"`
+	AvailableBalance int64  `json:"available_balance"`
+	HeldBalance      int64  `json:"held_balance"`
+}
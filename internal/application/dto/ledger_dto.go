@@ -0,0 +1,25 @@
+package dto
+
+type LedgerVerificationResponse struct {
+	UserID              string `json:"user_id"`
+	WalletID            string `json:"wallet_id"`
+	Currency            string `json:"currency"`
+	CachedBalance       int64  `json:"cached_balance"`
+	LedgerBalance       int64  `json:"ledger_balance"`
+	LedgerAccountOpened bool   `json:"ledger_account_opened"`
+	Consistent          bool   `json:"consistent"`
+}
+
+// TrialBalanceResponse reports the ledger totals per currency; the ledger is
+// balanced when every currency is.
+type TrialBalanceResponse struct {
+	Currencies []CurrencyTrialBalance `json:"currencies"`
+	Balanced   bool                   `json:"balanced"`
+}
+
+type CurrencyTrialBalance struct {
+	Currency     string `json:"currency"`
+	TotalDebits  int64  `json:"total_debits"`
+	TotalCredits int64  `json:"total_credits"`
+	Balanced     bool   `json:"balanced"`
+}
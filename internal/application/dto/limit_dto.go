@@ -0,0 +1,54 @@
+package dto
+
+// WithdrawalLimits holds withdrawal limits in minor units of their currency.
+// An omitted limit does not apply; in a wallet's overrides it keeps the tier's
+// limit.
+type WithdrawalLimits struct {
+	MinAmount      *int64 `json:"min_amount,omitempty" validate:"omitempty,gt=0"`
+	PerTransaction *int64 `json:"per_transaction,omitempty" validate:"omitempty,gt=0"`
+	Daily          *int64 `json:"daily,omitempty" validate:"omitempty,gt=0"`
+	Monthly        *int64 `json:"monthly,omitempty" validate:"omitempty,gt=0"`
+}
+
+type SetTierLimitsRequest struct {
+	Currency string           `json:"currency" validate:"required,len=3,alpha"`
+	Limits   WithdrawalLimits `json:"limits"`
+}
+
+type TierLimitsResponse struct {
+	Tier     string           `json:"tier"`
+	Currency string           `json:"currency"`
+	Limits   WithdrawalLimits `json:"limits"`
+}
+
+type TierLimitsListResponse struct {
+	Tiers []TierLimitsResponse `json:"tiers"`
+}
+
+// SetWalletLimitsRequest changes a wallet's tier, its overrides, or both. An
+// omitted field is left as it is; empty overrides remove them.
+type SetWalletLimitsRequest struct {
+	Tier      string            `json:"tier,omitempty" validate:"omitempty,oneof=STANDARD PREMIUM"`
+	Overrides *WithdrawalLimits `json:"overrides,omitempty"`
+}
+
+// LimitUsage reports what a wallet withdrew in the current UTC day and month.
+// The remaining amounts are absent when no limit applies to the window;
+// remaining_today also accounts for the monthly limit.
+type LimitUsage struct {
+	WithdrawnToday     int64  `json:"withdrawn_today"`
+	WithdrawnThisMonth int64  `json:"withdrawn_this_month"`
+	RemainingToday     *int64 `json:"remaining_today,omitempty"`
+	RemainingThisMonth *int64 `json:"remaining_this_month,omitempty"`
+}
+
+type WalletLimitsResponse struct {
+	WalletID   string           `json:"wallet_id"`
+	UserID     string           `json:"user_id"`
+	Tier       string           `json:"tier"`
+	Currency   string           `json:"currency"`
+	TierLimits WithdrawalLimits `json:"tier_limits"`
+	Overrides  WithdrawalLimits `json:"overrides"`
+	Effective  WithdrawalLimits `json:"effective"`
+	Usage      LimitUsage       `json:"usage"`
+}
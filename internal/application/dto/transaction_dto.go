@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+type TransactionHistoryQuery struct {
+	Types     []string
+	Statuses  []string
+	MinAmount *int64
+	MaxAmount *int64
+	From      *time.Time
+	To        *time.Time
+	Cursor    string
+	Limit     int
+}
+
+type TransactionResponse struct {
+	ID            string `json:"id"`
+	WalletID      string `json:"wallet_id"`
+	Type          string `json:"type"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	TransferID    string `json:"transfer_id,omitempty"`
+	ReversalOf    string `json:"reversal_of,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type TransactionHistoryResponse struct {
+	UserID       string                `json:"user_id"`
+	WalletID     string                `json:"wallet_id"`
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// ReverseTransactionRequest reverses a withdrawal; without an amount the whole
+// withdrawal is reversed.
+type ReverseTransactionRequest struct {
+	Amount   int64  `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+type ReversalResponse struct {
+	ReversalID            string `json:"reversal_id"`
+	OriginalTransactionID string `json:"original_transaction_id"`
+	WalletID              string `json:"wallet_id"`
+	UserID                string `json:"user_id,omitempty"`
+	AmountReversed        int64  `json:"amount_reversed"`
+	OriginalAmount        int64  `json:"original_amount"`
+	Currency              string `json:"currency"`
+	Reason                string `json:"reason"`
+	NewBalance            int64  `json:"new_balance"`
+	Success               bool   `json:"success"`
+	Message               string `json:"message,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+type CreateUserRequest struct {
+	Name        string `json:"name" validate:"required,max=50"`
+	ExternalRef string `json:"external_ref,omitempty" validate:"omitempty,max=64"`
+	Currency    string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+// UserResponse describes a user and, when they have one, their default
+// wallet.
+type UserResponse struct {
+	UserID        string          `json:"user_id"`
+	Name          string          `json:"name"`
+	ExternalRef   string          `json:"external_ref,omitempty"`
+	CreatedAt     string          `json:"created_at"`
+	DefaultWallet *WalletResponse `json:"default_wallet,omitempty"`
+}
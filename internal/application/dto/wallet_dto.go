@@ -1,24 +1,129 @@
 package dto
 
 type WithdrawRequest struct {
-	UserID string `json:"user_id" validate:"required,uuid"`
-	Amount int64  `json:"amount" validate:"required,gt=0"`
+	UserID   string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount   int64  `json:"amount" validate:"required,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
 }
 
+// WithdrawResponse describes an executed withdrawal or, when
+// withdrawal_request_id is set, one waiting for approval: nothing has been
+// withdrawn yet and the amount is held until the request is decided or
+// expires at approval_expires_at.
 type WithdrawResponse struct {
-	UserID          string `json:"user_id"`
-	AmountWithdrawn int64  `json:"amount_withdrawn"`
+	UserID              string `json:"user_id,omitempty"`
+	WalletID            string `json:"wallet_id,omitempty"`
+	AmountWithdrawn     int64  `json:"amount_withdrawn"`
+	Currency            string `json:"currency"`
+	NewBalance          int64  `json:"new_balance"`
+	Success             bool   `json:"success"`
+	Message             string `json:"message,omitempty"`
+	WithdrawalRequestID string `json:"withdrawal_request_id,omitempty"`
+	ApprovalExpiresAt   string `json:"approval_expires_at,omitempty"`
+}
+
+type DepositRequest struct {
+	UserID   string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount   int64  `json:"amount" validate:"required,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type DepositResponse struct {
+	UserID          string `json:"user_id,omitempty"`
+	WalletID        string `json:"wallet_id,omitempty"`
+	AmountDeposited int64  `json:"amount_deposited"`
+	Currency        string `json:"currency"`
 	NewBalance      int64  `json:"new_balance"`
 	Success         bool   `json:"success"`
 	Message         string `json:"message,omitempty"`
 }
 
+type TransferRequest struct {
+	FromUserID   string `json:"from_user_id,omitempty" validate:"required_without=FromWalletID,excluded_with=FromWalletID,omitempty,uuid"`
+	FromWalletID string `json:"from_wallet_id,omitempty" validate:"omitempty,uuid"`
+	ToUserID     string `json:"to_user_id,omitempty" validate:"required_without=ToWalletID,excluded_with=ToWalletID,omitempty,uuid,nefield=FromUserID"`
+	ToWalletID   string `json:"to_wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount       int64  `json:"amount" validate:"required,gt=0"`
+	Currency     string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type TransferResponse struct {
+	TransferID        string `json:"transfer_id,omitempty"`
+	FromUserID        string `json:"from_user_id,omitempty"`
+	FromWalletID      string `json:"from_wallet_id,omitempty"`
+	ToUserID          string `json:"to_user_id,omitempty"`
+	ToWalletID        string `json:"to_wallet_id,omitempty"`
+	AmountTransferred int64  `json:"amount_transferred"`
+	Currency          string `json:"currency"`
+	NewBalance        int64  `json:"new_balance"`
+	Success           bool   `json:"success"`
+	Message           string `json:"message,omitempty"`
+}
+
+// BalanceResponse carries the balance in minor units of its currency, plus the
+// same amount formatted as a decimal string such as "10.50 USD". The balance
+// includes funds reserved by holds and is negative while the wallet is
+// overdrawn; available_balance excludes held funds and includes the unused
+// overdraft.
 type BalanceResponse struct {
-	UserID  string `json:"user_id"`
-	Balance int64  `json:"balance"`
+	UserID           string `json:"user_id,omitempty"`
+	WalletID         string `json:"wallet_id,omitempty"`
+	Balance          int64  `json:"balance"`
+	AvailableBalance int64  `json:"available_balance"`
+	HeldBalance      int64  `json:"held_balance"`
+	OverdraftLimit   int64  `json:"overdraft_limit"`
+	Status           string `json:"status,omitempty"`
+	Currency         string `json:"currency,omitempty"`
+	FormattedBalance string `json:"formatted_balance,omitempty"`
+}
+
+type CreateWalletRequest struct {
+	Name     string `json:"name" validate:"required,max=50"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type WalletResponse struct {
+	WalletID         string `json:"wallet_id"`
+	UserID           string `json:"user_id"`
+	Name             string `json:"name"`
+	IsDefault        bool   `json:"is_default"`
+	Tier             string `json:"tier"`
+	Status           string `json:"status"`
+	Balance          int64  `json:"balance"`
+	AvailableBalance int64  `json:"available_balance"`
+	HeldBalance      int64  `json:"held_balance"`
+	OverdraftLimit   int64  `json:"overdraft_limit"`
+	Currency         string `json:"currency"`
+	FormattedBalance string `json:"formatted_balance"`
+}
+
+type SetOverdraftLimitRequest struct {
+	OverdraftLimit int64  `json:"overdraft_limit" validate:"gte=0"`
+	Currency       string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type ChangeWalletStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=ACTIVE FROZEN DEBIT_BLOCKED CLOSED"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+type WalletStatusChangeResponse struct {
+	ChangeID   string `json:"change_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+type WalletStatusHistoryResponse struct {
+	WalletID string                       `json:"wallet_id"`
+	Status   string                       `json:"status"`
+	Changes  []WalletStatusChangeResponse `json:"changes"`
 }
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+type WalletListResponse struct {
+	UserID  string           `json:"user_id"`
+	Wallets []WalletResponse `json:"wallets"`
 }
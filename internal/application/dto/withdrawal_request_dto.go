@@ -0,0 +1,45 @@
+package dto
+
+// WithdrawalDecisionRequest approves or rejects a withdrawal request. The
+// reason is optional for approvals and required for rejections.
+type WithdrawalDecisionRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=255"`
+}
+
+type WithdrawalRequestQuery struct {
+	WalletID    string
+	Statuses    []string
+	RequestedBy string
+	Cursor      string
+	Limit       int
+}
+
+// WithdrawalRequestResponse describes a withdrawal waiting for, or decided by,
+// approval. Once approved, transaction_id is the executed withdrawal. History
+// is only included when a single request is read.
+type WithdrawalRequestResponse struct {
+	RequestID     string                           `json:"request_id"`
+	WalletID      string                           `json:"wallet_id"`
+	UserID        string                           `json:"user_id,omitempty"`
+	Amount        int64                            `json:"amount"`
+	Currency      string                           `json:"currency"`
+	Status        string                           `json:"status"`
+	RequestedBy   string                           `json:"requested_by"`
+	DecidedBy     string                           `json:"decided_by,omitempty"`
+	TransactionID string                           `json:"transaction_id,omitempty"`
+	ExpiresAt     string                           `json:"expires_at"`
+	CreatedAt     string                           `json:"created_at"`
+	History       []WithdrawalRequestEventResponse `json:"history,omitempty"`
+}
+
+type WithdrawalRequestEventResponse struct {
+	Action     string `json:"action"`
+	Actor      string `json:"actor"`
+	Reason     string `json:"reason,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+type WithdrawalRequestListResponse struct {
+	Requests   []WithdrawalRequestResponse `json:"requests"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
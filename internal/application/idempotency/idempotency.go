@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"bank/internal/application/auth"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+)
+
+const (
+	// HeaderName is the request header clients use to make retries safe.
+	HeaderName = "Idempotency-Key"
+
+	// MaxKeyLength bounds the size of client supplied keys.
+	MaxKeyLength = 255
+
+	DefaultTTL = 24 * time.Hour
+)
+
+type contextKey struct{}
+
+// WithKey attaches the client supplied idempotency key to ctx.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// KeyFromContext returns the idempotency key attached to ctx, if any.
+func KeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(contextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// HashRequest fingerprints an operation and its inputs so that a key reused
+// for a different request can be detected.
+func HashRequest(operation string, parts ...string) string {
+	sum := sha256.Sum256([]byte(operation + "\n" + strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Guard stores and replays responses through the idempotency repository of the
+// caller's unit of work, so the stored response commits or rolls back together
+// with the balance change it describes.
+type Guard struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func NewGuard(repo repository.IdempotencyRepository, ttl time.Duration) *Guard {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Guard{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+// Replay looks up the key attached to ctx using repo, which must belong to the
+// caller's unit of work. When a live record for the same
+// request exists, the stored response is decoded into response and true is
+// returned. A live record for a different request yields
+// domain.ErrIdempotencyKeyReused; so does a record saved by another caller.
+func (g *Guard) Replay(ctx context.Context, repo repository.IdempotencyRepository, requestHash string, response any) (bool, error) {
+	key, ok := KeyFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	requestHash = callerHash(ctx, requestHash)
+
+	record, err := repo.GetIdempotencyRecordForUpdate(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	if record == nil || record.IsExpired(time.Now().UTC()) {
+		return false, nil
+	}
+
+	if !record.Matches(requestHash) {
+		return false, domain.ErrIdempotencyKeyReused
+	}
+
+	if err := json.Unmarshal(record.ResponseBody(), response); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Save records response under the key attached to ctx. It is a no-op for
+// requests without a key.
+func (g *Guard) Save(ctx context.Context, repo repository.IdempotencyRepository, requestHash string, response any) error {
+	key, ok := KeyFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	requestHash = callerHash(ctx, requestHash)
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return repo.SaveIdempotencyRecord(ctx, entity.NewIdempotencyRecord(key, requestHash, body, g.ttl))
+}
+
+// callerHash binds requestHash to the caller attached to ctx, so that one
+// caller cannot have another's response replayed by guessing their key.
+func callerHash(ctx context.Context, requestHash string) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return HashRequest(requestHash, principal.Subject)
+	}
+	return requestHash
+}
+
+// PurgeExpired deletes records whose window has passed.
+func (g *Guard) PurgeExpired(ctx context.Context) (int64, error) {
+	return g.repo.DeleteExpiredIdempotencyRecords(ctx, time.Now().UTC())
+}
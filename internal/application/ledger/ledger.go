@@ -0,0 +1,180 @@
+package ledger
+
+import (
+	"context"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+// Recorder writes the journal entries behind every money movement. It is
+// created from the ledger repository of the caller's unit of work so that the
+// postings commit or roll back together with the cached wallet balance.
+type Recorder struct {
+	repo repository.LedgerRepository
+}
+
+func NewRecorder(repo repository.LedgerRepository) *Recorder {
+	return &Recorder{
+		repo: repo,
+	}
+}
+
+// OpenWalletAccount returns the wallet's ledger account, creating it on first
+// use. A wallet that already holds a balance when it is opened receives an
+// opening balance entry so that its postings match the cached balance.
+//
+// It must be called while the wallet row is locked and before the wallet's
+// balance is changed within the unit of work.
+func (r *Recorder) OpenWalletAccount(ctx context.Context, wallet *entity.Wallet) (*entity.LedgerAccount, error) {
+	account, err := r.repo.GetWalletAccount(ctx, wallet.ID())
+	if err != nil || account != nil {
+		return account, err
+	}
+
+	account = entity.NewWalletLedgerAccount(wallet.ID())
+	if err := r.repo.CreateLedgerAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	if wallet.Balance().IsZero() {
+		return account, nil
+	}
+
+	opening, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountOpeningBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	// An overdrawn wallet opens with a debit balance instead.
+	debited, credited := opening.ID(), account.ID()
+	balance := wallet.Balance()
+	if balance.IsNegative() {
+		debited, credited = credited, debited
+	}
+
+	amount, err := valueobject.NewMoney(max(balance.Amount(), -balance.Amount()), balance.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.post(ctx, wallet.ID().String(), "opening balance",
+		entity.NewDebit(debited, amount),
+		entity.NewCredit(credited, amount),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// RecordWithdrawal moves amount from the wallet to the cash-out account.
+func (r *Recorder) RecordWithdrawal(ctx context.Context, account *entity.LedgerAccount, amount valueobject.Money, reference string) error {
+	cashOut, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountCashOut)
+	if err != nil {
+		return err
+	}
+
+	return r.post(ctx, reference, "withdrawal",
+		entity.NewDebit(account.ID(), amount),
+		entity.NewCredit(cashOut.ID(), amount),
+	)
+}
+
+// RecordReversal undoes amount of a withdrawal, moving it from the cash-out
+// account back to the wallet.
+func (r *Recorder) RecordReversal(ctx context.Context, account *entity.LedgerAccount, amount valueobject.Money, reference string) error {
+	cashOut, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountCashOut)
+	if err != nil {
+		return err
+	}
+
+	return r.post(ctx, reference, "reversal",
+		entity.NewDebit(cashOut.ID(), amount),
+		entity.NewCredit(account.ID(), amount),
+	)
+}
+
+// RecordDeposit moves amount from the cash-in account to the wallet.
+func (r *Recorder) RecordDeposit(ctx context.Context, account *entity.LedgerAccount, amount valueobject.Money, reference string) error {
+	cashIn, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountCashIn)
+	if err != nil {
+		return err
+	}
+
+	return r.post(ctx, reference, "deposit",
+		entity.NewDebit(cashIn.ID(), amount),
+		entity.NewCredit(account.ID(), amount),
+	)
+}
+
+// RecordAdjustment books a manual credit or debit of the wallet against the
+// adjustments account.
+func (r *Recorder) RecordAdjustment(ctx context.Context, account *entity.LedgerAccount, direction entity.AdjustmentDirection, amount valueobject.Money, reference string) error {
+	adjustments, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountAdjustments)
+	if err != nil {
+		return err
+	}
+
+	debited, credited := adjustments.ID(), account.ID()
+	if direction == entity.AdjustmentDirectionDebit {
+		debited, credited = credited, debited
+	}
+
+	return r.post(ctx, reference, "adjustment",
+		entity.NewDebit(debited, amount),
+		entity.NewCredit(credited, amount),
+	)
+}
+
+// RecordTransfer moves amount between two wallets.
+func (r *Recorder) RecordTransfer(ctx context.Context, from, to *entity.LedgerAccount, amount valueobject.Money, reference string) error {
+	return r.post(ctx, reference, "transfer",
+		entity.NewDebit(from.ID(), amount),
+		entity.NewCredit(to.ID(), amount),
+	)
+}
+
+// RecordExchange moves the source amount out of one wallet and the target
+// amount into another of a different currency. The service's FX position
+// takes the source amount less the fee and pays out the target amount; the
+// fee is booked as revenue. Each currency balances on its own.
+func (r *Recorder) RecordExchange(ctx context.Context, from, to *entity.LedgerAccount, source, fee, target valueobject.Money, reference string) error {
+	position, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountFXPosition)
+	if err != nil {
+		return err
+	}
+
+	converted, err := source.Subtract(fee)
+	if err != nil {
+		return err
+	}
+
+	postings := []entity.Posting{
+		entity.NewDebit(from.ID(), source),
+		entity.NewCredit(position.ID(), converted),
+		entity.NewDebit(position.ID(), target),
+		entity.NewCredit(to.ID(), target),
+	}
+
+	if !fee.IsZero() {
+		revenue, err := r.repo.GetSystemAccount(ctx, entity.SystemAccountFXRevenue)
+		if err != nil {
+			return err
+		}
+		postings = append(postings, entity.NewCredit(revenue.ID(), fee))
+	}
+
+	return r.post(ctx, reference, "exchange", postings...)
+}
+
+func (r *Recorder) post(ctx context.Context, reference, description string, postings ...entity.Posting) error {
+	entry, err := entity.NewJournalEntry(reference, description, postings...)
+	if err != nil {
+		return err
+	}
+
+	return r.repo.InsertJournalEntry(ctx, entry)
+}
@@ -0,0 +1,183 @@
+package ledger
+
+import (
+	"context"
+	"testing"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+func money(t *testing.T, amount int64, code string) valueobject.Money {
+	t.Helper()
+	currency, err := valueobject.NewCurrency(code)
+	if err != nil {
+		t.Fatalf("unexpected error creating currency: %v", err)
+	}
+	result, err := valueobject.NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("unexpected error creating amount: %v", err)
+	}
+	return result
+}
+
+// walletWithBalance builds a wallet holding balance, which may be negative.
+func walletWithBalance(t *testing.T, userID valueobject.UserID, balance int64, code string) *entity.Wallet {
+	t.Helper()
+	signed, err := valueobject.NewBalance(balance, money(t, 0, code).Currency())
+	if err != nil {
+		t.Fatalf("unexpected error creating balance: %v", err)
+	}
+	zero := money(t, 0, code)
+	overdraft := money(t, max(-balance, 0), code)
+	return entity.ReconstructWallet(valueobject.NewUserIDRandom(), userID, code, code == "USD", entity.WalletTierStandard,
+		entity.WalletStatusActive, signed, zero, overdraft)
+}
+
+// net returns the credits less the debits posted to an account, the balance
+// of a wallet account.
+func net(t *testing.T, repo repository.LedgerRepository, account *entity.LedgerAccount) int64 {
+	t.Helper()
+	debits, credits, err := repo.GetAccountTotals(context.Background(), account.ID())
+	if err != nil {
+		t.Fatalf("unexpected error reading account totals: %v", err)
+	}
+	return credits - debits
+}
+
+func systemNet(t *testing.T, repo repository.LedgerRepository, code string) int64 {
+	t.Helper()
+	account, err := repo.GetSystemAccount(context.Background(), code)
+	if err != nil {
+		t.Fatalf("unexpected error reading account %s: %v", code, err)
+	}
+	return net(t, repo, account)
+}
+
+// assertTrialBalance checks that the debits equal the credits in every
+// currency.
+func assertTrialBalance(t *testing.T, repo repository.LedgerRepository) {
+	t.Helper()
+	totals, err := repo.GetLedgerTotals(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error reading ledger totals: %v", err)
+	}
+	for _, total := range totals {
+		if total.Debits != total.Credits {
+			t.Errorf("expected the %s postings to balance, got %d debits and %d credits", total.Currency, total.Debits, total.Credits)
+		}
+	}
+}
+
+func TestRecorderOpenWalletAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     int64
+		wantOpening int64
+	}{
+		{name: "should open a funded wallet with a credit balance", balance: 10000, wantOpening: -10000},
+		{name: "should open an overdrawn wallet with a debit balance", balance: -2500, wantOpening: 2500},
+		{name: "should open an empty wallet without an entry", balance: 0, wantOpening: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := memory.NewLedgerRepository(memory.NewStore())
+			recorder := NewRecorder(repo)
+			wallet := walletWithBalance(t, valueobject.NewUserIDRandom(), tt.balance, "USD")
+
+			// Act
+			account, err := recorder.OpenWalletAccount(context.Background(), wallet)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if balance := net(t, repo, account); balance != tt.balance {
+				t.Errorf("expected the account to open at %d, got %d", tt.balance, balance)
+			}
+			if opening := systemNet(t, repo, entity.SystemAccountOpeningBalance); opening != tt.wantOpening {
+				t.Errorf("expected %d on the opening balance account, got %d", tt.wantOpening, opening)
+			}
+			assertTrialBalance(t, repo)
+		})
+	}
+
+	t.Run("should post the opening balance only once", func(t *testing.T) {
+		// Arrange
+		repo := memory.NewLedgerRepository(memory.NewStore())
+		recorder := NewRecorder(repo)
+		wallet := walletWithBalance(t, valueobject.NewUserIDRandom(), 10000, "USD")
+		first, _ := recorder.OpenWalletAccount(context.Background(), wallet)
+
+		// Act
+		second, err := recorder.OpenWalletAccount(context.Background(), wallet)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !second.ID().Equals(first.ID()) {
+			t.Errorf("expected the same account, got %s and %s", first.ID().String(), second.ID().String())
+		}
+		if balance := net(t, repo, second); balance != 10000 {
+			t.Errorf("expected the account to stay at 10000, got %d", balance)
+		}
+	})
+}
+
+func TestRecorderPostings(t *testing.T) {
+	t.Run("should keep the trial balance at zero across every kind of movement", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		repo := memory.NewLedgerRepository(memory.NewStore())
+		recorder := NewRecorder(repo)
+		userID := valueobject.NewUserIDRandom()
+		dollars, _ := recorder.OpenWalletAccount(ctx, walletWithBalance(t, userID, 10000, "USD"))
+		euros, _ := recorder.OpenWalletAccount(ctx, walletWithBalance(t, userID, 0, "EUR"))
+		payee, _ := recorder.OpenWalletAccount(ctx, walletWithBalance(t, valueobject.NewUserIDRandom(), 0, "USD"))
+
+		// Act
+		steps := []struct {
+			name string
+			post func() error
+		}{
+			{"withdrawal", func() error { return recorder.RecordWithdrawal(ctx, dollars, money(t, 2000, "USD"), "w-1") }},
+			{"deposit", func() error { return recorder.RecordDeposit(ctx, dollars, money(t, 500, "USD"), "d-1") }},
+			{"reversal", func() error { return recorder.RecordReversal(ctx, dollars, money(t, 1000, "USD"), "r-1") }},
+			{"transfer", func() error { return recorder.RecordTransfer(ctx, dollars, payee, money(t, 3000, "USD"), "t-1") }},
+			{"exchange", func() error {
+				return recorder.RecordExchange(ctx, dollars, euros, money(t, 1000, "USD"), money(t, 5, "USD"), money(t, 915, "EUR"), "x-1")
+			}},
+			{"adjustment", func() error {
+				return recorder.RecordAdjustment(ctx, payee, entity.AdjustmentDirectionDebit, money(t, 200, "USD"), "a-1")
+			}},
+		}
+		for _, step := range steps {
+			if err := step.post(); err != nil {
+				t.Fatalf("unexpected error posting %s: %v", step.name, err)
+			}
+		}
+
+		// Assert
+		assertTrialBalance(t, repo)
+		if balance := net(t, repo, dollars); balance != 10000-2000+500+1000-3000-1000 {
+			t.Errorf("expected the USD wallet at %d, got %d", 10000-2000+500+1000-3000-1000, balance)
+		}
+		if balance := net(t, repo, payee); balance != 2800 {
+			t.Errorf("expected the payee at 2800, got %d", balance)
+		}
+		if balance := net(t, repo, euros); balance != 915 {
+			t.Errorf("expected the EUR wallet at 915, got %d", balance)
+		}
+		if revenue := systemNet(t, repo, entity.SystemAccountFXRevenue); revenue != 5 {
+			t.Errorf("expected 5 of FX revenue, got %d", revenue)
+		}
+		if cashOut := systemNet(t, repo, entity.SystemAccountCashOut); cashOut != 1000 {
+			t.Errorf("expected 1000 paid out net of the reversal, got %d", cashOut)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package limits
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+// Usage is what a wallet has withdrawn in the current limit windows.
+type Usage struct {
+	Today     int64
+	ThisMonth int64
+}
+
+// Effective returns the limits that apply to a wallet: those of its tier in
+// its currency, with the wallet's overrides applied.
+func Effective(ctx context.Context, repo repository.LimitRepository, wallet *entity.Wallet) (entity.WithdrawalLimits, error) {
+	tierLimits, err := repo.GetTierLimits(ctx, wallet.Tier(), wallet.Currency())
+	if err != nil {
+		return entity.WithdrawalLimits{}, err
+	}
+
+	overrides, err := repo.GetWalletLimits(ctx, wallet.ID())
+	if err != nil {
+		return entity.WithdrawalLimits{}, err
+	}
+
+	return tierLimits.Override(overrides), nil
+}
+
+// CurrentUsage sums the withdrawals of a wallet in the day and month that now
+// falls in.
+func CurrentUsage(ctx context.Context, repo repository.TransactionRepository, walletID valueobject.UserID, now time.Time) (Usage, error) {
+	dayStart, monthStart := entity.LimitWindows(now)
+	end := dayStart.AddDate(0, 0, 1)
+
+	today, err := repo.SumWithdrawals(ctx, walletID, dayStart, end)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	thisMonth, err := repo.SumWithdrawals(ctx, walletID, monthStart, end)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{Today: today, ThisMonth: thisMonth}, nil
+}
+
+// CheckWithdrawal tests a withdrawal of amount from wallet against its limits
+// and fails with a *domain.LimitExceededError when it breaks one.
+//
+// It must be called while the wallet row is locked, so that concurrent
+// withdrawals from the wallet are counted one after the other.
+func CheckWithdrawal(ctx context.Context, repos repository.Repositories, wallet *entity.Wallet, amount valueobject.Money, now time.Time) error {
+	effective, err := Effective(ctx, repos.Limits, wallet)
+	if err != nil {
+		return err
+	}
+	if effective.IsZero() {
+		return nil
+	}
+
+	usage, err := CurrentUsage(ctx, repos.Transactions, wallet.ID(), now)
+	if err != nil {
+		return err
+	}
+
+	return effective.Check(amount, usage.Today, usage.ThisMonth)
+}
@@ -0,0 +1,166 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+// now is mid-month, so that the day and month windows differ.
+var now = time.Date(2025, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+func usd(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, valueobject.DefaultCurrency())
+	if err != nil {
+		t.Fatalf("unexpected error creating amount: %v", err)
+	}
+	return money
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+// insertCompleted stores a completed transaction created at createdAt.
+func insertCompleted(t *testing.T, store *memory.Store, walletID valueobject.UserID, txType entity.TransactionType, amount int64, createdAt time.Time) {
+	t.Helper()
+	transaction := entity.ReconstructTransaction(valueobject.NewUserIDRandom(), walletID, txType, usd(t, amount),
+		entity.TransactionStatusCompleted, createdAt.Format(time.RFC3339), "", nil, nil, "")
+	if err := memory.NewTransactionRepository(store).InsertTransaction(context.Background(), transaction); err != nil {
+		t.Fatalf("unexpected error inserting transaction: %v", err)
+	}
+}
+
+func TestCurrentUsage(t *testing.T) {
+	tests := []struct {
+		name          string
+		now           time.Time
+		wantToday     int64
+		wantThisMonth int64
+	}{
+		{name: "should count the UTC day and month that now falls in", now: now, wantToday: 33000, wantThisMonth: 39000},
+		{name: "should use UTC windows whatever the zone of now", now: time.Date(2025, time.March, 14, 21, 0, 0, 0, time.FixedZone("EST", -5*60*60)), wantToday: 33000, wantThisMonth: 39000},
+		{name: "should count the previous day only in the month", now: time.Date(2025, time.March, 14, 23, 59, 59, 0, time.UTC), wantToday: 2000, wantThisMonth: 6000},
+		{name: "should start over with a new month", now: time.Date(2025, time.February, 28, 23, 59, 59, 0, time.UTC), wantToday: 8000, wantThisMonth: 8000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := memory.NewStore()
+			walletID := valueobject.NewUserIDRandom()
+			insertCompleted(t, store, walletID, entity.TransactionTypeWithdrawal, 1000, time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeTransferOut, 32000, time.Date(2025, time.March, 15, 9, 0, 0, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeWithdrawal, 2000, time.Date(2025, time.March, 14, 23, 59, 59, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeWithdrawal, 4000, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeWithdrawal, 8000, time.Date(2025, time.February, 28, 23, 59, 59, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeWithdrawal, 16000, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC))
+			insertCompleted(t, store, walletID, entity.TransactionTypeDeposit, 64000, time.Date(2025, time.March, 15, 9, 0, 0, 0, time.UTC))
+
+			// Act
+			usage, err := CurrentUsage(context.Background(), memory.NewTransactionRepository(store), walletID, tt.now)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if usage.Today != tt.wantToday || usage.ThisMonth != tt.wantThisMonth {
+				t.Errorf("expected %d today and %d this month, got %d and %d", tt.wantToday, tt.wantThisMonth, usage.Today, usage.ThisMonth)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	t.Run("should let a wallet's override win over its tier's limit", func(t *testing.T) {
+		// Arrange
+		store := memory.NewStore()
+		wallet := store.AddWallet(valueobject.NewUserIDRandom(), usd(t, 0))
+		limitRepo := memory.NewLimitRepository(store)
+		if err := limitRepo.SetWalletLimits(context.Background(), wallet.ID(), entity.WithdrawalLimits{PerTransaction: int64Ptr(100000)}); err != nil {
+			t.Fatalf("unexpected error setting limits: %v", err)
+		}
+		tierLimits, _ := limitRepo.GetTierLimits(context.Background(), entity.WalletTierStandard, valueobject.DefaultCurrency())
+
+		// Act
+		effective, err := Effective(context.Background(), limitRepo, wallet)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if effective.PerTransaction == nil || *effective.PerTransaction != 100000 {
+			t.Errorf("expected the override of 100000 per transaction, got %v", effective.PerTransaction)
+		}
+		if effective.Daily == nil || tierLimits.Daily == nil || *effective.Daily != *tierLimits.Daily {
+			t.Errorf("expected the tier's daily limit, got %v", effective.Daily)
+		}
+	})
+}
+
+func TestCheckWithdrawal(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        int64
+		wantLimit     string
+		wantRemaining int64
+	}{
+		{name: "should allow an amount within every limit", amount: 30000},
+		{name: "should refuse an amount below the minimum", amount: 500, wantLimit: entity.LimitMinAmount, wantRemaining: 0},
+		{name: "should refuse an amount above the per-transaction maximum", amount: 60000, wantLimit: entity.LimitPerTransaction, wantRemaining: 50000},
+		{name: "should refuse an amount above what is left of the day", amount: 45000, wantLimit: entity.LimitDaily, wantRemaining: 40000},
+		{name: "should refuse an amount above what is left of the month", amount: 35000, wantLimit: entity.LimitMonthly, wantRemaining: 30000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			store := memory.NewStore()
+			wallet := store.AddWallet(valueobject.NewUserIDRandom(), usd(t, 1000000))
+			repos := repository.Repositories{
+				Limits:       memory.NewLimitRepository(store),
+				Transactions: memory.NewTransactionRepository(store),
+			}
+			overrides := entity.WithdrawalLimits{
+				MinAmount:      int64Ptr(1000),
+				PerTransaction: int64Ptr(50000),
+				Daily:          int64Ptr(80000),
+				Monthly:        int64Ptr(100000),
+			}
+			if err := repos.Limits.SetWalletLimits(context.Background(), wallet.ID(), overrides); err != nil {
+				t.Fatalf("unexpected error setting limits: %v", err)
+			}
+			insertCompleted(t, store, wallet.ID(), entity.TransactionTypeWithdrawal, 40000, time.Date(2025, time.March, 15, 9, 0, 0, 0, time.UTC))
+			insertCompleted(t, store, wallet.ID(), entity.TransactionTypeWithdrawal, 30000, time.Date(2025, time.March, 2, 12, 0, 0, 0, time.UTC))
+
+			// Act
+			err := CheckWithdrawal(context.Background(), repos, wallet, usd(t, tt.amount), now)
+
+			// Assert
+			if tt.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var limitErr *domain.LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("expected a LimitExceededError, got %v", err)
+			}
+			if !errors.Is(err, domain.ErrLimitExceeded) {
+				t.Errorf("expected the error to be ErrLimitExceeded")
+			}
+			if limitErr.Limit != tt.wantLimit || limitErr.Remaining != tt.wantRemaining {
+				t.Errorf("expected the %s limit with %d remaining, got the %s limit with %d remaining", tt.wantLimit, tt.wantRemaining, limitErr.Limit, limitErr.Remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"encoding/base64"
+	"log"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	DefaultAdminPageSize = 20
+	MaxAdminPageSize     = 100
+)
+
+type adminService struct {
+	walletRepo            repository.WalletRepository
+	transactionRepo       repository.TransactionRepository
+	auditRepo             repository.AuditRepository
+	withdrawalRequestRepo repository.WithdrawalRequestRepository
+}
+
+// NewAdminService creates a new back-office read service implementation
+func NewAdminService(walletRepo repository.WalletRepository, transactionRepo repository.TransactionRepository, auditRepo repository.AuditRepository, withdrawalRequestRepo repository.WithdrawalRequestRepository) domainService.AdminService {
+	return &adminService{
+		walletRepo:            walletRepo,
+		transactionRepo:       transactionRepo,
+		auditRepo:             auditRepo,
+		withdrawalRequestRepo: withdrawalRequestRepo,
+	}
+}
+
+func (s *adminService) SearchWallets(ctx context.Context, query dto.WalletSearchQuery) (*dto.WalletSearchResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeViewer); err != nil {
+		log.Printf("🚫 Wallet search refused: %v", err)
+		return nil, err
+	}
+
+	filter, err := buildWalletFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page exists.
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	wallets, err := s.walletRepo.SearchWallets(ctx, filter)
+	if err != nil {
+		log.Printf("❌ Failed to search wallets: %v", err)
+		return nil, err
+	}
+
+	response := &dto.WalletSearchResponse{
+		Wallets: make([]dto.WalletResponse, 0, pageSize),
+	}
+
+	if len(wallets) > pageSize {
+		wallets = wallets[:pageSize]
+		response.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(wallets[len(wallets)-1].ID().String()))
+	}
+
+	for _, wallet := range wallets {
+		response.Wallets = append(response.Wallets, toWalletResponse(wallet))
+	}
+
+	return response, nil
+}
+
+func buildWalletFilter(query dto.WalletSearchQuery) (repository.WalletFilter, error) {
+	filter := repository.WalletFilter{
+		Name:  query.Name,
+		Limit: adminPageSize(query.Limit),
+	}
+
+	if query.UserID != "" {
+		userID, err := valueobject.NewUserID(query.UserID)
+		if err != nil {
+			return filter, domain.NewValidationError("user_id", "user_id must be a UUID")
+		}
+		filter.UserID = &userID
+	}
+
+	for _, value := range query.Statuses {
+		status, err := entity.ParseWalletStatus(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, value := range query.Tiers {
+		tier, err := entity.ParseWalletTier(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Tiers = append(filter.Tiers, tier)
+	}
+
+	if query.Currency != "" {
+		currency, err := valueobject.NewCurrency(query.Currency)
+		if err != nil {
+			return filter, domain.NewValidationError("currency", "unsupported currency "+query.Currency)
+		}
+		filter.Currency = &currency
+	}
+
+	if query.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil {
+			return filter, errInvalidCursor()
+		}
+		after, err := valueobject.NewUserID(string(raw))
+		if err != nil {
+			return filter, errInvalidCursor()
+		}
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+func (s *adminService) GetTransaction(ctx context.Context, transactionID valueobject.UserID) (*dto.TransactionResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeViewer); err != nil {
+		log.Printf("🚫 Transaction %s refused: %v", transactionID.String(), err)
+		return nil, err
+	}
+
+	transaction, err := s.transactionRepo.GetTransaction(ctx, transactionID)
+	if err != nil {
+		log.Printf("❌ Transaction %s not found: %v", transactionID.String(), err)
+		return nil, err
+	}
+
+	response := toTransactionResponse(transaction)
+	return &response, nil
+}
+
+func (s *adminService) ListAuditEntries(ctx context.Context, query dto.AuditLogQuery) (*dto.AuditLogResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeSupervisor); err != nil {
+		log.Printf("🚫 Audit trail refused: %v", err)
+		return nil, err
+	}
+
+	filter, err := buildAuditFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	entries, err := s.auditRepo.ListAuditEntries(ctx, filter)
+	if err != nil {
+		log.Printf("❌ Failed to list audit entries: %v", err)
+		return nil, err
+	}
+
+	response := &dto.AuditLogResponse{
+		Entries: make([]dto.AuditEntryResponse, 0, pageSize),
+	}
+
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+		response.NextCursor = encodeCursor(repository.TransactionCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID(),
+		})
+	}
+
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, dto.AuditEntryResponse{
+			EntryID:    entry.ID().String(),
+			Actor:      entry.Actor(),
+			Action:     string(entry.Action()),
+			TargetType: string(entry.TargetType()),
+			TargetID:   entry.TargetID(),
+			Reason:     entry.Reason(),
+			Details:    entry.Details(),
+			CreatedAt:  entry.CreatedAt().UTC().Format(time.RFC3339Nano),
+		})
+	}
+
+	return response, nil
+}
+
+func buildAuditFilter(query dto.AuditLogQuery) (repository.AuditFilter, error) {
+	filter := repository.AuditFilter{
+		Actor:    query.Actor,
+		TargetID: query.TargetID,
+		From:     query.From,
+		To:       query.To,
+		Limit:    adminPageSize(query.Limit),
+	}
+
+	for _, value := range query.Actions {
+		action, err := entity.ParseAuditAction(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Actions = append(filter.Actions, action)
+	}
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return filter, domain.NewValidationError("from", "from must be before to")
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		after := repository.AuditCursor(cursor)
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+func (s *adminService) GetWithdrawalRequest(ctx context.Context, requestID valueobject.UserID) (*dto.WithdrawalRequestResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeViewer); err != nil {
+		log.Printf("🚫 Withdrawal request %s refused: %v", requestID.String(), err)
+		return nil, err
+	}
+
+	request, err := s.withdrawalRequestRepo.GetWithdrawalRequest(ctx, requestID)
+	if err != nil {
+		log.Printf("❌ Withdrawal request %s not found: %v", requestID.String(), err)
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.GetWallet(ctx, valueobject.WalletByID(request.WalletID()))
+	if err != nil {
+		log.Printf("❌ Wallet %s of withdrawal request %s not found: %v", request.WalletID().String(), requestID.String(), err)
+		return nil, err
+	}
+
+	events, err := s.withdrawalRequestRepo.ListWithdrawalRequestEvents(ctx, requestID)
+	if err != nil {
+		log.Printf("❌ Failed to read history of withdrawal request %s: %v", requestID.String(), err)
+		return nil, err
+	}
+
+	response := approvals.Response(request, wallet, events)
+	return &response, nil
+}
+
+func (s *adminService) ListWithdrawalRequests(ctx context.Context, query dto.WithdrawalRequestQuery) (*dto.WithdrawalRequestListResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeViewer); err != nil {
+		log.Printf("🚫 Withdrawal requests refused: %v", err)
+		return nil, err
+	}
+
+	filter, err := buildWithdrawalRequestFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	requests, err := s.withdrawalRequestRepo.ListWithdrawalRequests(ctx, filter)
+	if err != nil {
+		log.Printf("❌ Failed to list withdrawal requests: %v", err)
+		return nil, err
+	}
+
+	response := &dto.WithdrawalRequestListResponse{
+		Requests: make([]dto.WithdrawalRequestResponse, 0, pageSize),
+	}
+
+	if len(requests) > pageSize {
+		requests = requests[:pageSize]
+		last := requests[len(requests)-1]
+		response.NextCursor = encodeCursor(repository.TransactionCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID(),
+		})
+	}
+
+	for _, request := range requests {
+		response.Requests = append(response.Requests, approvals.Response(request, nil, nil))
+	}
+
+	return response, nil
+}
+
+func buildWithdrawalRequestFilter(query dto.WithdrawalRequestQuery) (repository.WithdrawalRequestFilter, error) {
+	filter := repository.WithdrawalRequestFilter{
+		RequestedBy: query.RequestedBy,
+		Limit:       adminPageSize(query.Limit),
+	}
+
+	if query.WalletID != "" {
+		walletID, err := valueobject.NewUserID(query.WalletID)
+		if err != nil {
+			return filter, domain.NewValidationError("wallet_id", "wallet_id must be a UUID")
+		}
+		filter.WalletID = &walletID
+	}
+
+	for _, value := range query.Statuses {
+		status, err := entity.ParseWithdrawalRequestStatus(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		after := repository.WithdrawalRequestCursor(cursor)
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+func adminPageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultAdminPageSize
+	}
+	return min(limit, MaxAdminPageSize)
+}
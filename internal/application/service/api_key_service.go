@@ -0,0 +1,135 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"strings"
+	"time"
+
+	"bank/internal/application/audit"
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+// apiKeySecretPrefix marks API key secrets so that leaked ones are easy to
+// recognise, for example by secret scanners.
+const apiKeySecretPrefix = "wsk_"
+
+type apiKeyService struct {
+	unitOfWork repository.UnitOfWork
+	apiKeyRepo repository.APIKeyRepository
+}
+
+// NewAPIKeyService creates a new API key administration service
+// implementation
+func NewAPIKeyService(unitOfWork repository.UnitOfWork, apiKeyRepo repository.APIKeyRepository) domainService.APIKeyService {
+	return &apiKeyService{
+		unitOfWork: unitOfWork,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// IssueAPIKey generates a random secret and stores only the signing key
+// derived from it.
+func (s *apiKeyService) IssueAPIKey(ctx context.Context, userID valueobject.UserID, name string, scopes []string) (*dto.IssuedAPIKeyResponse, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		log.Printf("❌ Failed to generate API key secret: %v", err)
+		return nil, err
+	}
+	secret := apiKeySecretPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	key, err := entity.NewAPIKey(userID, name, scopes, entity.DeriveAPIKeySigningKey(secret), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.APIKeys.CreateAPIKey(ctx, key); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, repos.Audit, entity.AuditActionAPIKeyIssued, entity.AuditTargetAPIKey, key.ID().String(), "", map[string]string{
+			"user_id": userID.String(),
+			"name":    key.Name(),
+			"scopes":  strings.Join(key.Scopes(), " "),
+		})
+	})
+	if err != nil {
+		log.Printf("❌ Failed to issue API key for user %s: %v", userID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("🔑 Issued API key %s for user %s with scopes %v", key.ID().String(), userID.String(), key.Scopes())
+	return &dto.IssuedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Secret:         secret,
+	}, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, keyID valueobject.UserID) (*dto.APIKeyResponse, error) {
+	var key *entity.APIKey
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var err error
+		key, err = repos.APIKeys.GetAPIKeyForUpdate(ctx, keyID)
+		if err != nil {
+			return err
+		}
+
+		if err := key.Revoke(time.Now()); err != nil {
+			return err
+		}
+
+		if err := repos.APIKeys.UpdateAPIKey(ctx, key); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, repos.Audit, entity.AuditActionAPIKeyRevoked, entity.AuditTargetAPIKey, key.ID().String(), "", map[string]string{
+			"user_id": key.UserID().String(),
+		})
+	})
+	if err != nil {
+		log.Printf("❌ Failed to revoke API key %s: %v", keyID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("🔑 Revoked API key %s of user %s", keyID.String(), key.UserID().String())
+	response := toAPIKeyResponse(key)
+	return &response, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, userID valueobject.UserID) (*dto.APIKeyListResponse, error) {
+	keys, err := s.apiKeyRepo.ListAPIKeys(ctx, userID)
+	if err != nil {
+		log.Printf("❌ Failed to list API keys of user %s: %v", userID.String(), err)
+		return nil, err
+	}
+
+	response := &dto.APIKeyListResponse{
+		UserID: userID.String(),
+		Keys:   make([]dto.APIKeyResponse, 0, len(keys)),
+	}
+	for _, key := range keys {
+		response.Keys = append(response.Keys, toAPIKeyResponse(key))
+	}
+	return response, nil
+}
+
+func toAPIKeyResponse(key *entity.APIKey) dto.APIKeyResponse {
+	response := dto.APIKeyResponse{
+		KeyID:     key.ID().String(),
+		UserID:    key.UserID().String(),
+		Name:      key.Name(),
+		Scopes:    key.Scopes(),
+		CreatedAt: key.CreatedAt().UTC().Format(time.RFC3339Nano),
+	}
+	if revokedAt := key.RevokedAt(); revokedAt != nil {
+		response.RevokedAt = revokedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return response
+}
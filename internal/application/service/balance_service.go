@@ -5,6 +5,7 @@ import (
 	"context"
 	"log"
 
+	"bank/internal/application/auth"
 	"bank/internal/application/dto"
 	"bank/internal/domain/repository"
 	"bank/internal/domain/valueobject"
@@ -21,19 +22,30 @@ func NewBalanceUseCase(walletRepo repository.WalletRepository) domainService.Bal
 	}
 }
 
-func (uc *balanceService) GetBalance(ctx context.Context, userID valueobject.UserID) (*dto.BalanceResponse, error) {
+func (uc *balanceService) GetBalance(ctx context.Context, ref valueobject.WalletRef) (*dto.BalanceResponse, error) {
 
-	wallet, err := uc.walletRepo.GetWallet(ctx, userID)
+	wallet, err := uc.walletRepo.GetWallet(ctx, ref)
 	if err != nil {
-		log.Printf("❌ Wallet not found for user %s: %v", userID.String(), err)
+		log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
 		return &dto.BalanceResponse{
-			UserID:  userID.String(),
 			Balance: 0,
 		}, err
 	}
 
+	if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+		log.Printf("🚫 Balance of %s refused: %v", ref.String(), err)
+		return nil, err
+	}
+
 	return &dto.BalanceResponse{
-		UserID:  userID.String(),
-		Balance: wallet.Balance().Amount(),
+		UserID:           wallet.UserID().String(),
+		WalletID:         wallet.ID().String(),
+		Balance:          wallet.Balance().Amount(),
+		AvailableBalance: wallet.AvailableBalance().Amount(),
+		HeldBalance:      wallet.HeldBalance().Amount(),
+		OverdraftLimit:   wallet.OverdraftLimit().Amount(),
+		Status:           string(wallet.Status()),
+		Currency:         wallet.Currency().Code(),
+		FormattedBalance: wallet.Balance().String(),
 	}, nil
 }
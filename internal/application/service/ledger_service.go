@@ -0,0 +1,110 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"log"
+
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type ledgerService struct {
+	unitOfWork repository.UnitOfWork
+	ledgerRepo repository.LedgerRepository
+}
+
+// NewLedgerService creates a new ledger verification service implementation
+func NewLedgerService(unitOfWork repository.UnitOfWork, ledgerRepo repository.LedgerRepository) domainService.LedgerService {
+	return &ledgerService{
+		unitOfWork: unitOfWork,
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+// VerifyWalletBalance compares the cached wallet balance with the sum of the
+// wallet's postings. The wallet row is locked while both are read, since every
+// money movement takes the same lock before posting.
+func (s *ledgerService) VerifyWalletBalance(ctx context.Context, ref valueobject.WalletRef) (*dto.LedgerVerificationResponse, error) {
+	var response *dto.LedgerVerificationResponse
+
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, ref)
+		if err != nil {
+			log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			log.Printf("🚫 Ledger verification of %s refused: %v", ref.String(), err)
+			return err
+		}
+
+		response = &dto.LedgerVerificationResponse{
+			UserID:        wallet.UserID().String(),
+			WalletID:      wallet.ID().String(),
+			Currency:      wallet.Currency().Code(),
+			CachedBalance: wallet.Balance().Amount(),
+		}
+
+		account, err := repos.Ledger.GetWalletAccount(ctx, wallet.ID())
+		if err != nil {
+			return err
+		}
+
+		// A wallet that has not moved money since the ledger was introduced
+		// has no postings yet; its account is opened on its next movement.
+		if account == nil {
+			response.Consistent = true
+			return nil
+		}
+
+		debits, credits, err := repos.Ledger.GetAccountTotals(ctx, account.ID())
+		if err != nil {
+			return err
+		}
+
+		response.LedgerAccountOpened = true
+		response.LedgerBalance = credits - debits
+		response.Consistent = response.LedgerBalance == response.CachedBalance
+
+		if !response.Consistent {
+			log.Printf("⚠️ Ledger mismatch for wallet %s: cached %d, ledger %d",
+				wallet.ID().String(), response.CachedBalance, response.LedgerBalance)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *ledgerService) GetTrialBalance(ctx context.Context) (*dto.TrialBalanceResponse, error) {
+	totals, err := s.ledgerRepo.GetLedgerTotals(ctx)
+	if err != nil {
+		log.Printf("❌ Failed to compute trial balance: %v", err)
+		return nil, err
+	}
+
+	response := &dto.TrialBalanceResponse{
+		Currencies: make([]dto.CurrencyTrialBalance, 0, len(totals)),
+		Balanced:   true,
+	}
+	for _, total := range totals {
+		balanced := total.Debits == total.Credits
+		response.Currencies = append(response.Currencies, dto.CurrencyTrialBalance{
+			Currency:     total.Currency,
+			TotalDebits:  total.Debits,
+			TotalCredits: total.Credits,
+			Balanced:     balanced,
+		})
+		response.Balanced = response.Balanced && balanced
+	}
+
+	return response, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"bank/internal/application/audit"
+	"bank/internal/application/dto"
+	"bank/internal/application/limits"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type limitService struct {
+	unitOfWork      repository.UnitOfWork
+	limitRepo       repository.LimitRepository
+	walletRepo      repository.WalletRepository
+	transactionRepo repository.TransactionRepository
+}
+
+// NewLimitService creates a new withdrawal limit administration service
+func NewLimitService(unitOfWork repository.UnitOfWork, limitRepo repository.LimitRepository, walletRepo repository.WalletRepository, transactionRepo repository.TransactionRepository) domainService.LimitService {
+	return &limitService{
+		unitOfWork:      unitOfWork,
+		limitRepo:       limitRepo,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (s *limitService) ListTierLimits(ctx context.Context) (*dto.TierLimitsListResponse, error) {
+	tiers, err := s.limitRepo.ListTierLimits(ctx)
+	if err != nil {
+		log.Printf("❌ Failed to list tier limits: %v", err)
+		return nil, err
+	}
+
+	response := &dto.TierLimitsListResponse{
+		Tiers: make([]dto.TierLimitsResponse, 0, len(tiers)),
+	}
+	for tier, currencies := range tiers {
+		for currency, tierLimits := range currencies {
+			response.Tiers = append(response.Tiers, dto.TierLimitsResponse{
+				Tier:     string(tier),
+				Currency: currency,
+				Limits:   toLimitsDTO(tierLimits),
+			})
+		}
+	}
+
+	sort.Slice(response.Tiers, func(i, j int) bool {
+		if response.Tiers[i].Tier != response.Tiers[j].Tier {
+			return response.Tiers[i].Tier < response.Tiers[j].Tier
+		}
+		return response.Tiers[i].Currency < response.Tiers[j].Currency
+	})
+
+	return response, nil
+}
+
+func (s *limitService) SetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency, tierLimits entity.WithdrawalLimits) (*dto.TierLimitsResponse, error) {
+	if err := tierLimits.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.Limits.SetTierLimits(ctx, tier, currency, tierLimits); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, repos.Audit, entity.AuditActionTierLimitsSet, entity.AuditTargetTier, string(tier)+"/"+currency.Code(), "", limitDetails(tierLimits))
+	})
+	if err != nil {
+		log.Printf("❌ Failed to set %s limits of tier %s: %v", currency.Code(), tier, err)
+		return nil, err
+	}
+
+	log.Printf("🚦 Set %s withdrawal limits of tier %s", currency.Code(), tier)
+	return &dto.TierLimitsResponse{
+		Tier:     string(tier),
+		Currency: currency.Code(),
+		Limits:   toLimitsDTO(tierLimits),
+	}, nil
+}
+
+func (s *limitService) GetWalletLimits(ctx context.Context, walletID valueobject.UserID) (*dto.WalletLimitsResponse, error) {
+	wallet, err := s.walletRepo.GetWallet(ctx, valueobject.WalletByID(walletID))
+	if err != nil {
+		log.Printf("❌ Wallet %s not found: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	response, err := walletLimitsResponse(ctx, s.limitRepo, s.transactionRepo, wallet)
+	if err != nil {
+		log.Printf("❌ Failed to load limits of wallet %s: %v", walletID.String(), err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// SetWalletLimits locks the wallet so that the change does not interleave
+// with a withdrawal being checked against the old limits.
+func (s *limitService) SetWalletLimits(ctx context.Context, walletID valueobject.UserID, tier *entity.WalletTier, overrides *entity.WithdrawalLimits) (*dto.WalletLimitsResponse, error) {
+	var response *dto.WalletLimitsResponse
+
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(walletID))
+		if err != nil {
+			return err
+		}
+
+		if tier != nil {
+			wallet.ChangeTier(*tier)
+			if err := repos.Wallets.UpdateWalletTier(ctx, wallet.ID(), wallet.Tier()); err != nil {
+				return err
+			}
+		}
+
+		if overrides != nil {
+			if err := overrides.Validate(); err != nil {
+				return err
+			}
+			if err := repos.Limits.SetWalletLimits(ctx, wallet.ID(), *overrides); err != nil {
+				return err
+			}
+		}
+
+		effective, err := limits.Effective(ctx, repos.Limits, wallet)
+		if err != nil {
+			return err
+		}
+		if err := effective.Validate(); err != nil {
+			return err
+		}
+
+		details := map[string]string{"tier": string(wallet.Tier())}
+		if overrides != nil {
+			details = limitDetails(*overrides)
+			details["tier"] = string(wallet.Tier())
+		}
+		if err := audit.Record(ctx, repos.Audit, entity.AuditActionWalletLimitsSet, entity.AuditTargetWallet, wallet.ID().String(), "", details); err != nil {
+			return err
+		}
+
+		response, err = walletLimitsResponse(ctx, repos.Limits, repos.Transactions, wallet)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Failed to set limits of wallet %s: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("🚦 Set withdrawal limits of wallet %s (tier %s)", walletID.String(), response.Tier)
+	return response, nil
+}
+
+func walletLimitsResponse(ctx context.Context, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository, wallet *entity.Wallet) (*dto.WalletLimitsResponse, error) {
+	tierLimits, err := limitRepo.GetTierLimits(ctx, wallet.Tier(), wallet.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := limitRepo.GetWalletLimits(ctx, wallet.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := limits.CurrentUsage(ctx, transactionRepo, wallet.ID(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	effective := tierLimits.Override(overrides)
+
+	limitUsage := dto.LimitUsage{
+		WithdrawnToday:     usage.Today,
+		WithdrawnThisMonth: usage.ThisMonth,
+	}
+	if effective.Monthly != nil {
+		remaining := max(*effective.Monthly-usage.ThisMonth, 0)
+		limitUsage.RemainingThisMonth = &remaining
+	}
+	if effective.Daily != nil {
+		remaining := max(*effective.Daily-usage.Today, 0)
+		limitUsage.RemainingToday = &remaining
+	}
+	if limitUsage.RemainingThisMonth != nil && (limitUsage.RemainingToday == nil || *limitUsage.RemainingThisMonth < *limitUsage.RemainingToday) {
+		remaining := *limitUsage.RemainingThisMonth
+		limitUsage.RemainingToday = &remaining
+	}
+
+	return &dto.WalletLimitsResponse{
+		WalletID:   wallet.ID().String(),
+		UserID:     wallet.UserID().String(),
+		Tier:       string(wallet.Tier()),
+		Currency:   wallet.Currency().Code(),
+		TierLimits: toLimitsDTO(tierLimits),
+		Overrides:  toLimitsDTO(overrides),
+		Effective:  toLimitsDTO(effective),
+		Usage:      limitUsage,
+	}, nil
+}
+
+// limitDetails lists the configured limits for the audit trail; unset limits
+// are left out.
+func limitDetails(l entity.WithdrawalLimits) map[string]string {
+	details := make(map[string]string)
+	for name, value := range map[string]*int64{
+		entity.LimitMinAmount:      l.MinAmount,
+		entity.LimitPerTransaction: l.PerTransaction,
+		entity.LimitDaily:          l.Daily,
+		entity.LimitMonthly:        l.Monthly,
+	} {
+		if value != nil {
+			details[name] = strconv.FormatInt(*value, 10)
+		}
+	}
+	return details
+}
+
+func toLimitsDTO(l entity.WithdrawalLimits) dto.WithdrawalLimits {
+	return dto.WithdrawalLimits{
+		MinAmount:      l.MinAmount,
+		PerTransaction: l.PerTransaction,
+		Daily:          l.Daily,
+		Monthly:        l.Monthly,
+	}
+}
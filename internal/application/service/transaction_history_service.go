@@ -0,0 +1,193 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"encoding/base64"
+	"log"
+	"strings"
+	"time"
+
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	DefaultTransactionPageSize = 20
+	MaxTransactionPageSize     = 100
+)
+
+type transactionHistoryService struct {
+	walletRepo      repository.WalletRepository
+	transactionRepo repository.TransactionRepository
+}
+
+// NewTransactionHistoryService creates a new transaction history service implementation
+func NewTransactionHistoryService(walletRepo repository.WalletRepository, transactionRepo repository.TransactionRepository) domainService.TransactionHistoryService {
+	return &transactionHistoryService{
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (s *transactionHistoryService) ListTransactions(ctx context.Context, ref valueobject.WalletRef, query dto.TransactionHistoryQuery) (*dto.TransactionHistoryResponse, error) {
+	filter, err := buildTransactionFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.GetWallet(ctx, ref)
+	if err != nil {
+		log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+		log.Printf("🚫 Transaction history of %s refused: %v", ref.String(), err)
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page exists.
+	pageSize := filter.Limit
+	filter.Limit = pageSize + 1
+
+	transactions, err := s.transactionRepo.ListTransactions(ctx, wallet.ID(), filter)
+	if err != nil {
+		log.Printf("❌ Failed to list transactions for wallet %s: %v", wallet.ID().String(), err)
+		return nil, err
+	}
+
+	response := &dto.TransactionHistoryResponse{
+		UserID:       wallet.UserID().String(),
+		WalletID:     wallet.ID().String(),
+		Transactions: make([]dto.TransactionResponse, 0, pageSize),
+	}
+
+	if len(transactions) > pageSize {
+		transactions = transactions[:pageSize]
+		last := transactions[len(transactions)-1]
+		response.NextCursor = encodeCursor(repository.TransactionCursor{
+			CreatedAt: last.CreatedAt(),
+			ID:        last.ID(),
+		})
+	}
+
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, toTransactionResponse(transaction))
+	}
+
+	return response, nil
+}
+
+func buildTransactionFilter(query dto.TransactionHistoryQuery) (repository.TransactionFilter, error) {
+	filter := repository.TransactionFilter{
+		MinAmount: query.MinAmount,
+		MaxAmount: query.MaxAmount,
+		From:      query.From,
+		To:        query.To,
+		Limit:     query.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultTransactionPageSize
+	}
+	if filter.Limit > MaxTransactionPageSize {
+		filter.Limit = MaxTransactionPageSize
+	}
+
+	for _, value := range query.Types {
+		txType, err := entity.ParseTransactionType(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Types = append(filter.Types, txType)
+	}
+
+	for _, value := range query.Statuses {
+		status, err := entity.ParseTransactionStatus(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		return filter, domain.NewValidationError("min_amount", "min_amount must not exceed max_amount")
+	}
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return filter, domain.NewValidationError("from", "from must be before to")
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+func toTransactionResponse(transaction *entity.Transaction) dto.TransactionResponse {
+	response := dto.TransactionResponse{
+		ID:            transaction.ID().String(),
+		WalletID:      transaction.WalletID().String(),
+		Type:          string(transaction.Type()),
+		Amount:        transaction.Amount().Amount(),
+		Currency:      transaction.Amount().Currency().Code(),
+		Status:        string(transaction.Status()),
+		FailureReason: transaction.FailureReason(),
+		CreatedAt:     transaction.CreatedAt().UTC().Format(time.RFC3339Nano),
+	}
+
+	if transferID := transaction.TransferID(); transferID != nil {
+		response.TransferID = transferID.String()
+	}
+
+	if reversalOf := transaction.ReversalOf(); reversalOf != nil {
+		response.ReversalOf = reversalOf.String()
+	}
+	response.Reason = transaction.Reason()
+
+	return response
+}
+
+func errInvalidCursor() error {
+	return domain.NewValidationError("cursor", "invalid cursor")
+}
+
+// Cursors are opaque to clients: base64url("<created_at>|<id>").
+func encodeCursor(cursor repository.TransactionCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return repository.TransactionCursor{}, errInvalidCursor()
+	}
+
+	createdAtPart, idPart, found := strings.Cut(string(raw), "|")
+	if !found {
+		return repository.TransactionCursor{}, errInvalidCursor()
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtPart)
+	if err != nil {
+		return repository.TransactionCursor{}, errInvalidCursor()
+	}
+
+	id, err := valueobject.NewUserID(idPart)
+	if err != nil {
+		return repository.TransactionCursor{}, errInvalidCursor()
+	}
+
+	return repository.TransactionCursor{CreatedAt: createdAt, ID: id}, nil
+}
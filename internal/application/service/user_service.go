@@ -0,0 +1,101 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type userService struct {
+	unitOfWork repository.UnitOfWork
+	userRepo   repository.UserRepository
+	walletRepo repository.WalletRepository
+}
+
+// NewUserService creates a new user registration service implementation
+func NewUserService(unitOfWork repository.UnitOfWork, userRepo repository.UserRepository, walletRepo repository.WalletRepository) domainService.UserService {
+	return &userService{
+		unitOfWork: unitOfWork,
+		userRepo:   userRepo,
+		walletRepo: walletRepo,
+	}
+}
+
+// RegisterUser creates the user and their empty default wallet in one unit of
+// work, so that no user is ever left without a wallet.
+func (s *userService) RegisterUser(ctx context.Context, name, externalRef string, currency valueobject.Currency) (*dto.UserResponse, error) {
+	user, err := entity.NewUser(name, externalRef, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := entity.NewWallet(user.ID(), entity.DefaultWalletName, currency)
+	if err != nil {
+		return nil, err
+	}
+	wallet.MarkDefault()
+
+	err = s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		if err := repos.Users.CreateUser(ctx, user); err != nil {
+			return err
+		}
+
+		return repos.Wallets.CreateWallet(ctx, wallet)
+	})
+	if err != nil {
+		log.Printf("❌ Failed to register user %q: %v", user.Name(), err)
+		return nil, err
+	}
+
+	log.Printf("👤 Registered user %s with a %s default wallet %s", user.ID().String(), currency.Code(), wallet.ID().String())
+	response := toUserResponse(user, wallet)
+	return &response, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, userID valueobject.UserID) (*dto.UserResponse, error) {
+	if err := auth.Authorize(ctx, userID); err != nil {
+		log.Printf("🚫 User %s refused: %v", userID.String(), err)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		log.Printf("❌ User %s not found: %v", userID.String(), err)
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.GetWallet(ctx, valueobject.DefaultWalletOf(userID))
+	if errors.Is(err, domain.ErrWalletNotFound) {
+		wallet = nil
+	} else if err != nil {
+		log.Printf("❌ Failed to load default wallet of user %s: %v", userID.String(), err)
+		return nil, err
+	}
+
+	response := toUserResponse(user, wallet)
+	return &response, nil
+}
+
+// toUserResponse describes user; wallet is their default wallet, or nil.
+func toUserResponse(user *entity.User, wallet *entity.Wallet) dto.UserResponse {
+	response := dto.UserResponse{
+		UserID:      user.ID().String(),
+		Name:        user.Name(),
+		ExternalRef: user.ExternalRef(),
+		CreatedAt:   user.CreatedAt().UTC().Format(time.RFC3339Nano),
+	}
+	if wallet != nil {
+		walletResponse := toWalletResponse(wallet)
+		response.DefaultWallet = &walletResponse
+	}
+	return response
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	domainService "bank/internal/domain/service"
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"bank/internal/application/audit"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type walletService struct {
+	unitOfWork repository.UnitOfWork
+	walletRepo repository.WalletRepository
+}
+
+// NewWalletService creates a new wallet management service implementation
+func NewWalletService(unitOfWork repository.UnitOfWork, walletRepo repository.WalletRepository) domainService.WalletService {
+	return &walletService{
+		unitOfWork: unitOfWork,
+		walletRepo: walletRepo,
+	}
+}
+
+// CreateWallet opens an empty wallet for a user. The first wallet a user opens
+// becomes their default wallet.
+func (s *walletService) CreateWallet(ctx context.Context, userID valueobject.UserID, name string, currency valueobject.Currency) (*dto.WalletResponse, error) {
+	if err := auth.Authorize(ctx, userID); err != nil {
+		log.Printf("🚫 Creating wallet %q for user %s refused: %v", name, userID.String(), err)
+		return nil, err
+	}
+
+	wallet, err := entity.NewWallet(userID, name, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		existing, err := repos.Wallets.ListWallets(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			wallet.MarkDefault()
+		}
+
+		return repos.Wallets.CreateWallet(ctx, wallet)
+	})
+	if err != nil {
+		log.Printf("❌ Failed to create wallet %q for user %s: %v", name, userID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("👛 Created %s wallet %q for user %s", currency.Code(), name, userID.String())
+	response := toWalletResponse(wallet)
+	return &response, nil
+}
+
+func (s *walletService) GetWallet(ctx context.Context, walletID valueobject.UserID) (*dto.WalletResponse, error) {
+	wallet, err := s.walletRepo.GetWallet(ctx, valueobject.WalletByID(walletID))
+	if err != nil {
+		log.Printf("❌ Wallet %s not found: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+		log.Printf("🚫 Wallet %s refused: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	response := toWalletResponse(wallet)
+	return &response, nil
+}
+
+func (s *walletService) ListWallets(ctx context.Context, userID valueobject.UserID) (*dto.WalletListResponse, error) {
+	if err := auth.Authorize(ctx, userID); err != nil {
+		log.Printf("🚫 Wallets of user %s refused: %v", userID.String(), err)
+		return nil, err
+	}
+
+	wallets, err := s.walletRepo.ListWallets(ctx, userID)
+	if err != nil {
+		log.Printf("❌ Failed to list wallets for user %s: %v", userID.String(), err)
+		return nil, err
+	}
+
+	response := &dto.WalletListResponse{
+		UserID:  userID.String(),
+		Wallets: make([]dto.WalletResponse, 0, len(wallets)),
+	}
+	for _, wallet := range wallets {
+		response.Wallets = append(response.Wallets, toWalletResponse(wallet))
+	}
+
+	return response, nil
+}
+
+// SetOverdraftLimit changes how far a wallet's balance may go below zero. The
+// wallet is locked so that the change does not interleave with a withdrawal.
+// It takes a supervisor.
+func (s *walletService) SetOverdraftLimit(ctx context.Context, walletID valueobject.UserID, limit valueobject.Money) (*dto.WalletResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeSupervisor); err != nil {
+		log.Printf("🚫 Setting overdraft limit of wallet %s refused: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	var wallet *entity.Wallet
+
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var err error
+		wallet, err = repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(walletID))
+		if err != nil {
+			return err
+		}
+
+		previous := wallet.OverdraftLimit()
+		if err := wallet.SetOverdraftLimit(limit); err != nil {
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletOverdraftLimit(ctx, wallet.ID(), wallet.OverdraftLimit().Amount()); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, repos.Audit, entity.AuditActionOverdraftLimitSet, entity.AuditTargetWallet, wallet.ID().String(), "", map[string]string{
+			"previous_overdraft_limit": strconv.FormatInt(previous.Amount(), 10),
+			"overdraft_limit":          strconv.FormatInt(limit.Amount(), 10),
+			"currency":                 limit.Currency().Code(),
+		})
+	})
+	if err != nil {
+		log.Printf("❌ Failed to set overdraft limit of wallet %s: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("👛 Set overdraft limit of wallet %s to %s", walletID.String(), limit.String())
+	response := toWalletResponse(wallet)
+	return &response, nil
+}
+
+// ChangeStatus moves a wallet to another status and records why. The wallet is
+// locked so that closing it cannot race a deposit. Operators may freeze and
+// block wallets; reactivating or closing one takes a supervisor.
+func (s *walletService) ChangeStatus(ctx context.Context, walletID valueobject.UserID, status entity.WalletStatus, reason string) (*dto.WalletResponse, error) {
+	role := auth.ScopeOperator
+	if status == entity.WalletStatusActive || status == entity.WalletStatusClosed {
+		role = auth.ScopeSupervisor
+	}
+	if err := auth.RequireRole(ctx, role); err != nil {
+		log.Printf("🚫 Changing status of wallet %s to %s refused: %v", walletID.String(), status, err)
+		return nil, err
+	}
+
+	var wallet *entity.Wallet
+	var change *entity.WalletStatusChange
+
+	err := s.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var err error
+		wallet, err = repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(walletID))
+		if err != nil {
+			return err
+		}
+
+		change, err = wallet.ChangeStatus(status, reason, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletStatus(ctx, wallet.ID(), wallet.Status()); err != nil {
+			return err
+		}
+
+		if err := repos.Wallets.InsertWalletStatusChange(ctx, change); err != nil {
+			return err
+		}
+
+		return audit.Record(ctx, repos.Audit, entity.AuditActionWalletStatusChanged, entity.AuditTargetWallet, wallet.ID().String(), change.Reason(), map[string]string{
+			"change_id":   change.ID().String(),
+			"from_status": string(change.From()),
+			"to_status":   string(change.To()),
+		})
+	})
+	if err != nil {
+		log.Printf("❌ Failed to change status of wallet %s to %s: %v", walletID.String(), status, err)
+		return nil, err
+	}
+
+	log.Printf("👛 Changed status of wallet %s from %s to %s: %s", walletID.String(), change.From(), change.To(), change.Reason())
+	response := toWalletResponse(wallet)
+	return &response, nil
+}
+
+func (s *walletService) ListStatusHistory(ctx context.Context, walletID valueobject.UserID) (*dto.WalletStatusHistoryResponse, error) {
+	wallet, err := s.walletRepo.GetWallet(ctx, valueobject.WalletByID(walletID))
+	if err != nil {
+		log.Printf("❌ Wallet %s not found: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	changes, err := s.walletRepo.ListWalletStatusChanges(ctx, walletID)
+	if err != nil {
+		log.Printf("❌ Failed to list status changes of wallet %s: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	response := &dto.WalletStatusHistoryResponse{
+		WalletID: wallet.ID().String(),
+		Status:   string(wallet.Status()),
+		Changes:  make([]dto.WalletStatusChangeResponse, 0, len(changes)),
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, dto.WalletStatusChangeResponse{
+			ChangeID:   change.ID().String(),
+			FromStatus: string(change.From()),
+			ToStatus:   string(change.To()),
+			Reason:     change.Reason(),
+			ChangedAt:  change.ChangedAt().UTC().Format(time.RFC3339Nano),
+		})
+	}
+
+	return response, nil
+}
+
+func toWalletResponse(wallet *entity.Wallet) dto.WalletResponse {
+	return dto.WalletResponse{
+		WalletID:         wallet.ID().String(),
+		UserID:           wallet.UserID().String(),
+		Name:             wallet.Name(),
+		IsDefault:        wallet.IsDefault(),
+		Tier:             string(wallet.Tier()),
+		Status:           string(wallet.Status()),
+		Balance:          wallet.Balance().Amount(),
+		AvailableBalance: wallet.AvailableBalance().Amount(),
+		HeldBalance:      wallet.HeldBalance().Amount(),
+		OverdraftLimit:   wallet.OverdraftLimit().Amount(),
+		Currency:         wallet.Currency().Code(),
+		FormattedBalance: wallet.Balance().String(),
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"bank/internal/application/audit"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+type adjustmentUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+}
+
+// NewAdjustmentUseCase creates a new manual adjustment use case implementation
+func NewAdjustmentUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard) domainusecase.AdjustmentUseCase {
+	return &adjustmentUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+	}
+}
+
+// Adjust applies the adjustment like a deposit or withdrawal: the wallet's
+// status and, for debits, its available balance are respected, but
+// withdrawal limits are not.
+func (uc *adjustmentUseCase) Adjust(ctx context.Context, walletID valueobject.UserID, direction entity.AdjustmentDirection, amount valueobject.Money, reason entity.AdjustmentReason, note string) (*dto.AdjustmentResponse, error) {
+	if err := auth.RequireRole(ctx, auth.ScopeOperator); err != nil {
+		log.Printf("🚫 Adjustment of wallet %s refused: %v", walletID.String(), err)
+		return nil, err
+	}
+
+	note = strings.TrimSpace(note)
+	requestHash := idempotency.HashRequest("adjust", walletID.String(), string(direction), amount.String(), string(reason), note)
+
+	var response *dto.AdjustmentResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.AdjustmentResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for wallet %s: %v", walletID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying adjustment of wallet %s", walletID.String())
+			response = &replayed
+			return nil
+		}
+
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(walletID))
+		if err != nil {
+			log.Printf("❌ Wallet %s not found: %v", walletID.String(), err)
+			return err
+		}
+
+		transaction, err := entity.NewAdjustmentTransaction(wallet.ID(), direction, amount, reason, note)
+		if err != nil {
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if direction == entity.AdjustmentDirectionDebit {
+			err = wallet.Withdraw(amount)
+		} else {
+			err = wallet.Deposit(amount)
+		}
+		if err != nil {
+			log.Printf("❌ %s adjustment rejected for wallet %s: %v", direction, wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Transactions.InsertTransaction(ctx, completed(transaction)); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordAdjustment(ctx, account, direction, amount, transaction.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		err = audit.Record(ctx, repos.Audit, entity.AuditActionBalanceAdjusted, entity.AuditTargetWallet, wallet.ID().String(), transaction.Reason(), map[string]string{
+			"transaction_id": transaction.ID().String(),
+			"direction":      string(direction),
+			"amount":         strconv.FormatInt(amount.Amount(), 10),
+			"currency":       amount.Currency().Code(),
+			"reason_code":    string(reason),
+			"new_balance":    strconv.FormatInt(wallet.Balance().Amount(), 10),
+		})
+		if err != nil {
+			log.Printf("❌ Failed to audit adjustment %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		response = &dto.AdjustmentResponse{
+			TransactionID: transaction.ID().String(),
+			WalletID:      wallet.ID().String(),
+			UserID:        wallet.UserID().String(),
+			Direction:     string(direction),
+			Amount:        amount.Amount(),
+			Currency:      amount.Currency().Code(),
+			ReasonCode:    string(reason),
+			Note:          note,
+			NewBalance:    wallet.Balance().Amount(),
+			Success:       true,
+			Message:       "adjustment successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for wallet %s: %v", walletID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return &dto.AdjustmentResponse{
+			WalletID: walletID.String(),
+			Success:  false,
+			Message:  err.Error(),
+		}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+type depositUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+}
+
+// NewDepositUseCase creates a new deposit use case implementation
+func NewDepositUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard) domainusecase.DepositUseCase {
+	return &depositUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+	}
+}
+
+// Deposit credits money that arrived from outside, so only staff and the
+// services granted the deposit scope may call it, for any wallet. A customer
+// cannot credit their own wallet.
+func (uc *depositUseCase) Deposit(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money) (*dto.DepositResponse, error) {
+	if err := auth.RequireRoleOrScope(ctx, auth.ScopeOperator, auth.ScopeDeposit); err != nil {
+		log.Printf("🚫 Deposit to %s refused: %v", ref.String(), err)
+		return nil, err
+	}
+
+	requestHash := idempotency.HashRequest("deposit", ref.String(), amount.String())
+
+	var response *dto.DepositResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.DepositResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for %s: %v", ref.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying deposit for %s", ref.String())
+			response = &replayed
+			return nil
+		}
+
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, ref)
+		if err != nil {
+			log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for %s: %v", ref.String(), err)
+			return err
+		}
+
+		if err := wallet.Deposit(amount); err != nil {
+			log.Printf("❌ Deposit rejected for %s: %v", ref.String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for %s: %v", ref.String(), err)
+			return err
+		}
+
+		transaction := completed(entity.NewTransaction(
+			wallet.ID(),
+			entity.TransactionTypeDeposit,
+			amount,
+		))
+
+		if err := repos.Transactions.InsertTransaction(ctx, transaction); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordDeposit(ctx, account, amount, transaction.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		response = &dto.DepositResponse{
+			UserID:          wallet.UserID().String(),
+			WalletID:        wallet.ID().String(),
+			AmountDeposited: amount.Amount(),
+			Currency:        amount.Currency().Code(),
+			NewBalance:      wallet.Balance().Amount(),
+			Success:         true,
+			Message:         "deposit successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for %s: %v", ref.String(), err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return &dto.DepositResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	return response, nil
+}
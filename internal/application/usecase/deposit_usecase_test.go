@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bank/internal/application/auth"
+	"bank/internal/application/idempotency"
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestDepositUseCase(t *testing.T) {
+	operator := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "operator", Scopes: []string{auth.ScopeOperator}})
+	processor := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "processor", Scopes: []string{auth.ScopeDeposit}})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		owner       bool
+		failInserts bool
+		wantErr     error
+		wantBalance int64
+	}{
+		{name: "should credit the wallet for an operator", ctx: operator, wantBalance: 12500},
+		{name: "should credit the wallet for a service with the deposit scope", ctx: processor, wantBalance: 12500},
+		{name: "should refuse the wallet's owner", owner: true, wantErr: domain.ErrForbidden, wantBalance: 10000},
+		{name: "should leave the balance unchanged when the unit of work rolls back", ctx: operator, failInserts: true, wantErr: errInsertFailed, wantBalance: 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(tt.failInserts)
+			wallet := f.addWallet(t, 10000)
+			uc := NewDepositUseCase(f.unitOfWork, f.guard)
+			ctx := tt.ctx
+			if tt.owner {
+				ctx = ownerOf(wallet)
+			}
+
+			// Act
+			response, err := uc.Deposit(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && response.NewBalance != tt.wantBalance {
+				t.Errorf("expected new balance %d in the response, got %d", tt.wantBalance, response.NewBalance)
+			}
+			if balance := f.balance(t, wallet); balance != tt.wantBalance {
+				t.Errorf("expected balance %d, got %d", tt.wantBalance, balance)
+			}
+		})
+	}
+
+	t.Run("should replay a deposit sent twice with the same key", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewDepositUseCase(f.unitOfWork, f.guard)
+		ctx := idempotency.WithKey(operator, "deposit-once")
+
+		// Act
+		first, err := uc.Deposit(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+		second, replayErr := uc.Deposit(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+
+		// Assert
+		if err != nil || replayErr != nil {
+			t.Fatalf("expected no error, got %v and %v", err, replayErr)
+		}
+		if *second != *first {
+			t.Errorf("expected the first response to be replayed, got %+v and %+v", first, second)
+		}
+		if balance := f.balance(t, wallet); balance != 12500 {
+			t.Errorf("expected the amount to be credited once, got balance %d", balance)
+		}
+		if count := f.completedTransactions(t, wallet); count != 1 {
+			t.Errorf("expected 1 deposit, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/service"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	// DefaultQuoteTTL is how long a quoted rate can be redeemed.
+	DefaultQuoteTTL = 30 * time.Second
+
+	// DefaultSpreadBps is the share of the source amount, in basis points,
+	// kept by the service on every exchange.
+	DefaultSpreadBps = 50
+)
+
+type exchangeUseCase struct {
+	unitOfWork   repository.UnitOfWork
+	rateProvider service.RateProvider
+	idempotency  *idempotency.Guard
+	spreadBps    int64
+	quoteTTL     time.Duration
+	approvals    approvals.Policy
+}
+
+// NewExchangeUseCase creates a new currency exchange use case implementation.
+// Exchanges whose source amount is above the approval thresholds of
+// approvalPolicy are refused, like transfers and holds.
+func NewExchangeUseCase(unitOfWork repository.UnitOfWork, rateProvider service.RateProvider, idempotencyGuard *idempotency.Guard, spreadBps int64, quoteTTL time.Duration, approvalPolicy approvals.Policy) domainusecase.ExchangeUseCase {
+	if quoteTTL <= 0 {
+		quoteTTL = DefaultQuoteTTL
+	}
+	return &exchangeUseCase{
+		unitOfWork:   unitOfWork,
+		rateProvider: rateProvider,
+		idempotency:  idempotencyGuard,
+		spreadBps:    spreadBps,
+		quoteTTL:     quoteTTL,
+		approvals:    approvalPolicy,
+	}
+}
+
+func (uc *exchangeUseCase) Quote(ctx context.Context, fromWalletID, toWalletID valueobject.UserID, amount valueobject.Money) (*dto.ExchangeQuoteResponse, error) {
+	var quote *entity.ExchangeQuote
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		fromWallet, err := repos.Wallets.GetWallet(ctx, valueobject.WalletByID(fromWalletID))
+		if err != nil {
+			return err
+		}
+
+		// Both wallets must belong to the same user, which the quote checks.
+		if err := auth.Authorize(ctx, fromWallet.UserID()); err != nil {
+			return err
+		}
+
+		toWallet, err := repos.Wallets.GetWallet(ctx, valueobject.WalletByID(toWalletID))
+		if err != nil {
+			return err
+		}
+
+		rate, err := uc.rateProvider.GetRate(ctx, fromWallet.Currency(), toWallet.Currency())
+		if err != nil {
+			return err
+		}
+
+		quote, err = entity.NewExchangeQuote(fromWallet, toWallet, amount, rate, uc.spreadBps, time.Now(), uc.quoteTTL)
+		if err != nil {
+			return err
+		}
+
+		return repos.Quotes.InsertQuote(ctx, quote)
+	})
+	if err != nil {
+		log.Printf("❌ Failed to quote exchange from wallet %s to wallet %s: %v", fromWalletID.String(), toWalletID.String(), err)
+		return nil, err
+	}
+
+	log.Printf("💱 Quoted %s to %s at %s for wallet %s, valid until %s",
+		quote.SourceAmount().String(), quote.TargetAmount().String(), quote.Rate().String(),
+		fromWalletID.String(), quote.ExpiresAt().Format(time.RFC3339))
+
+	return &dto.ExchangeQuoteResponse{
+		QuoteID:        quote.ID().String(),
+		UserID:         quote.UserID().String(),
+		FromWalletID:   quote.FromWalletID().String(),
+		ToWalletID:     quote.ToWalletID().String(),
+		Rate:           quote.Rate().String(),
+		SourceAmount:   quote.SourceAmount().Amount(),
+		SourceCurrency: quote.SourceAmount().Currency().Code(),
+		Fee:            quote.Fee().Amount(),
+		TargetAmount:   quote.TargetAmount().Amount(),
+		TargetCurrency: quote.TargetAmount().Currency().Code(),
+		ExpiresAt:      quote.ExpiresAt().UTC().Format(time.RFC3339Nano),
+	}, nil
+}
+
+func (uc *exchangeUseCase) Exchange(ctx context.Context, quoteID valueobject.UserID) (*dto.ExchangeResponse, error) {
+	failed := func(message string) *dto.ExchangeResponse {
+		return &dto.ExchangeResponse{
+			QuoteID: quoteID.String(),
+			Success: false,
+			Message: message,
+		}
+	}
+
+	requestHash := idempotency.HashRequest("exchange", quoteID.String())
+
+	var response *dto.ExchangeResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.ExchangeResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for exchange quote %s: %v", quoteID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying exchange for quote %s", quoteID.String())
+			response = &replayed
+			return nil
+		}
+
+		quote, err := repos.Quotes.GetQuoteForUpdate(ctx, quoteID)
+		if err != nil {
+			log.Printf("❌ Exchange quote %s not found: %v", quoteID.String(), err)
+			return err
+		}
+
+		if err := auth.Authorize(ctx, quote.UserID()); err != nil {
+			log.Printf("🚫 Exchange of quote %s refused: %v", quoteID.String(), err)
+			return err
+		}
+
+		if uc.approvals.Requires(quote.SourceAmount()) {
+			log.Printf("🚦 Exchange of quote %s for %s is above the approval threshold", quoteID.String(), quote.SourceAmount().String())
+			return domain.ErrApprovalRequired
+		}
+
+		if err := quote.Redeem(time.Now()); err != nil {
+			log.Printf("❌ Exchange quote %s cannot be redeemed: %v", quoteID.String(), err)
+			return err
+		}
+
+		fromWallet, toWallet, err := lockWallets(ctx, repos.Wallets,
+			valueobject.WalletByID(quote.FromWalletID()), valueobject.WalletByID(quote.ToWalletID()))
+		if err != nil {
+			log.Printf("❌ Cannot lock wallets for exchange quote %s: %v", quoteID.String(), err)
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		fromAccount, err := recorder.OpenWalletAccount(ctx, fromWallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		toAccount, err := recorder.OpenWalletAccount(ctx, toWallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		if err := fromWallet.Withdraw(quote.SourceAmount()); err != nil {
+			log.Printf("💸 Exchange rejected for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		if err := toWallet.Deposit(quote.TargetAmount()); err != nil {
+			log.Printf("❌ Exchange rejected for target wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, fromWallet.ID(), fromWallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, toWallet.ID(), toWallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		legs := []*entity.Transaction{
+			completed(entity.NewTransferTransaction(fromWallet.ID(), entity.TransactionTypeExchangeOut, quote.SourceAmount(), quote.ID())),
+			completed(entity.NewTransferTransaction(toWallet.ID(), entity.TransactionTypeExchangeIn, quote.TargetAmount(), quote.ID())),
+		}
+
+		for _, leg := range legs {
+			if err := repos.Transactions.InsertTransaction(ctx, leg); err != nil {
+				log.Printf("❌ Failed to save transaction %s: %v", leg.ID().String(), err)
+				return err
+			}
+		}
+
+		if err := recorder.RecordExchange(ctx, fromAccount, toAccount, quote.SourceAmount(), quote.Fee(), quote.TargetAmount(), quote.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for exchange %s: %v", quoteID.String(), err)
+			return err
+		}
+
+		if err := repos.Quotes.MarkQuoteUsed(ctx, quote); err != nil {
+			log.Printf("❌ Failed to mark exchange quote %s as used: %v", quoteID.String(), err)
+			return err
+		}
+
+		response = &dto.ExchangeResponse{
+			QuoteID:        quote.ID().String(),
+			UserID:         quote.UserID().String(),
+			FromWalletID:   fromWallet.ID().String(),
+			ToWalletID:     toWallet.ID().String(),
+			Rate:           quote.Rate().String(),
+			AmountDebited:  quote.SourceAmount().Amount(),
+			SourceCurrency: quote.SourceAmount().Currency().Code(),
+			Fee:            quote.Fee().Amount(),
+			AmountCredited: quote.TargetAmount().Amount(),
+			TargetCurrency: quote.TargetAmount().Currency().Code(),
+			NewBalance:     fromWallet.Balance().Amount(),
+			Success:        true,
+			Message:        "exchange successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for exchange %s: %v", quoteID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return failed(err.Error()), err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+// fixedRate quotes every pair at the same rate.
+type fixedRate struct {
+	rate string
+}
+
+func (p fixedRate) GetRate(ctx context.Context, base, quote valueobject.Currency) (valueobject.ExchangeRate, error) {
+	return valueobject.NewExchangeRate(base, quote, p.rate)
+}
+
+// addExchangeWallets creates a user with a USD wallet holding balance and an
+// empty EUR wallet.
+func (f *fixture) addExchangeWallets(t *testing.T, balance int64) (*entity.Wallet, *entity.Wallet) {
+	t.Helper()
+	user, err := entity.NewUser("Ada Lovelace", "", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error creating user: %v", err)
+	}
+	f.store.AddUser(user)
+	from := f.store.AddWallet(user.ID(), usd(t, balance))
+
+	eur, _ := valueobject.NewCurrency("EUR")
+	to, _ := entity.NewWallet(user.ID(), "euros", eur)
+	if err := memory.NewWalletRepository(f.store).CreateWallet(context.Background(), to); err != nil {
+		t.Fatalf("unexpected error creating wallet: %v", err)
+	}
+	return from, to
+}
+
+// ledgerCredits sums the credits posted to a system account.
+func (f *fixture) ledgerCredits(t *testing.T, code string) int64 {
+	t.Helper()
+	ledgerRepo := memory.NewLedgerRepository(f.store)
+	account, err := ledgerRepo.GetSystemAccount(context.Background(), code)
+	if err != nil {
+		t.Fatalf("unexpected error reading account %s: %v", code, err)
+	}
+	_, credits, err := ledgerRepo.GetAccountTotals(context.Background(), account.ID())
+	if err != nil {
+		t.Fatalf("unexpected error reading totals of %s: %v", code, err)
+	}
+	return credits
+}
+
+// assertJournalBalances checks that the debits equal the credits in every
+// currency.
+func (f *fixture) assertJournalBalances(t *testing.T) {
+	t.Helper()
+	totals, err := memory.NewLedgerRepository(f.store).GetLedgerTotals(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error reading ledger totals: %v", err)
+	}
+	for _, total := range totals {
+		if total.Debits != total.Credits {
+			t.Errorf("expected the %s postings to balance, got %d debits and %d credits", total.Currency, total.Debits, total.Credits)
+		}
+	}
+}
+
+func TestExchangeUseCase(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       int64
+		spreadBps    int64
+		wantFee      int64
+		wantCredited int64
+	}{
+		{name: "should round a half cent down to the even cent", amount: 10001, wantCredited: 5000},
+		{name: "should round a half cent up to the even cent", amount: 10003, wantCredited: 5002},
+		{name: "should take the fee before converting and round it down to the even cent", amount: 10100, spreadBps: 50, wantFee: 50, wantCredited: 5025},
+		{name: "should take the fee before converting and round it up to the even cent", amount: 10300, spreadBps: 50, wantFee: 52, wantCredited: 5124},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(false)
+			from, to := f.addExchangeWallets(t, 100000)
+			uc := NewExchangeUseCase(f.unitOfWork, fixedRate{rate: "0.5"}, f.guard, tt.spreadBps, time.Minute, approvals.Policy{})
+			quote, err := uc.Quote(ownerOf(from), from.ID(), to.ID(), usd(t, tt.amount))
+			if err != nil {
+				t.Fatalf("unexpected error quoting: %v", err)
+			}
+			quoteID, _ := valueobject.NewUserID(quote.QuoteID)
+
+			// Act
+			response, err := uc.Exchange(ownerOf(from), quoteID)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if response.Fee != tt.wantFee || response.AmountCredited != tt.wantCredited {
+				t.Errorf("expected fee %d and %d credited, got %d and %d", tt.wantFee, tt.wantCredited, response.Fee, response.AmountCredited)
+			}
+			if balance := f.balance(t, from); balance != 100000-tt.amount {
+				t.Errorf("expected source balance %d, got %d", 100000-tt.amount, balance)
+			}
+			if balance := f.balance(t, to); balance != tt.wantCredited {
+				t.Errorf("expected target balance %d, got %d", tt.wantCredited, balance)
+			}
+			if revenue := f.ledgerCredits(t, entity.SystemAccountFXRevenue); revenue != tt.wantFee {
+				t.Errorf("expected %d booked as FX revenue, got %d", tt.wantFee, revenue)
+			}
+			f.assertJournalBalances(t)
+		})
+	}
+
+	t.Run("should refuse a quote that was already used", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from, to := f.addExchangeWallets(t, 100000)
+		uc := NewExchangeUseCase(f.unitOfWork, fixedRate{rate: "0.5"}, f.guard, 0, time.Minute, approvals.Policy{})
+		quote, err := uc.Quote(ownerOf(from), from.ID(), to.ID(), usd(t, 10000))
+		if err != nil {
+			t.Fatalf("unexpected error quoting: %v", err)
+		}
+		quoteID, _ := valueobject.NewUserID(quote.QuoteID)
+		if _, err := uc.Exchange(ownerOf(from), quoteID); err != nil {
+			t.Fatalf("unexpected error exchanging: %v", err)
+		}
+
+		// Act
+		_, err = uc.Exchange(ownerOf(from), quoteID)
+
+		// Assert
+		if !errors.Is(err, domain.ErrQuoteAlreadyUsed) {
+			t.Errorf("expected ErrQuoteAlreadyUsed, got %v", err)
+		}
+		if f.balance(t, from) != 90000 || f.balance(t, to) != 5000 {
+			t.Errorf("expected the quote to be exchanged once, got balances %d and %d", f.balance(t, from), f.balance(t, to))
+		}
+	})
+
+	t.Run("should refuse an expired quote", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from, to := f.addExchangeWallets(t, 100000)
+		rate, _ := fixedRate{rate: "0.5"}.GetRate(context.Background(), from.Currency(), to.Currency())
+		quote, err := entity.NewExchangeQuote(from, to, usd(t, 10000), rate, 0, time.Now().Add(-time.Hour), time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error quoting: %v", err)
+		}
+		if err := memory.NewExchangeQuoteRepository(f.store).InsertQuote(context.Background(), quote); err != nil {
+			t.Fatalf("unexpected error saving quote: %v", err)
+		}
+		uc := NewExchangeUseCase(f.unitOfWork, fixedRate{rate: "0.5"}, f.guard, 0, time.Minute, approvals.Policy{})
+
+		// Act
+		_, err = uc.Exchange(ownerOf(from), quote.ID())
+
+		// Assert
+		if !errors.Is(err, domain.ErrQuoteExpired) {
+			t.Errorf("expected ErrQuoteExpired, got %v", err)
+		}
+		if f.balance(t, from) != 100000 || f.balance(t, to) != 0 {
+			t.Errorf("expected both balances unchanged, got %d and %d", f.balance(t, from), f.balance(t, to))
+		}
+	})
+
+	t.Run("should refuse a source amount above the approval threshold", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from, to := f.addExchangeWallets(t, 100000)
+		uc := NewExchangeUseCase(f.unitOfWork, fixedRate{rate: "0.5"}, f.guard, 0, time.Minute, approvalPolicy)
+		quote, err := uc.Quote(ownerOf(from), from.ID(), to.ID(), usd(t, 60000))
+		if err != nil {
+			t.Fatalf("unexpected error quoting: %v", err)
+		}
+		quoteID, _ := valueobject.NewUserID(quote.QuoteID)
+
+		// Act
+		_, err = uc.Exchange(ownerOf(from), quoteID)
+
+		// Assert
+		if !errors.Is(err, domain.ErrApprovalRequired) {
+			t.Errorf("expected ErrApprovalRequired, got %v", err)
+		}
+		if f.balance(t, from) != 100000 || f.balance(t, to) != 0 {
+			t.Errorf("expected both balances unchanged, got %d and %d", f.balance(t, from), f.balance(t, to))
+		}
+	})
+}
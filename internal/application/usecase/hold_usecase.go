@@ -0,0 +1,447 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/application/limits"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	// DefaultHoldTTL is how long a hold lasts when neither the request nor the
+	// configuration sets an expiry.
+	DefaultHoldTTL = 7 * 24 * time.Hour
+
+	// expiredHoldBatchSize bounds how many expired holds are read at once.
+	expiredHoldBatchSize = 100
+)
+
+type holdUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+	defaultTTL  time.Duration
+	approvals   approvals.Policy
+}
+
+// NewHoldUseCase creates a new hold use case implementation. Holds and
+// captures above the approval thresholds of approvalPolicy are refused, as
+// they would otherwise move the money without the second approval a
+// withdrawal of the same amount needs.
+func NewHoldUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard, defaultTTL time.Duration, approvalPolicy approvals.Policy) domainusecase.HoldUseCase {
+	if defaultTTL <= 0 {
+		defaultTTL = DefaultHoldTTL
+	}
+	return &holdUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+		defaultTTL:  defaultTTL,
+		approvals:   approvalPolicy,
+	}
+}
+
+func (uc *holdUseCase) PlaceHold(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money, ttl time.Duration) (*dto.HoldResponse, error) {
+	if ttl == 0 {
+		ttl = uc.defaultTTL
+	}
+
+	requestHash := idempotency.HashRequest("hold", ref.String(), amount.String(), ttl.String())
+
+	var response *dto.HoldResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.HoldResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for %s: %v", ref.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying hold for %s", ref.String())
+			response = &replayed
+			return nil
+		}
+
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, ref)
+		if err != nil {
+			log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			log.Printf("🚫 Hold on %s refused: %v", ref.String(), err)
+			return err
+		}
+
+		// A hold the withdrawal limits or approval thresholds would never let
+		// be captured is refused up front; the capture checks them again.
+		if err := uc.checkHoldLimits(ctx, repos, wallet, amount); err != nil {
+			return err
+		}
+
+		hold, err := entity.NewHold(wallet.ID(), amount, time.Now(), ttl)
+		if err != nil {
+			return err
+		}
+
+		available := wallet.AvailableBalance().Amount()
+		if err := wallet.PlaceHold(amount); err != nil {
+			log.Printf("💸 Hold rejected for %s: attempted %d, available %d: %v",
+				ref.String(), amount.Amount(), available, err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+			log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Holds.InsertHold(ctx, hold); err != nil {
+			log.Printf("❌ Failed to save hold %s: %v", hold.ID().String(), err)
+			return err
+		}
+
+		response = toHoldResponse(hold, wallet)
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for %s: %v", ref.String(), err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (uc *holdUseCase) GetHold(ctx context.Context, holdID valueobject.UserID) (*dto.HoldResponse, error) {
+	var response *dto.HoldResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		hold, err := repos.Holds.GetHold(ctx, holdID)
+		if err != nil {
+			return err
+		}
+
+		wallet, err := repos.Wallets.GetWallet(ctx, valueobject.WalletByID(hold.WalletID()))
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			return err
+		}
+
+		response = toHoldResponse(hold, wallet)
+		return nil
+	})
+	if err != nil {
+		log.Printf("❌ Failed to get hold %s: %v", holdID.String(), err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (uc *holdUseCase) CaptureHold(ctx context.Context, holdID valueobject.UserID, amount *valueobject.Money) (*dto.HoldResponse, error) {
+	requestedAmount := "full"
+	if amount != nil {
+		requestedAmount = amount.String()
+	}
+	requestHash := idempotency.HashRequest("capture-hold", holdID.String(), requestedAmount)
+
+	var response *dto.HoldResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.HoldResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for hold %s: %v", holdID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying capture of hold %s", holdID.String())
+			response = &replayed
+			return nil
+		}
+
+		// The hold is locked before its wallet, in the same order as releases
+		// and the expiry sweep.
+		hold, wallet, err := lockHold(ctx, repos, holdID)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			log.Printf("🚫 Capture of hold %s refused: %v", holdID.String(), err)
+			return err
+		}
+
+		captured := hold.Amount()
+		if amount != nil {
+			captured = *amount
+		}
+
+		// The capture is the withdrawal that counts towards the limits and
+		// the approval thresholds.
+		if err := uc.checkHoldLimits(ctx, repos, wallet, captured); err != nil {
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		transaction := entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, captured)
+
+		if err := hold.Capture(captured, transaction.ID(), time.Now()); err != nil {
+			log.Printf("❌ Hold %s cannot be captured: %v", holdID.String(), err)
+			return err
+		}
+
+		if err := wallet.CaptureHold(hold.Amount(), captured); err != nil {
+			log.Printf("❌ Capture of hold %s rejected for wallet %s: %v", holdID.String(), wallet.ID().String(), err)
+			return err
+		}
+
+		// The held balance shrinks first: it may never exceed the balance.
+		if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+			log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Transactions.InsertTransaction(ctx, completed(transaction)); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordWithdrawal(ctx, account, captured, transaction.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Holds.UpdateHold(ctx, hold); err != nil {
+			log.Printf("❌ Failed to update hold %s: %v", holdID.String(), err)
+			return err
+		}
+
+		response = toHoldResponse(hold, wallet)
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for hold %s: %v", holdID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (uc *holdUseCase) ReleaseHold(ctx context.Context, holdID valueobject.UserID) (*dto.HoldResponse, error) {
+	requestHash := idempotency.HashRequest("release-hold", holdID.String())
+
+	var response *dto.HoldResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.HoldResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for hold %s: %v", holdID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying release of hold %s", holdID.String())
+			response = &replayed
+			return nil
+		}
+
+		hold, wallet, err := lockHold(ctx, repos, holdID)
+		if err != nil {
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			log.Printf("🚫 Release of hold %s refused: %v", holdID.String(), err)
+			return err
+		}
+
+		if err := hold.Release(time.Now()); err != nil {
+			log.Printf("❌ Hold %s cannot be released: %v", holdID.String(), err)
+			return err
+		}
+
+		if err := uc.returnHeldFunds(ctx, repos, hold, wallet); err != nil {
+			return err
+		}
+
+		response = toHoldResponse(hold, wallet)
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for hold %s: %v", holdID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ReleaseExpiredHolds expires each hold in its own unit of work, so that one
+// failure neither undoes nor blocks the others for long.
+func (uc *holdUseCase) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int, error) {
+	released := 0
+
+	for {
+		var expired []*entity.Hold
+		err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+			var err error
+			expired, err = repos.Holds.ListExpiredHolds(ctx, now, expiredHoldBatchSize)
+			return err
+		})
+		if err != nil {
+			return released, err
+		}
+
+		for _, candidate := range expired {
+			expiredNow := false
+			err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+				hold, wallet, err := lockHold(ctx, repos, candidate.ID())
+				if err != nil {
+					return err
+				}
+
+				// Captured or released since it was listed.
+				if hold.Status() != entity.HoldStatusActive {
+					return nil
+				}
+
+				if err := hold.Expire(now); err != nil {
+					return err
+				}
+
+				if err := uc.returnHeldFunds(ctx, repos, hold, wallet); err != nil {
+					return err
+				}
+
+				expiredNow = true
+				return nil
+			})
+			if err != nil {
+				return released, err
+			}
+			if expiredNow {
+				released++
+			}
+		}
+
+		if len(expired) < expiredHoldBatchSize {
+			return released, nil
+		}
+	}
+}
+
+// returnHeldFunds makes the amount of a released or expired hold available
+// again and stores the hold.
+func (uc *holdUseCase) returnHeldFunds(ctx context.Context, repos repository.Repositories, hold *entity.Hold, wallet *entity.Wallet) error {
+	if err := wallet.ReleaseHold(hold.Amount()); err != nil {
+		log.Printf("❌ Cannot release hold %s on wallet %s: %v", hold.ID().String(), wallet.ID().String(), err)
+		return err
+	}
+
+	if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+		log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+		return err
+	}
+
+	if err := repos.Holds.UpdateHold(ctx, hold); err != nil {
+		log.Printf("❌ Failed to update hold %s: %v", hold.ID().String(), err)
+		return err
+	}
+
+	return nil
+}
+
+// checkHoldLimits tests a hold or capture of amount against the approval
+// thresholds and the withdrawal limits of wallet, which must be locked. Amounts
+// above a threshold have to go through a withdrawal request instead.
+func (uc *holdUseCase) checkHoldLimits(ctx context.Context, repos repository.Repositories, wallet *entity.Wallet, amount valueobject.Money) error {
+	if uc.approvals.Requires(amount) {
+		log.Printf("🚦 Hold of %s on wallet %s is above the approval threshold", amount.String(), wallet.ID().String())
+		return domain.ErrApprovalRequired
+	}
+
+	err := limits.CheckWithdrawal(ctx, repos, wallet, amount, time.Now())
+	if errors.Is(err, domain.ErrLimitExceeded) {
+		log.Printf("💸 Withdrawal limit exceeded for wallet %s: %v", wallet.ID().String(), err)
+	} else if err != nil {
+		log.Printf("❌ Failed to check withdrawal limits for wallet %s: %v", wallet.ID().String(), err)
+	}
+	return err
+}
+
+// lockHold locks a hold and then its wallet.
+func lockHold(ctx context.Context, repos repository.Repositories, holdID valueobject.UserID) (*entity.Hold, *entity.Wallet, error) {
+	hold, err := repos.Holds.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		log.Printf("❌ Hold %s not found: %v", holdID.String(), err)
+		return nil, nil, err
+	}
+
+	wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(hold.WalletID()))
+	if err != nil {
+		log.Printf("❌ Wallet %s of hold %s not found: %v", hold.WalletID().String(), holdID.String(), err)
+		return nil, nil, err
+	}
+
+	return hold, wallet, nil
+}
+
+func toHoldResponse(hold *entity.Hold, wallet *entity.Wallet) *dto.HoldResponse {
+	response := &dto.HoldResponse{
+		HoldID:           hold.ID().String(),
+		WalletID:         wallet.ID().String(),
+		UserID:           wallet.UserID().String(),
+		Amount:           hold.Amount().Amount(),
+		CapturedAmount:   hold.CapturedAmount().Amount(),
+		Currency:         hold.Amount().Currency().Code(),
+		Status:           string(hold.Status()),
+		ExpiresAt:        hold.ExpiresAt().UTC().Format(time.RFC3339Nano),
+		Balance:          wallet.Balance().Amount(),
+		AvailableBalance: wallet.AvailableBalance().Amount(),
+		HeldBalance:      wallet.HeldBalance().Amount(),
+	}
+	if transactionID := hold.TransactionID(); transactionID != nil {
+		response.TransactionID = transactionID.String()
+	}
+	return response
+}
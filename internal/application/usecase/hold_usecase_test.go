@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+// placeHold holds amount of wallet for ttl as its owner.
+func (f *fixture) placeHold(t *testing.T, uc domainusecase.HoldUseCase, wallet *entity.Wallet, amount int64, ttl time.Duration) valueobject.UserID {
+	t.Helper()
+	response, err := uc.PlaceHold(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, amount), ttl)
+	if err != nil {
+		t.Fatalf("unexpected error placing hold: %v", err)
+	}
+	holdID, _ := valueobject.NewUserID(response.HoldID)
+	return holdID
+}
+
+// addExpiredHold stores a hold of amount on wallet that expired an hour ago
+// and has not been swept yet.
+func (f *fixture) addExpiredHold(t *testing.T, wallet *entity.Wallet, amount int64) valueobject.UserID {
+	t.Helper()
+	hold, err := entity.NewHold(wallet.ID(), usd(t, amount), time.Now().Add(-2*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error creating hold: %v", err)
+	}
+	if err := memory.NewHoldRepository(f.store).InsertHold(context.Background(), hold); err != nil {
+		t.Fatalf("unexpected error saving hold: %v", err)
+	}
+	if err := memory.NewWalletRepository(f.store).UpdateWalletHeldBalance(context.Background(), wallet.ID(), f.heldBalance(t, wallet)+amount); err != nil {
+		t.Fatalf("unexpected error holding funds: %v", err)
+	}
+	return hold.ID()
+}
+
+func (f *fixture) holdStatus(t *testing.T, holdID valueobject.UserID) entity.HoldStatus {
+	t.Helper()
+	hold, err := memory.NewHoldRepository(f.store).GetHold(context.Background(), holdID)
+	if err != nil {
+		t.Fatalf("unexpected error reading hold: %v", err)
+	}
+	return hold.Status()
+}
+
+func TestHoldUseCaseCapture(t *testing.T) {
+	tests := []struct {
+		name          string
+		capture       *int64
+		wantInvalid   bool
+		wantCaptured  int64
+		wantBalance   int64
+		wantHeld      int64
+		wantStatus    entity.HoldStatus
+		wantCompleted int
+	}{
+		{name: "should capture the whole hold without an amount", wantCaptured: 5000, wantBalance: 5000, wantStatus: entity.HoldStatusCaptured, wantCompleted: 1},
+		{name: "should release the remainder of a partial capture", capture: int64Ptr(3000), wantCaptured: 3000, wantBalance: 7000, wantStatus: entity.HoldStatusCaptured, wantCompleted: 1},
+		{name: "should refuse an amount above the hold", capture: int64Ptr(6000), wantInvalid: true, wantBalance: 10000, wantHeld: 5000, wantStatus: entity.HoldStatusActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(false)
+			wallet := f.addWallet(t, 10000)
+			uc := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+			holdID := f.placeHold(t, uc, wallet, 5000, 0)
+			var amount *valueobject.Money
+			if tt.capture != nil {
+				captured := usd(t, *tt.capture)
+				amount = &captured
+			}
+
+			// Act
+			response, err := uc.CaptureHold(ownerOf(wallet), holdID, amount)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if tt.wantInvalid != errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error %t, got %v", tt.wantInvalid, err)
+			}
+			if !tt.wantInvalid && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tt.wantInvalid && (response.CapturedAmount != tt.wantCaptured || response.AvailableBalance != tt.wantBalance) {
+				t.Errorf("expected %d captured and %d available in the response, got %d and %d", tt.wantCaptured, tt.wantBalance, response.CapturedAmount, response.AvailableBalance)
+			}
+			if f.balance(t, wallet) != tt.wantBalance || f.heldBalance(t, wallet) != tt.wantHeld {
+				t.Errorf("expected balance %d with %d held, got %d with %d held", tt.wantBalance, tt.wantHeld, f.balance(t, wallet), f.heldBalance(t, wallet))
+			}
+			if status := f.holdStatus(t, holdID); status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, status)
+			}
+			if count := f.completedTransactions(t, wallet); count != tt.wantCompleted {
+				t.Errorf("expected %d completed withdrawals, got %d", tt.wantCompleted, count)
+			}
+		})
+	}
+
+	t.Run("should refuse to capture a released hold", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+		holdID := f.placeHold(t, uc, wallet, 5000, 0)
+		if _, err := uc.ReleaseHold(ownerOf(wallet), holdID); err != nil {
+			t.Fatalf("unexpected error releasing hold: %v", err)
+		}
+
+		// Act
+		_, err := uc.CaptureHold(ownerOf(wallet), holdID, nil)
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+		if f.balance(t, wallet) != 10000 || f.heldBalance(t, wallet) != 0 {
+			t.Errorf("expected balance 10000 with nothing held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+	})
+
+	t.Run("should refuse to capture an expired hold", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		holdID := f.addExpiredHold(t, wallet, 5000)
+		uc := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+
+		// Act
+		_, err := uc.CaptureHold(ownerOf(wallet), holdID, nil)
+
+		// Assert
+		if !errors.Is(err, domain.ErrHoldExpired) {
+			t.Errorf("expected ErrHoldExpired, got %v", err)
+		}
+		if f.balance(t, wallet) != 10000 || f.completedTransactions(t, wallet) != 0 {
+			t.Errorf("expected nothing withdrawn, got balance %d", f.balance(t, wallet))
+		}
+	})
+}
+
+func TestHoldUseCaseAvailableBalance(t *testing.T) {
+	t.Run("should leave only the available balance to a later withdrawal", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		holds := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+		f.placeHold(t, holds, wallet, 8000, 0)
+		withdraw := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvals.Policy{})
+
+		// Act
+		_, aboveErr := withdraw.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, 3000))
+		_, withinErr := withdraw.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, 2000))
+
+		// Assert
+		if !errors.Is(aboveErr, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds above the available balance, got %v", aboveErr)
+		}
+		if withinErr != nil {
+			t.Errorf("expected the available balance to be withdrawn, got %v", withinErr)
+		}
+		if f.balance(t, wallet) != 8000 || f.heldBalance(t, wallet) != 8000 {
+			t.Errorf("expected balance 8000 with 8000 held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+	})
+}
+
+func TestHoldUseCaseReleaseExpiredHolds(t *testing.T) {
+	t.Run("should release only the holds that have expired", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+		expiring := f.placeHold(t, uc, wallet, 3000, time.Minute)
+		lasting := f.placeHold(t, uc, wallet, 2000, 0)
+
+		// Act
+		released, err := uc.ReleaseExpiredHolds(context.Background(), time.Now().Add(2*time.Minute))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if released != 1 {
+			t.Errorf("expected 1 released hold, got %d", released)
+		}
+		if f.holdStatus(t, expiring) != entity.HoldStatusExpired || f.holdStatus(t, lasting) != entity.HoldStatusActive {
+			t.Errorf("expected EXPIRED and ACTIVE, got %s and %s", f.holdStatus(t, expiring), f.holdStatus(t, lasting))
+		}
+		if f.balance(t, wallet) != 10000 || f.heldBalance(t, wallet) != 2000 {
+			t.Errorf("expected balance 10000 with 2000 held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+	})
+
+	t.Run("should release a hold that expired before it was captured", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		holdID := f.addExpiredHold(t, wallet, 5000)
+		uc := NewHoldUseCase(f.unitOfWork, f.guard, time.Hour, approvals.Policy{})
+
+		// Act
+		released, err := uc.ReleaseExpiredHolds(context.Background(), time.Now())
+
+		// Assert
+		if err != nil || released != 1 {
+			t.Fatalf("expected 1 released hold, got %d and %v", released, err)
+		}
+		if status := f.holdStatus(t, holdID); status != entity.HoldStatusExpired {
+			t.Errorf("expected status EXPIRED, got %s", status)
+		}
+		if f.heldBalance(t, wallet) != 0 {
+			t.Errorf("expected nothing held, got %d", f.heldBalance(t, wallet))
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+type reversalUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+}
+
+// NewReversalUseCase creates a new withdrawal reversal use case implementation
+func NewReversalUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard) domainusecase.ReversalUseCase {
+	return &reversalUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+	}
+}
+
+func (uc *reversalUseCase) Reverse(ctx context.Context, transactionID valueobject.UserID, amount *valueobject.Money, reason string) (*dto.ReversalResponse, error) {
+	requestedAmount := "full"
+	if amount != nil {
+		requestedAmount = amount.String()
+	}
+	requestHash := idempotency.HashRequest("reverse", transactionID.String(), requestedAmount, reason)
+
+	var response *dto.ReversalResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.ReversalResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for transaction %s: %v", transactionID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying reversal of transaction %s", transactionID.String())
+			response = &replayed
+			return nil
+		}
+
+		// Locking the withdrawal serialises concurrent reversals of it; its
+		// wallet is locked second.
+		original, err := repos.Transactions.GetTransactionForUpdate(ctx, transactionID)
+		if err != nil {
+			log.Printf("❌ Transaction %s not found: %v", transactionID.String(), err)
+			return err
+		}
+
+		existing, err := repos.Transactions.GetReversal(ctx, transactionID)
+		if err != nil {
+			log.Printf("❌ Failed to look up reversals of transaction %s: %v", transactionID.String(), err)
+			return err
+		}
+		if existing != nil {
+			log.Printf("❌ Transaction %s was already reversed by %s", transactionID.String(), existing.ID().String())
+			return domain.ErrTransactionAlreadyReversed
+		}
+
+		reversed := original.Amount()
+		if amount != nil {
+			reversed = *amount
+		}
+
+		reversal, err := entity.NewReversalTransaction(original, reversed, reason)
+		if err != nil {
+			log.Printf("❌ Transaction %s cannot be reversed: %v", transactionID.String(), err)
+			return err
+		}
+
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(original.WalletID()))
+		if err != nil {
+			log.Printf("❌ Wallet %s of transaction %s not found: %v", original.WalletID().String(), transactionID.String(), err)
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := wallet.Deposit(reversed); err != nil {
+			log.Printf("❌ Reversal of transaction %s rejected for wallet %s: %v", transactionID.String(), wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Transactions.InsertTransaction(ctx, completed(reversal)); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", reversal.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordReversal(ctx, account, reversed, reversal.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", reversal.ID().String(), err)
+			return err
+		}
+
+		response = &dto.ReversalResponse{
+			ReversalID:            reversal.ID().String(),
+			OriginalTransactionID: original.ID().String(),
+			WalletID:              wallet.ID().String(),
+			UserID:                wallet.UserID().String(),
+			AmountReversed:        reversed.Amount(),
+			OriginalAmount:        original.Amount().Amount(),
+			Currency:              reversed.Currency().Code(),
+			Reason:                reason,
+			NewBalance:            wallet.Balance().Amount(),
+			Success:               true,
+			Message:               "reversal successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for transaction %s: %v", transactionID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return &dto.ReversalResponse{
+			OriginalTransactionID: transactionID.String(),
+			Success:               false,
+			Message:               err.Error(),
+		}, err
+	}
+
+	return response, nil
+}
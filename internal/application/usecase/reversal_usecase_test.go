@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bank/internal/application/approvals"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+// withdraw takes amount from wallet as its owner and returns the withdrawal.
+func (f *fixture) withdraw(t *testing.T, wallet *entity.Wallet, amount int64) valueobject.UserID {
+	t.Helper()
+	uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvals.Policy{})
+	if _, err := uc.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, amount)); err != nil {
+		t.Fatalf("unexpected error withdrawing: %v", err)
+	}
+
+	transactions, err := memory.NewTransactionRepository(f.store).ListTransactions(context.Background(), wallet.ID(), repository.TransactionFilter{Limit: 1})
+	if err != nil || len(transactions) != 1 {
+		t.Fatalf("expected the withdrawal, got %d transactions and %v", len(transactions), err)
+	}
+	return transactions[0].ID()
+}
+
+func TestReversalUseCase(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       *int64
+		wantInvalid  bool
+		wantReversed int64
+		wantBalance  int64
+	}{
+		{name: "should return the whole withdrawal without an amount", wantReversed: 4000, wantBalance: 10000},
+		{name: "should return part of the withdrawal", amount: int64Ptr(1500), wantReversed: 1500, wantBalance: 7500},
+		{name: "should refuse an amount above the withdrawal", amount: int64Ptr(4001), wantInvalid: true, wantBalance: 6000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(false)
+			wallet := f.addWallet(t, 10000)
+			withdrawalID := f.withdraw(t, wallet, 4000)
+			uc := NewReversalUseCase(f.unitOfWork, f.guard)
+			var amount *valueobject.Money
+			if tt.amount != nil {
+				reversed := usd(t, *tt.amount)
+				amount = &reversed
+			}
+
+			// Act
+			response, err := uc.Reverse(context.Background(), withdrawalID, amount, "duplicate payout")
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if tt.wantInvalid != errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error %t, got %v", tt.wantInvalid, err)
+			}
+			if !tt.wantInvalid && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if balance := f.balance(t, wallet); balance != tt.wantBalance {
+				t.Errorf("expected balance %d, got %d", tt.wantBalance, balance)
+			}
+			f.assertJournalBalances(t)
+			if tt.wantInvalid {
+				return
+			}
+
+			if response.AmountReversed != tt.wantReversed || response.OriginalAmount != 4000 || response.NewBalance != tt.wantBalance {
+				t.Errorf("expected %d of 4000 reversed to balance %d, got %+v", tt.wantReversed, tt.wantBalance, response)
+			}
+			reversal, err := memory.NewTransactionRepository(f.store).GetReversal(context.Background(), withdrawalID)
+			if err != nil || reversal == nil {
+				t.Fatalf("expected the reversal of the withdrawal, got %v", err)
+			}
+			if reversal.ID().String() != response.ReversalID || reversal.Type() != entity.TransactionTypeReversal {
+				t.Errorf("expected the reversal %s, got %s %s", response.ReversalID, reversal.Type(), reversal.ID().String())
+			}
+			if reversalOf := reversal.ReversalOf(); reversalOf == nil || !reversalOf.Equals(withdrawalID) {
+				t.Errorf("expected the reversal to point at withdrawal %s, got %v", withdrawalID.String(), reversalOf)
+			}
+			if reversal.Amount().Amount() != tt.wantReversed || reversal.Reason() != "duplicate payout" {
+				t.Errorf("expected %d reversed for the reason given, got %d for %q", tt.wantReversed, reversal.Amount().Amount(), reversal.Reason())
+			}
+		})
+	}
+
+	t.Run("should refuse a second reversal of the same withdrawal", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		withdrawalID := f.withdraw(t, wallet, 4000)
+		uc := NewReversalUseCase(f.unitOfWork, f.guard)
+		partial := usd(t, 1500)
+		if _, err := uc.Reverse(context.Background(), withdrawalID, &partial, "duplicate payout"); err != nil {
+			t.Fatalf("unexpected error reversing: %v", err)
+		}
+
+		// Act
+		_, err := uc.Reverse(context.Background(), withdrawalID, nil, "the rest too")
+
+		// Assert
+		if !errors.Is(err, domain.ErrTransactionAlreadyReversed) {
+			t.Errorf("expected ErrTransactionAlreadyReversed, got %v", err)
+		}
+		if balance := f.balance(t, wallet); balance != 7500 {
+			t.Errorf("expected only the first reversal to be credited, got balance %d", balance)
+		}
+	})
+
+	t.Run("should refuse to reverse anything but a withdrawal", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from := f.addWallet(t, 10000)
+		to := f.addWallet(t, 0)
+		transfer := NewTransferUseCase(f.unitOfWork, f.guard, approvals.Policy{})
+		if _, err := transfer.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 4000)); err != nil {
+			t.Fatalf("unexpected error transferring: %v", err)
+		}
+		transactions, _ := memory.NewTransactionRepository(f.store).ListTransactions(context.Background(), from.ID(), repository.TransactionFilter{Limit: 1})
+		uc := NewReversalUseCase(f.unitOfWork, f.guard)
+
+		// Act
+		_, err := uc.Reverse(context.Background(), transactions[0].ID(), nil, "sent by mistake")
+
+		// Assert
+		if !errors.Is(err, domain.ErrTransactionNotReversible) {
+			t.Errorf("expected ErrTransactionNotReversible, got %v", err)
+		}
+		if balance := f.balance(t, from); balance != 6000 {
+			t.Errorf("expected balance 6000, got %d", balance)
+		}
+	})
+}
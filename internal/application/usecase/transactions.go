@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+)
+
+// completed marks a transaction that is inserted in the same unit of work as
+// the balance change it describes, so it either commits as COMPLETED or not at
+// all.
+func completed(transaction *entity.Transaction) *entity.Transaction {
+	// A freshly created transaction is always pending, so this cannot fail.
+	_ = transaction.Complete()
+	return transaction
+}
+
+// recordFailed keeps an audit trail of a declined operation. It runs after the
+// operation's unit of work has rolled back, through a standalone repository;
+// a failure to record is logged but does not change the outcome.
+func recordFailed(ctx context.Context, transactionRepo repository.TransactionRepository, transaction *entity.Transaction, reason string) {
+	if err := transaction.Fail(reason); err != nil {
+		log.Printf("❌ Failed to mark transaction %s as failed: %v", transaction.ID().String(), err)
+		return
+	}
+
+	if err := transactionRepo.InsertTransaction(ctx, transaction); err != nil {
+		log.Printf("❌ Failed to record failed transaction %s: %v", transaction.ID().String(), err)
+	}
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/application/limits"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+type transferUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+	approvals   approvals.Policy
+}
+
+// NewTransferUseCase creates a new transfer use case implementation.
+// Transfers above the approval thresholds of approvalPolicy are refused, as
+// they would otherwise move the money without the second approval a
+// withdrawal of the same amount needs.
+func NewTransferUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard, approvalPolicy approvals.Policy) domainusecase.TransferUseCase {
+	return &transferUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+		approvals:   approvalPolicy,
+	}
+}
+
+func (uc *transferUseCase) Transfer(ctx context.Context, from, to valueobject.WalletRef, amount valueobject.Money) (*dto.TransferResponse, error) {
+	failed := func(message string) *dto.TransferResponse {
+		return &dto.TransferResponse{
+			Success: false,
+			Message: message,
+		}
+	}
+
+	if from == to {
+		return failed(domain.ErrSameWallet.Error()), domain.ErrSameWallet
+	}
+
+	requestHash := idempotency.HashRequest("transfer", from.String(), to.String(), amount.String())
+
+	var response *dto.TransferResponse
+
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.TransferResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for transfer from %s to %s: %v", from.String(), to.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying transfer %s", replayed.TransferID)
+			response = &replayed
+			return nil
+		}
+
+		fromWallet, toWallet, err := lockWallets(ctx, repos.Wallets, from, to)
+		if err != nil {
+			log.Printf("❌ Cannot lock wallets for transfer from %s to %s: %v", from.String(), to.String(), err)
+			return err
+		}
+
+		// Anyone may be paid; only the sender's wallet needs authorising.
+		if err := auth.Authorize(ctx, fromWallet.UserID()); err != nil {
+			log.Printf("🚫 Transfer from %s refused: %v", from.String(), err)
+			return err
+		}
+
+		if uc.approvals.Requires(amount) {
+			log.Printf("🚦 Transfer of %s from %s is above the approval threshold", amount.String(), from.String())
+			return domain.ErrApprovalRequired
+		}
+
+		// A transfer takes money out of the sender's wallet as a withdrawal does.
+		if err := limits.CheckWithdrawal(ctx, repos, fromWallet, amount, time.Now()); err != nil {
+			if errors.Is(err, domain.ErrLimitExceeded) {
+				log.Printf("💸 Withdrawal limit exceeded for transfer from %s: %v", from.String(), err)
+			} else {
+				log.Printf("❌ Failed to check withdrawal limits for transfer from %s: %v", from.String(), err)
+			}
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		fromAccount, err := recorder.OpenWalletAccount(ctx, fromWallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		toAccount, err := recorder.OpenWalletAccount(ctx, toWallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		if err := fromWallet.Withdraw(amount); err != nil {
+			log.Printf("💸 Transfer rejected for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		if err := toWallet.Deposit(amount); err != nil {
+			log.Printf("❌ Transfer rejected for recipient wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, fromWallet.ID(), fromWallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", fromWallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, toWallet.ID(), toWallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", toWallet.ID().String(), err)
+			return err
+		}
+
+		transferID := valueobject.NewUserIDRandom()
+		legs := []*entity.Transaction{
+			completed(entity.NewTransferTransaction(fromWallet.ID(), entity.TransactionTypeTransferOut, amount, transferID)),
+			completed(entity.NewTransferTransaction(toWallet.ID(), entity.TransactionTypeTransferIn, amount, transferID)),
+		}
+
+		for _, leg := range legs {
+			if err := repos.Transactions.InsertTransaction(ctx, leg); err != nil {
+				log.Printf("❌ Failed to save transaction %s: %v", leg.ID().String(), err)
+				return err
+			}
+		}
+
+		if err := recorder.RecordTransfer(ctx, fromAccount, toAccount, amount, transferID.String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transfer %s: %v", transferID.String(), err)
+			return err
+		}
+
+		response = &dto.TransferResponse{
+			TransferID:        transferID.String(),
+			FromUserID:        fromWallet.UserID().String(),
+			FromWalletID:      fromWallet.ID().String(),
+			ToUserID:          toWallet.UserID().String(),
+			ToWalletID:        toWallet.ID().String(),
+			AmountTransferred: amount.Amount(),
+			Currency:          amount.Currency().Code(),
+			NewBalance:        fromWallet.Balance().Amount(),
+			Success:           true,
+			Message:           "transfer successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for transfer %s: %v", transferID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return failed(err.Error()), err
+	}
+
+	return response, nil
+}
+
+// lockWallets locks both wallets in a deterministic order, by wallet ID, so
+// that two opposite transfers between the same pair of wallets cannot
+// deadlock. References by user are resolved to wallet IDs first; a user's
+// default wallet never changes, so the resolution cannot go stale.
+func lockWallets(ctx context.Context, walletRepo repository.WalletRepository, from, to valueobject.WalletRef) (*entity.Wallet, *entity.Wallet, error) {
+	fromID, err := resolveWalletID(ctx, walletRepo, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toID, err := resolveWalletID(ctx, walletRepo, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fromID.Equals(toID) {
+		return nil, nil, domain.ErrSameWallet
+	}
+
+	first, second := fromID, toID
+	if second.String() < first.String() {
+		first, second = second, first
+	}
+
+	firstWallet, err := walletRepo.GetWalletForUpdate(ctx, valueobject.WalletByID(first))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secondWallet, err := walletRepo.GetWalletForUpdate(ctx, valueobject.WalletByID(second))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if first.Equals(fromID) {
+		return firstWallet, secondWallet, nil
+	}
+	return secondWallet, firstWallet, nil
+}
+
+func resolveWalletID(ctx context.Context, walletRepo repository.WalletRepository, ref valueobject.WalletRef) (valueobject.UserID, error) {
+	if walletID, ok := ref.WalletID(); ok {
+		return walletID, nil
+	}
+
+	wallet, err := walletRepo.GetWallet(ctx, ref)
+	if err != nil {
+		return valueobject.UserID{}, err
+	}
+	return wallet.ID(), nil
+}
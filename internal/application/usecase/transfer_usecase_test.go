@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/idempotency"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+// slowLockUnitOfWork pauses after each wallet lock, so that concurrent
+// transfers hold one wallet while they ask for the other.
+type slowLockUnitOfWork struct {
+	unitOfWork repository.UnitOfWork
+}
+
+func (u slowLockUnitOfWork) RunInTx(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return u.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		repos.Wallets = slowLockWalletRepository{repos.Wallets}
+		return fn(ctx, repos)
+	})
+}
+
+type slowLockWalletRepository struct {
+	repository.WalletRepository
+}
+
+func (r slowLockWalletRepository) GetWalletForUpdate(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error) {
+	wallet, err := r.WalletRepository.GetWalletForUpdate(ctx, ref)
+	time.Sleep(time.Millisecond)
+	return wallet, err
+}
+
+func TestTransferUseCase(t *testing.T) {
+	tests := []struct {
+		name            string
+		amount          int64
+		failInserts     bool
+		wantErr         error
+		wantFromBalance int64
+		wantToBalance   int64
+	}{
+		{name: "should move the amount between the wallets", amount: 2500, wantFromBalance: 7500, wantToBalance: 7500},
+		{name: "should refuse an amount above the sender's balance", amount: 20000, wantErr: domain.ErrInsufficientFunds, wantFromBalance: 10000, wantToBalance: 5000},
+		{name: "should leave both balances unchanged when the unit of work rolls back", amount: 2500, failInserts: true, wantErr: errInsertFailed, wantFromBalance: 10000, wantToBalance: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(tt.failInserts)
+			from := f.addWallet(t, 10000)
+			to := f.addWallet(t, 5000)
+			uc := NewTransferUseCase(f.unitOfWork, f.guard, approvals.Policy{})
+
+			// Act
+			response, err := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, tt.amount))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && response.NewBalance != tt.wantFromBalance {
+				t.Errorf("expected new balance %d in the response, got %d", tt.wantFromBalance, response.NewBalance)
+			}
+			if balance := f.balance(t, from); balance != tt.wantFromBalance {
+				t.Errorf("expected sender balance %d, got %d", tt.wantFromBalance, balance)
+			}
+			if balance := f.balance(t, to); balance != tt.wantToBalance {
+				t.Errorf("expected recipient balance %d, got %d", tt.wantToBalance, balance)
+			}
+		})
+	}
+
+	t.Run("should replay a transfer sent twice with the same key", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from := f.addWallet(t, 10000)
+		to := f.addWallet(t, 5000)
+		uc := NewTransferUseCase(f.unitOfWork, f.guard, approvals.Policy{})
+		ctx := idempotency.WithKey(ownerOf(from), "transfer-once")
+
+		// Act
+		first, err := uc.Transfer(ctx, valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 2500))
+		second, replayErr := uc.Transfer(ctx, valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 2500))
+
+		// Assert
+		if err != nil || replayErr != nil {
+			t.Fatalf("expected no error, got %v and %v", err, replayErr)
+		}
+		if *second != *first {
+			t.Errorf("expected the first response to be replayed, got %+v and %+v", first, second)
+		}
+		if f.balance(t, from) != 7500 || f.balance(t, to) != 7500 {
+			t.Errorf("expected the amount to move once, got balances %d and %d", f.balance(t, from), f.balance(t, to))
+		}
+	})
+
+	t.Run("should hold the sender to its withdrawal limits", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from := f.addWallet(t, 2000000)
+		to := f.addWallet(t, 0)
+		uc := NewTransferUseCase(f.unitOfWork, f.guard, approvals.Policy{})
+		for range 2 {
+			if _, err := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 450000)); err != nil {
+				t.Fatalf("unexpected error transferring: %v", err)
+			}
+		}
+
+		// Act
+		_, perTransactionErr := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 600000))
+		_, dailyErr := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 200000))
+
+		// Assert
+		var limitErr *domain.LimitExceededError
+		if !errors.As(perTransactionErr, &limitErr) || limitErr.Limit != entity.LimitPerTransaction {
+			t.Errorf("expected the per-transaction limit to be exceeded, got %v", perTransactionErr)
+		}
+		if !errors.As(dailyErr, &limitErr) || limitErr.Limit != entity.LimitDaily || limitErr.Remaining != 100000 {
+			t.Errorf("expected the daily limit to be exceeded with 100000 remaining, got %v", dailyErr)
+		}
+		if balance := f.balance(t, from); balance != 1100000 {
+			t.Errorf("expected balance 1100000, got %d", balance)
+		}
+	})
+
+	t.Run("should refuse an amount above the approval threshold", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		from := f.addWallet(t, 100000)
+		to := f.addWallet(t, 0)
+		policy := approvals.Policy{Thresholds: map[string]int64{"USD": 50000}}
+		uc := NewTransferUseCase(f.unitOfWork, f.guard, policy)
+
+		// Act
+		_, aboveErr := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 50001))
+		_, atErr := uc.Transfer(ownerOf(from), valueobject.WalletByID(from.ID()), valueobject.WalletByID(to.ID()), usd(t, 50000))
+
+		// Assert
+		if !errors.Is(aboveErr, domain.ErrApprovalRequired) {
+			t.Errorf("expected ErrApprovalRequired, got %v", aboveErr)
+		}
+		if atErr != nil {
+			t.Errorf("expected the threshold itself to be allowed, got %v", atErr)
+		}
+		if f.balance(t, from) != 50000 || f.balance(t, to) != 50000 {
+			t.Errorf("expected only the second transfer to move money, got balances %d and %d", f.balance(t, from), f.balance(t, to))
+		}
+	})
+
+	t.Run("should refuse a transfer to the same wallet, however it is named", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewTransferUseCase(f.unitOfWork, f.guard, approvals.Policy{})
+
+		// Act
+		_, sameRefErr := uc.Transfer(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+		_, resolvedErr := uc.Transfer(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), valueobject.DefaultWalletOf(wallet.UserID()), usd(t, 2500))
+
+		// Assert
+		if !errors.Is(sameRefErr, domain.ErrSameWallet) || !errors.Is(resolvedErr, domain.ErrSameWallet) {
+			t.Errorf("expected ErrSameWallet, got %v and %v", sameRefErr, resolvedErr)
+		}
+		if balance := f.balance(t, wallet); balance != 10000 {
+			t.Errorf("expected balance 10000, got %d", balance)
+		}
+	})
+
+	t.Run("should not deadlock on opposite transfers between the same wallets", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		a := f.addWallet(t, 100000)
+		b := f.addWallet(t, 100000)
+		uc := NewTransferUseCase(slowLockUnitOfWork{unitOfWork: f.unitOfWork}, f.guard, approvals.Policy{})
+		const transfers = 20
+		toB, toA := usd(t, 100), usd(t, 300)
+
+		// A deadlock shows as transfers running out of time.
+		ctxA, cancelA := context.WithTimeout(ownerOf(a), 5*time.Second)
+		defer cancelA()
+		ctxB, cancelB := context.WithTimeout(ownerOf(b), 5*time.Second)
+		defer cancelB()
+
+		// Act
+		errs := make(chan error, 2*transfers)
+		var wg sync.WaitGroup
+		for range transfers {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := uc.Transfer(ctxA, valueobject.WalletByID(a.ID()), valueobject.WalletByID(b.ID()), toB)
+				errs <- err
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := uc.Transfer(ctxB, valueobject.DefaultWalletOf(b.UserID()), valueobject.DefaultWalletOf(a.UserID()), toA)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Assert
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("expected every transfer to succeed without deadlocking, got %v", err)
+			}
+		}
+		balanceA, balanceB := f.balance(t, a), f.balance(t, b)
+		if balanceA+balanceB != 200000 {
+			t.Errorf("expected the total balance to stay 200000, got %d", balanceA+balanceB)
+		}
+		if balanceA != 100000+transfers*200 {
+			t.Errorf("expected wallet A to end with %d, got %d", 100000+transfers*200, balanceA)
+		}
+	})
+}
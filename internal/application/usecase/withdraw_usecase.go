@@ -2,10 +2,17 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"log"
+	"time"
 
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
 	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/application/limits"
+	"bank/internal/domain"
 	"bank/internal/domain/entity"
 	"bank/internal/domain/repository"
 	domainusecase "bank/internal/domain/usecase"
@@ -13,101 +20,203 @@ import (
 )
 
 type withdrawUseCase struct {
-	walletRepo      repository.WalletRepository
+	unitOfWork      repository.UnitOfWork
 	transactionRepo repository.TransactionRepository
-	db              *sql.DB
+	idempotency     *idempotency.Guard
+	approvals       approvals.Policy
 }
 
 // NewWithdrawUseCase creates a new withdraw use case implementation
-func NewWithdrawUseCase(walletRepo repository.WalletRepository, transactionRepo repository.TransactionRepository, db *sql.DB) domainusecase.WithdrawUseCase {
+func NewWithdrawUseCase(unitOfWork repository.UnitOfWork, transactionRepo repository.TransactionRepository, idempotencyGuard *idempotency.Guard, approvalPolicy approvals.Policy) domainusecase.WithdrawUseCase {
 	return &withdrawUseCase{
-		walletRepo:      walletRepo,
+		unitOfWork:      unitOfWork,
 		transactionRepo: transactionRepo,
-		db:              db,
+		idempotency:     idempotencyGuard,
+		approvals:       approvalPolicy,
 	}
 }
 
-func (uc *withdrawUseCase) Withdraw(ctx context.Context, userID valueobject.UserID, amount valueobject.Money) (*dto.WithdrawResponse, error) {
+func (uc *withdrawUseCase) Withdraw(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money) (*dto.WithdrawResponse, error) {
+	requestHash := idempotency.HashRequest("withdraw", ref.String(), amount.String())
 
-	// Begin transaction
-	tx, err := uc.db.BeginTx(ctx, nil)
-	if err != nil {
-		log.Printf("❌ Failed to begin transaction for user %s: %v", userID.String(), err)
-		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
-			Success: false,
-			Message: "failed to begin transaction",
-		}, err
-	}
+	var response *dto.WithdrawResponse
+	var declined *entity.Transaction
 
-	defer func() {
+	err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.WithdrawResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
 		if err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Printf("❌ Failed to rollback transaction for user %s: %v", userID.String(), rbErr)
+			log.Printf("❌ Idempotency check failed for %s: %v", ref.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying withdrawal for %s", ref.String())
+			response = &replayed
+			return nil
+		}
+
+		wallet, err := repos.Wallets.GetWalletForUpdate(ctx, ref)
+		if err != nil {
+			log.Printf("❌ Wallet not found for %s: %v", ref.String(), err)
+			return err
+		}
+
+		if err := auth.Authorize(ctx, wallet.UserID()); err != nil {
+			log.Printf("🚫 Withdrawal from %s refused: %v", ref.String(), err)
+			return err
+		}
+
+		if err := limits.CheckWithdrawal(ctx, repos, wallet, amount, time.Now()); err != nil {
+			if errors.Is(err, domain.ErrLimitExceeded) {
+				log.Printf("💸 Withdrawal limit exceeded for %s: %v", ref.String(), err)
+				declined = entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, amount)
+			} else {
+				log.Printf("❌ Failed to check withdrawal limits for %s: %v", ref.String(), err)
 			}
+			return err
+		}
+
+		if uc.approvals.Requires(amount) {
+			response, err = uc.holdForApproval(ctx, repos, wallet, amount)
+			if err != nil {
+				if errors.Is(err, domain.ErrInsufficientFunds) {
+					declined = entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, amount)
+				}
+				return err
+			}
+
+			if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+				log.Printf("❌ Failed to store idempotency key for %s: %v", ref.String(), err)
+				return err
+			}
+
+			return nil
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for %s: %v", ref.String(), err)
+			return err
+		}
+
+		available := wallet.AvailableBalance().Amount()
+		if err := wallet.Withdraw(amount); err != nil {
+			if errors.Is(err, domain.ErrInsufficientFunds) {
+				log.Printf("💸 Insufficient funds for %s: attempted %d, available %d",
+					ref.String(), amount.Amount(), available)
+				declined = entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, amount)
+			}
+			return err
+		}
+
+		newBalance := wallet.Balance().Amount()
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), newBalance); err != nil {
+			log.Printf("❌ Failed to update wallet balance for %s: %v", ref.String(), err)
+			return err
+		}
+
+		transaction := completed(entity.NewTransaction(
+			wallet.ID(),
+			entity.TransactionTypeWithdrawal,
+			amount,
+		))
+
+		if err := repos.Transactions.InsertTransaction(ctx, transaction); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordWithdrawal(ctx, account, amount, transaction.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		response = &dto.WithdrawResponse{
+			UserID:          wallet.UserID().String(),
+			WalletID:        wallet.ID().String(),
+			AmountWithdrawn: amount.Amount(),
+			Currency:        amount.Currency().Code(),
+			NewBalance:      newBalance,
+			Success:         true,
+			Message:         "withdrawal successful",
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for %s: %v", ref.String(), err)
+			return err
 		}
-	}()
 
-	wallet, err := uc.walletRepo.GetWalletForUpdate(ctx, tx, userID)
+		return nil
+	})
+
 	if err != nil {
-		log.Printf("❌ Wallet not found for user %s: %v", userID.String(), err)
+		// The declined attempt is recorded once the unit of work has rolled
+		// back, since its insert would otherwise wait on the wallet row lock.
+		if declined != nil {
+			recordFailed(ctx, uc.transactionRepo, declined, err.Error())
+		}
+
 		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
 			Success: false,
-			Message: "wallet not found",
+			Message: err.Error(),
 		}, err
 	}
 
-	if wallet.Balance().Amount() < amount.Amount() {
-		log.Printf("💸 Insufficient funds for user %s: attempted %d, available %d",
-			userID.String(), amount.Amount(), wallet.Balance().Amount())
-		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
-			Success: false,
-			Message: "insufficient funds",
-		}, nil
+	return response, nil
+}
+
+// holdForApproval holds amount on the wallet and leaves the withdrawal waiting
+// as a request, which is executed once someone other than the caller approves
+// it. Limits were checked against the amount already; they are checked again
+// on approval.
+func (uc *withdrawUseCase) holdForApproval(ctx context.Context, repos repository.Repositories, wallet *entity.Wallet, amount valueobject.Money) (*dto.WithdrawResponse, error) {
+	// The requester has to be known for someone else to approve the request.
+	principal, err := auth.Identify(ctx)
+	if err != nil {
+		log.Printf("🚫 Withdrawal from wallet %s needs approval but the caller is not identified: %v", wallet.ID().String(), err)
+		return nil, err
 	}
 
-	newBalance := wallet.Balance().Amount() - amount.Amount()
+	request, event, err := entity.NewWithdrawalRequest(wallet.ID(), amount, principal.Subject, time.Now(), uc.approvals.RequestTTL())
+	if err != nil {
+		return nil, err
+	}
 
-	if err := uc.walletRepo.UpdateWalletBalance(ctx, tx, wallet.ID(), newBalance); err != nil {
-		log.Printf("❌ Failed to update wallet balance for user %s: %v", userID.String(), err)
-		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
-			Success: false,
-			Message: "failed to update wallet balance",
-		}, err
+	available := wallet.AvailableBalance().Amount()
+	if err := wallet.PlaceHold(amount); err != nil {
+		if errors.Is(err, domain.ErrInsufficientFunds) {
+			log.Printf("💸 Insufficient funds for %s: attempted %d, available %d",
+				wallet.ID().String(), amount.Amount(), available)
+		}
+		return nil, err
 	}
 
-	transaction := entity.NewTransaction(
-		wallet.ID(),
-		entity.TransactionTypeWithdrawal,
-		amount,
-	)
+	if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+		log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+		return nil, err
+	}
 
-	if err := uc.transactionRepo.InsertTransaction(ctx, tx, transaction); err != nil {
-		log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
-		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
-			Success: false,
-			Message: "failed to record transaction",
-		}, err
+	if err := repos.WithdrawalRequests.InsertWithdrawalRequest(ctx, request); err != nil {
+		log.Printf("❌ Failed to save withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		log.Printf("❌ Failed to commit transaction for user %s: %v", userID.String(), err)
-		return &dto.WithdrawResponse{
-			UserID:  userID.String(),
-			Success: false,
-			Message: "failed to commit transaction",
-		}, err
+	if err := repos.WithdrawalRequests.InsertWithdrawalRequestEvent(ctx, event); err != nil {
+		log.Printf("❌ Failed to record history of withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
 	}
 
 	return &dto.WithdrawResponse{
-		UserID:          userID.String(),
-		AmountWithdrawn: amount.Amount(),
-		NewBalance:      newBalance,
-		Success:         true,
-		Message:         "withdrawal successful",
+		UserID:              wallet.UserID().String(),
+		WalletID:            wallet.ID().String(),
+		AmountWithdrawn:     0,
+		Currency:            amount.Currency().Code(),
+		NewBalance:          wallet.Balance().Amount(),
+		Success:             true,
+		Message:             "withdrawal awaiting approval",
+		WithdrawalRequestID: request.ID().String(),
+		ApprovalExpiresAt:   request.ExpiresAt().UTC().Format(time.RFC3339Nano),
 	}, nil
 }
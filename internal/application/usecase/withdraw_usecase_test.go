@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/application/idempotency"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+var errInsertFailed = errors.New("insert failed")
+
+// failingUnitOfWork fails every transaction insert, which the use cases make
+// after they changed the balances in the same unit of work.
+type failingUnitOfWork struct {
+	unitOfWork repository.UnitOfWork
+}
+
+func (u failingUnitOfWork) RunInTx(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	return u.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		repos.Transactions = failingTransactionRepository{repos.Transactions}
+		return fn(ctx, repos)
+	})
+}
+
+type failingTransactionRepository struct {
+	repository.TransactionRepository
+}
+
+func (failingTransactionRepository) InsertTransaction(ctx context.Context, transaction *entity.Transaction) error {
+	return errInsertFailed
+}
+
+type fixture struct {
+	store      *memory.Store
+	unitOfWork repository.UnitOfWork
+	guard      *idempotency.Guard
+}
+
+func newFixture(failInserts bool) *fixture {
+	store := memory.NewStore()
+	var unitOfWork repository.UnitOfWork = memory.NewUnitOfWork(store)
+	if failInserts {
+		unitOfWork = failingUnitOfWork{unitOfWork: unitOfWork}
+	}
+
+	return &fixture{
+		store:      store,
+		unitOfWork: unitOfWork,
+		guard:      idempotency.NewGuard(memory.NewIdempotencyRepository(store), 0),
+	}
+}
+
+func usd(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, valueobject.DefaultCurrency())
+	if err != nil {
+		t.Fatalf("unexpected error creating amount: %v", err)
+	}
+	return money
+}
+
+func (f *fixture) addWallet(t *testing.T, balance int64) *entity.Wallet {
+	t.Helper()
+	return f.store.AddWallet(valueobject.NewUserIDRandom(), usd(t, balance))
+}
+
+func (f *fixture) balance(t *testing.T, wallet *entity.Wallet) int64 {
+	t.Helper()
+	stored, err := memory.NewWalletRepository(f.store).GetWallet(context.Background(), valueobject.WalletByID(wallet.ID()))
+	if err != nil {
+		t.Fatalf("unexpected error reading wallet: %v", err)
+	}
+	return stored.Balance().Amount()
+}
+
+func (f *fixture) completedTransactions(t *testing.T, wallet *entity.Wallet) int {
+	t.Helper()
+	transactions, err := memory.NewTransactionRepository(f.store).ListTransactions(context.Background(), wallet.ID(), repository.TransactionFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error listing transactions: %v", err)
+	}
+
+	count := 0
+	for _, transaction := range transactions {
+		if transaction.Status() == entity.TransactionStatusCompleted {
+			count++
+		}
+	}
+	return count
+}
+
+// ownerOf acts as the customer owning wallet.
+func ownerOf(wallet *entity.Wallet) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: wallet.UserID().String()})
+}
+
+func TestWithdrawUseCase(t *testing.T) {
+	tests := []struct {
+		name        string
+		balance     int64
+		amount      int64
+		failInserts bool
+		wantErr     error
+		wantBalance int64
+	}{
+		{name: "should take the amount from the balance", balance: 10000, amount: 2500, wantBalance: 7500},
+		{name: "should refuse an amount above the balance", balance: 1000, amount: 2500, wantErr: domain.ErrInsufficientFunds, wantBalance: 1000},
+		{name: "should leave the balance unchanged when the unit of work rolls back", balance: 10000, amount: 2500, failInserts: true, wantErr: errInsertFailed, wantBalance: 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(tt.failInserts)
+			wallet := f.addWallet(t, tt.balance)
+			uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvals.Policy{})
+
+			// Act
+			response, err := uc.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, tt.amount))
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && response.NewBalance != tt.wantBalance {
+				t.Errorf("expected new balance %d in the response, got %d", tt.wantBalance, response.NewBalance)
+			}
+			if balance := f.balance(t, wallet); balance != tt.wantBalance {
+				t.Errorf("expected balance %d, got %d", tt.wantBalance, balance)
+			}
+		})
+	}
+
+	t.Run("should replay a withdrawal sent twice with the same key", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvals.Policy{})
+		ctx := idempotency.WithKey(ownerOf(wallet), "withdraw-once")
+
+		// Act
+		first, err := uc.Withdraw(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+		second, replayErr := uc.Withdraw(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+
+		// Assert
+		if err != nil || replayErr != nil {
+			t.Fatalf("expected no error, got %v and %v", err, replayErr)
+		}
+		if *second != *first {
+			t.Errorf("expected the first response to be replayed, got %+v and %+v", first, second)
+		}
+		if balance := f.balance(t, wallet); balance != 7500 {
+			t.Errorf("expected the amount to be taken once, got balance %d", balance)
+		}
+		if count := f.completedTransactions(t, wallet); count != 1 {
+			t.Errorf("expected 1 withdrawal, got %d", count)
+		}
+	})
+
+	t.Run("should refuse a key reused for another amount", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 10000)
+		uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvals.Policy{})
+		ctx := idempotency.WithKey(ownerOf(wallet), "withdraw-once")
+		_, _ = uc.Withdraw(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 2500))
+
+		// Act
+		_, err := uc.Withdraw(ctx, valueobject.WalletByID(wallet.ID()), usd(t, 3000))
+
+		// Assert
+		if !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+			t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+		}
+		if balance := f.balance(t, wallet); balance != 7500 {
+			t.Errorf("expected balance 7500, got %d", balance)
+		}
+	})
+}
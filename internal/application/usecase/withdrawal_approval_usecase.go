@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/audit"
+	"bank/internal/application/auth"
+	"bank/internal/application/dto"
+	"bank/internal/application/idempotency"
+	"bank/internal/application/ledger"
+	"bank/internal/application/limits"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	domainusecase "bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+)
+
+// expiredRequestBatchSize bounds how many expired withdrawal requests are read
+// at once.
+const expiredRequestBatchSize = 100
+
+type withdrawalApprovalUseCase struct {
+	unitOfWork  repository.UnitOfWork
+	idempotency *idempotency.Guard
+}
+
+// NewWithdrawalApprovalUseCase creates a new withdrawal approval use case
+// implementation
+func NewWithdrawalApprovalUseCase(unitOfWork repository.UnitOfWork, idempotencyGuard *idempotency.Guard) domainusecase.WithdrawalApprovalUseCase {
+	return &withdrawalApprovalUseCase{
+		unitOfWork:  unitOfWork,
+		idempotency: idempotencyGuard,
+	}
+}
+
+func (uc *withdrawalApprovalUseCase) Approve(ctx context.Context, requestID valueobject.UserID, reason string) (*dto.WithdrawalRequestResponse, error) {
+	principal, err := auth.Identify(ctx)
+	if err == nil {
+		err = auth.RequireRole(ctx, auth.ScopeOperator)
+	}
+	if err != nil {
+		log.Printf("🚫 Approval of withdrawal request %s refused: %v", requestID.String(), err)
+		return nil, err
+	}
+
+	requestHash := idempotency.HashRequest("approve-withdrawal", requestID.String(), reason)
+
+	var response *dto.WithdrawalRequestResponse
+	var overLimit error
+
+	err = uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.WithdrawalRequestResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for withdrawal request %s: %v", requestID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying approval of withdrawal request %s", requestID.String())
+			response = &replayed
+			return nil
+		}
+
+		request, wallet, err := lockWithdrawalRequest(ctx, repos, requestID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := request.CheckApprover(principal.Subject, now); err != nil {
+			log.Printf("🚫 Withdrawal request %s cannot be approved by %s: %v", requestID.String(), principal.Subject, err)
+			return err
+		}
+
+		// Limits may have been used up by other withdrawals while the request
+		// waited. A request they no longer allow is rejected, which releases
+		// its amount now rather than at its expiry.
+		amount := request.Amount()
+		if err := limits.CheckWithdrawal(ctx, repos, wallet, amount, now); err != nil {
+			if !errors.Is(err, domain.ErrLimitExceeded) {
+				log.Printf("❌ Failed to check withdrawal limits for withdrawal request %s: %v", requestID.String(), err)
+				return err
+			}
+			log.Printf("💸 Withdrawal request %s breaks the limits of wallet %s: %v", requestID.String(), wallet.ID().String(), err)
+			overLimit = err
+			return uc.rejectOverLimit(ctx, repos, request, wallet, err, now)
+		}
+
+		transaction := entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, amount)
+
+		event, err := request.Approve(principal.Subject, reason, transaction.ID(), now)
+		if err != nil {
+			log.Printf("🚫 Withdrawal request %s cannot be approved by %s: %v", requestID.String(), principal.Subject, err)
+			return err
+		}
+
+		recorder := ledger.NewRecorder(repos.Ledger)
+		account, err := recorder.OpenWalletAccount(ctx, wallet)
+		if err != nil {
+			log.Printf("❌ Failed to open ledger account for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := wallet.CaptureHold(amount, amount); err != nil {
+			log.Printf("❌ Withdrawal request %s rejected for wallet %s: %v", requestID.String(), wallet.ID().String(), err)
+			return err
+		}
+
+		// The held balance shrinks first: it may never exceed the balance.
+		if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+			log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount()); err != nil {
+			log.Printf("❌ Failed to update wallet balance for wallet %s: %v", wallet.ID().String(), err)
+			return err
+		}
+
+		if err := repos.Transactions.InsertTransaction(ctx, completed(transaction)); err != nil {
+			log.Printf("❌ Failed to save transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		if err := recorder.RecordWithdrawal(ctx, account, amount, transaction.ID().String()); err != nil {
+			log.Printf("❌ Failed to post journal entry for transaction %s: %v", transaction.ID().String(), err)
+			return err
+		}
+
+		response, err = uc.decide(ctx, repos, request, wallet, event, entity.AuditActionWithdrawalApproved, map[string]string{
+			"amount":         strconv.FormatInt(amount.Amount(), 10),
+			"currency":       amount.Currency().Code(),
+			"requested_by":   request.RequestedBy(),
+			"transaction_id": transaction.ID().String(),
+			"wallet_id":      wallet.ID().String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for withdrawal request %s: %v", requestID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if overLimit != nil {
+		return nil, overLimit
+	}
+
+	return response, nil
+}
+
+// rejectOverLimit rejects, on the system's behalf, a request whose approval
+// would break the wallet's limits and releases its amount.
+func (uc *withdrawalApprovalUseCase) rejectOverLimit(ctx context.Context, repos repository.Repositories, request *entity.WithdrawalRequest, wallet *entity.Wallet, limitErr error, now time.Time) error {
+	event, err := request.Reject(entity.WithdrawalRequestSystemActor, limitErr.Error(), now)
+	if err != nil {
+		log.Printf("❌ Withdrawal request %s cannot be rejected: %v", request.ID().String(), err)
+		return err
+	}
+
+	if err := releaseRequestedFunds(ctx, repos, request, wallet); err != nil {
+		return err
+	}
+
+	_, err = uc.decide(ctx, repos, request, wallet, event, entity.AuditActionWithdrawalRejected, map[string]string{
+		"amount":       strconv.FormatInt(request.Amount().Amount(), 10),
+		"currency":     request.Amount().Currency().Code(),
+		"requested_by": request.RequestedBy(),
+		"wallet_id":    wallet.ID().String(),
+	})
+	return err
+}
+
+func (uc *withdrawalApprovalUseCase) Reject(ctx context.Context, requestID valueobject.UserID, reason string) (*dto.WithdrawalRequestResponse, error) {
+	principal, err := auth.Identify(ctx)
+	if err == nil {
+		err = auth.RequireRole(ctx, auth.ScopeOperator)
+	}
+	if err != nil {
+		log.Printf("🚫 Rejection of withdrawal request %s refused: %v", requestID.String(), err)
+		return nil, err
+	}
+
+	requestHash := idempotency.HashRequest("reject-withdrawal", requestID.String(), reason)
+
+	var response *dto.WithdrawalRequestResponse
+
+	err = uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+		var replayed dto.WithdrawalRequestResponse
+		ok, err := uc.idempotency.Replay(ctx, repos.Idempotency, requestHash, &replayed)
+		if err != nil {
+			log.Printf("❌ Idempotency check failed for withdrawal request %s: %v", requestID.String(), err)
+			return err
+		}
+		if ok {
+			log.Printf("🔁 Replaying rejection of withdrawal request %s", requestID.String())
+			response = &replayed
+			return nil
+		}
+
+		request, wallet, err := lockWithdrawalRequest(ctx, repos, requestID)
+		if err != nil {
+			return err
+		}
+
+		event, err := request.Reject(principal.Subject, reason, time.Now())
+		if err != nil {
+			log.Printf("❌ Withdrawal request %s cannot be rejected: %v", requestID.String(), err)
+			return err
+		}
+
+		if err := releaseRequestedFunds(ctx, repos, request, wallet); err != nil {
+			return err
+		}
+
+		response, err = uc.decide(ctx, repos, request, wallet, event, entity.AuditActionWithdrawalRejected, map[string]string{
+			"amount":       strconv.FormatInt(request.Amount().Amount(), 10),
+			"currency":     request.Amount().Currency().Code(),
+			"requested_by": request.RequestedBy(),
+			"wallet_id":    wallet.ID().String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := uc.idempotency.Save(ctx, repos.Idempotency, requestHash, response); err != nil {
+			log.Printf("❌ Failed to store idempotency key for withdrawal request %s: %v", requestID.String(), err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// decide stores a decided request with the step that decided it, audits the
+// decision and describes the request with its full history.
+func (uc *withdrawalApprovalUseCase) decide(ctx context.Context, repos repository.Repositories, request *entity.WithdrawalRequest, wallet *entity.Wallet, event *entity.WithdrawalRequestEvent, action entity.AuditAction, details map[string]string) (*dto.WithdrawalRequestResponse, error) {
+	if err := repos.WithdrawalRequests.UpdateWithdrawalRequest(ctx, request); err != nil {
+		log.Printf("❌ Failed to update withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
+	}
+
+	if err := repos.WithdrawalRequests.InsertWithdrawalRequestEvent(ctx, event); err != nil {
+		log.Printf("❌ Failed to record history of withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
+	}
+
+	if err := audit.Record(ctx, repos.Audit, action, entity.AuditTargetWithdrawalRequest, request.ID().String(), event.Reason(), details); err != nil {
+		log.Printf("❌ Failed to audit withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
+	}
+
+	events, err := repos.WithdrawalRequests.ListWithdrawalRequestEvents(ctx, request.ID())
+	if err != nil {
+		log.Printf("❌ Failed to read history of withdrawal request %s: %v", request.ID().String(), err)
+		return nil, err
+	}
+
+	response := approvals.Response(request, wallet, events)
+	return &response, nil
+}
+
+// ExpireRequests expires each request in its own unit of work, so that one
+// failure neither undoes nor blocks the others for long.
+func (uc *withdrawalApprovalUseCase) ExpireRequests(ctx context.Context, now time.Time) (int, error) {
+	expiredCount := 0
+
+	for {
+		var expired []*entity.WithdrawalRequest
+		err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+			var err error
+			expired, err = repos.WithdrawalRequests.ListExpiredWithdrawalRequests(ctx, now, expiredRequestBatchSize)
+			return err
+		})
+		if err != nil {
+			return expiredCount, err
+		}
+
+		for _, candidate := range expired {
+			expiredNow := false
+			err := uc.unitOfWork.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+				request, wallet, err := lockWithdrawalRequest(ctx, repos, candidate.ID())
+				if err != nil {
+					return err
+				}
+
+				// Decided since it was listed.
+				if request.Status() != entity.WithdrawalRequestStatusPending {
+					return nil
+				}
+
+				event, err := request.Expire(now)
+				if err != nil {
+					return err
+				}
+
+				if err := releaseRequestedFunds(ctx, repos, request, wallet); err != nil {
+					return err
+				}
+
+				if err := repos.WithdrawalRequests.UpdateWithdrawalRequest(ctx, request); err != nil {
+					log.Printf("❌ Failed to update withdrawal request %s: %v", request.ID().String(), err)
+					return err
+				}
+
+				if err := repos.WithdrawalRequests.InsertWithdrawalRequestEvent(ctx, event); err != nil {
+					log.Printf("❌ Failed to record history of withdrawal request %s: %v", request.ID().String(), err)
+					return err
+				}
+
+				expiredNow = true
+				return nil
+			})
+			if err != nil {
+				return expiredCount, err
+			}
+			if expiredNow {
+				expiredCount++
+			}
+		}
+
+		if len(expired) < expiredRequestBatchSize {
+			return expiredCount, nil
+		}
+	}
+}
+
+// releaseRequestedFunds makes the amount held for a rejected or expired
+// request available again.
+func releaseRequestedFunds(ctx context.Context, repos repository.Repositories, request *entity.WithdrawalRequest, wallet *entity.Wallet) error {
+	if err := wallet.ReleaseHold(request.Amount()); err != nil {
+		log.Printf("❌ Cannot release withdrawal request %s on wallet %s: %v", request.ID().String(), wallet.ID().String(), err)
+		return err
+	}
+
+	if err := repos.Wallets.UpdateWalletHeldBalance(ctx, wallet.ID(), wallet.HeldBalance().Amount()); err != nil {
+		log.Printf("❌ Failed to update held balance for wallet %s: %v", wallet.ID().String(), err)
+		return err
+	}
+
+	return nil
+}
+
+// lockWithdrawalRequest locks a withdrawal request and then its wallet, in the
+// same order as holds.
+func lockWithdrawalRequest(ctx context.Context, repos repository.Repositories, requestID valueobject.UserID) (*entity.WithdrawalRequest, *entity.Wallet, error) {
+	request, err := repos.WithdrawalRequests.GetWithdrawalRequestForUpdate(ctx, requestID)
+	if err != nil {
+		log.Printf("❌ Withdrawal request %s not found: %v", requestID.String(), err)
+		return nil, nil, err
+	}
+
+	wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(request.WalletID()))
+	if err != nil {
+		log.Printf("❌ Wallet %s of withdrawal request %s not found: %v", request.WalletID().String(), requestID.String(), err)
+		return nil, nil, err
+	}
+
+	return request, wallet, nil
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/application/approvals"
+	"bank/internal/application/auth"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/memory"
+)
+
+// approvalPolicy asks for approval of USD withdrawals above 500.00.
+var approvalPolicy = approvals.Policy{Thresholds: map[string]int64{"USD": 50000}}
+
+// operator acts as the operator subject.
+func operator(subject string) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: subject, Scopes: []string{auth.ScopeOperator}})
+}
+
+func (f *fixture) heldBalance(t *testing.T, wallet *entity.Wallet) int64 {
+	t.Helper()
+	stored, err := memory.NewWalletRepository(f.store).GetWallet(context.Background(), valueobject.WalletByID(wallet.ID()))
+	if err != nil {
+		t.Fatalf("unexpected error reading wallet: %v", err)
+	}
+	return stored.HeldBalance().Amount()
+}
+
+// requestWithdrawal withdraws amount from wallet as requester, which must be
+// above approvalPolicy's threshold, and returns the withdrawal request.
+func (f *fixture) requestWithdrawal(t *testing.T, requester context.Context, wallet *entity.Wallet, amount int64) valueobject.UserID {
+	t.Helper()
+	uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvalPolicy)
+	response, err := uc.Withdraw(requester, valueobject.WalletByID(wallet.ID()), usd(t, amount))
+	if err != nil {
+		t.Fatalf("unexpected error requesting withdrawal: %v", err)
+	}
+	requestID, err := valueobject.NewUserID(response.WithdrawalRequestID)
+	if err != nil {
+		t.Fatalf("expected a withdrawal request, got %+v", response)
+	}
+	return requestID
+}
+
+func (f *fixture) withdrawalRequest(t *testing.T, requestID valueobject.UserID) *entity.WithdrawalRequest {
+	t.Helper()
+	request, err := memory.NewWithdrawalRequestRepository(f.store).GetWithdrawalRequest(context.Background(), requestID)
+	if err != nil {
+		t.Fatalf("unexpected error reading withdrawal request: %v", err)
+	}
+	return request
+}
+
+func TestWithdrawUseCaseApprovalThreshold(t *testing.T) {
+	t.Run("should hold an amount above the threshold in a pending request", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 100000)
+
+		// Act
+		requestID := f.requestWithdrawal(t, ownerOf(wallet), wallet, 60000)
+
+		// Assert
+		request := f.withdrawalRequest(t, requestID)
+		if request.Status() != entity.WithdrawalRequestStatusPending || request.Amount().Amount() != 60000 {
+			t.Errorf("expected a pending request for 60000, got %s for %d", request.Status(), request.Amount().Amount())
+		}
+		if request.RequestedBy() != wallet.UserID().String() {
+			t.Errorf("expected the owner as requester, got %q", request.RequestedBy())
+		}
+		if f.balance(t, wallet) != 100000 || f.heldBalance(t, wallet) != 60000 {
+			t.Errorf("expected balance 100000 with 60000 held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+		if count := f.completedTransactions(t, wallet); count != 0 {
+			t.Errorf("expected no withdrawal yet, got %d", count)
+		}
+	})
+
+	t.Run("should withdraw an amount at the threshold at once", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 100000)
+		uc := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvalPolicy)
+
+		// Act
+		response, err := uc.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, 50000))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if response.WithdrawalRequestID != "" || f.balance(t, wallet) != 50000 {
+			t.Errorf("expected an immediate withdrawal, got request %q and balance %d", response.WithdrawalRequestID, f.balance(t, wallet))
+		}
+	})
+}
+
+func TestWithdrawalApprovalUseCase(t *testing.T) {
+	tests := []struct {
+		name          string
+		approver      context.Context
+		wantErr       error
+		wantStatus    entity.WithdrawalRequestStatus
+		wantBalance   int64
+		wantHeld      int64
+		wantCompleted int
+	}{
+		{name: "should capture the hold when another operator approves", approver: operator("bob"), wantStatus: entity.WithdrawalRequestStatusApproved, wantBalance: 40000, wantCompleted: 1},
+		{name: "should refuse the requester's own approval", approver: operator("alice"), wantErr: domain.ErrSelfApproval, wantStatus: entity.WithdrawalRequestStatusPending, wantBalance: 100000, wantHeld: 60000},
+		{name: "should refuse the wallet's owner", wantErr: domain.ErrForbidden, wantStatus: entity.WithdrawalRequestStatusPending, wantBalance: 100000, wantHeld: 60000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(false)
+			wallet := f.addWallet(t, 100000)
+			requestID := f.requestWithdrawal(t, operator("alice"), wallet, 60000)
+			uc := NewWithdrawalApprovalUseCase(f.unitOfWork, f.guard)
+			approver := tt.approver
+			if approver == nil {
+				approver = ownerOf(wallet)
+			}
+
+			// Act
+			response, err := uc.Approve(approver, requestID, "checked with the customer")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if status := f.withdrawalRequest(t, requestID).Status(); status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, status)
+			}
+			if f.balance(t, wallet) != tt.wantBalance || f.heldBalance(t, wallet) != tt.wantHeld {
+				t.Errorf("expected balance %d with %d held, got %d with %d held", tt.wantBalance, tt.wantHeld, f.balance(t, wallet), f.heldBalance(t, wallet))
+			}
+			if count := f.completedTransactions(t, wallet); count != tt.wantCompleted {
+				t.Errorf("expected %d completed withdrawals, got %d", tt.wantCompleted, count)
+			}
+			if tt.wantErr == nil && (response.TransactionID == "" || response.DecidedBy != "bob" || len(response.History) != 2) {
+				t.Errorf("expected the withdrawal, decider and history in the response, got %+v", response)
+			}
+		})
+	}
+
+	t.Run("should release the hold when the request is rejected", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 100000)
+		requestID := f.requestWithdrawal(t, operator("alice"), wallet, 60000)
+		uc := NewWithdrawalApprovalUseCase(f.unitOfWork, f.guard)
+
+		// Act
+		response, err := uc.Reject(operator("bob"), requestID, "customer did not confirm")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if response.Status != string(entity.WithdrawalRequestStatusRejected) {
+			t.Errorf("expected status REJECTED, got %s", response.Status)
+		}
+		if f.balance(t, wallet) != 100000 || f.heldBalance(t, wallet) != 0 {
+			t.Errorf("expected balance 100000 with nothing held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+		if count := f.completedTransactions(t, wallet); count != 0 {
+			t.Errorf("expected no withdrawal, got %d", count)
+		}
+	})
+
+	t.Run("should release the hold of a request nobody decided in time", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 100000)
+		expiring := f.requestWithdrawal(t, operator("alice"), wallet, 60000)
+		uc := NewWithdrawalApprovalUseCase(f.unitOfWork, f.guard)
+
+		// Act
+		notYet, notYetErr := uc.ExpireRequests(context.Background(), time.Now())
+		expired, err := uc.ExpireRequests(context.Background(), time.Now().Add(approvals.DefaultTTL+time.Minute))
+
+		// Assert
+		if notYetErr != nil || err != nil {
+			t.Fatalf("expected no error, got %v and %v", notYetErr, err)
+		}
+		if notYet != 0 || expired != 1 {
+			t.Errorf("expected 0 then 1 expired requests, got %d and %d", notYet, expired)
+		}
+		if status := f.withdrawalRequest(t, expiring).Status(); status != entity.WithdrawalRequestStatusExpired {
+			t.Errorf("expected status EXPIRED, got %s", status)
+		}
+		if f.balance(t, wallet) != 100000 || f.heldBalance(t, wallet) != 0 {
+			t.Errorf("expected balance 100000 with nothing held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+	})
+
+	t.Run("should reject the request and release the hold when the limits no longer allow it", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 200000)
+		daily := int64(100000)
+		if err := memory.NewLimitRepository(f.store).SetWalletLimits(context.Background(), wallet.ID(), entity.WithdrawalLimits{Daily: &daily}); err != nil {
+			t.Fatalf("unexpected error setting limits: %v", err)
+		}
+		requestID := f.requestWithdrawal(t, operator("alice"), wallet, 60000)
+		withdraw := NewWithdrawUseCase(f.unitOfWork, memory.NewTransactionRepository(f.store), f.guard, approvalPolicy)
+		if _, err := withdraw.Withdraw(ownerOf(wallet), valueobject.WalletByID(wallet.ID()), usd(t, 50000)); err != nil {
+			t.Fatalf("unexpected error withdrawing: %v", err)
+		}
+		uc := NewWithdrawalApprovalUseCase(f.unitOfWork, f.guard)
+
+		// Act
+		_, err := uc.Approve(operator("bob"), requestID, "")
+
+		// Assert
+		var limitErr *domain.LimitExceededError
+		if !errors.As(err, &limitErr) || limitErr.Limit != entity.LimitDaily || limitErr.Remaining != 50000 {
+			t.Fatalf("expected the daily limit to be exceeded with 50000 remaining, got %v", err)
+		}
+		if request := f.withdrawalRequest(t, requestID); request.Status() != entity.WithdrawalRequestStatusRejected || request.DecidedBy() != entity.WithdrawalRequestSystemActor {
+			t.Errorf("expected the system to reject the request, got %s by %q", request.Status(), request.DecidedBy())
+		}
+		events, _ := memory.NewWithdrawalRequestRepository(f.store).ListWithdrawalRequestEvents(context.Background(), requestID)
+		if len(events) != 2 || events[1].Action() != entity.WithdrawalRequestActionRejected || events[1].Reason() != limitErr.Error() {
+			t.Errorf("expected the rejection and its limit in the history, got %d events", len(events))
+		}
+		if f.balance(t, wallet) != 150000 || f.heldBalance(t, wallet) != 0 {
+			t.Errorf("expected balance 150000 with nothing held, got %d with %d held", f.balance(t, wallet), f.heldBalance(t, wallet))
+		}
+	})
+
+	t.Run("should refuse to approve an expired request", func(t *testing.T) {
+		// Arrange
+		f := newFixture(false)
+		wallet := f.addWallet(t, 100000)
+		requestID := f.requestWithdrawal(t, operator("alice"), wallet, 60000)
+		uc := NewWithdrawalApprovalUseCase(f.unitOfWork, f.guard)
+		if _, err := uc.ExpireRequests(context.Background(), time.Now().Add(approvals.DefaultTTL+time.Minute)); err != nil {
+			t.Fatalf("unexpected error expiring requests: %v", err)
+		}
+
+		// Act
+		_, err := uc.Approve(operator("bob"), requestID, "")
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+		if f.balance(t, wallet) != 100000 {
+			t.Errorf("expected balance 100000, got %d", f.balance(t, wallet))
+		}
+	})
+}
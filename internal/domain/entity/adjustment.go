@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// AdjustmentDirection says whether an adjustment puts money into a wallet or
+// takes it out.
+type AdjustmentDirection string
+
+const (
+	AdjustmentDirectionCredit AdjustmentDirection = "CREDIT"
+	AdjustmentDirectionDebit  AdjustmentDirection = "DEBIT"
+)
+
+// ParseAdjustmentDirection converts a raw value, e.g. a request field, into a
+// known adjustment direction.
+func ParseAdjustmentDirection(value string) (AdjustmentDirection, error) {
+	switch direction := AdjustmentDirection(value); direction {
+	case AdjustmentDirectionCredit, AdjustmentDirectionDebit:
+		return direction, nil
+	default:
+		return "", domain.NewValidationError("direction", fmt.Sprintf("unknown adjustment direction %q", value))
+	}
+}
+
+// AdjustmentReason is the reason code every manual adjustment must carry, so
+// that adjustments can be reported on by cause.
+type AdjustmentReason string
+
+const (
+	// AdjustmentReasonCorrection fixes a balance left wrong by an error.
+	AdjustmentReasonCorrection AdjustmentReason = "CORRECTION"
+	// AdjustmentReasonGoodwill is a gesture towards the customer.
+	AdjustmentReasonGoodwill AdjustmentReason = "GOODWILL"
+	// AdjustmentReasonFeeRefund returns a fee charged outside the service.
+	AdjustmentReasonFeeRefund AdjustmentReason = "FEE_REFUND"
+	// AdjustmentReasonChargeback books a chargeback raised with the card or
+	// payment network.
+	AdjustmentReasonChargeback AdjustmentReason = "CHARGEBACK"
+	// AdjustmentReasonFraudRecovery recovers money obtained by fraud.
+	AdjustmentReasonFraudRecovery AdjustmentReason = "FRAUD_RECOVERY"
+	// AdjustmentReasonOther needs a note saying what the adjustment is for.
+	AdjustmentReasonOther AdjustmentReason = "OTHER"
+)
+
+// MaxAdjustmentNoteLength bounds the note recorded with an adjustment.
+const MaxAdjustmentNoteLength = 200
+
+// ParseAdjustmentReason converts a raw value into a known reason code.
+func ParseAdjustmentReason(value string) (AdjustmentReason, error) {
+	switch reason := AdjustmentReason(value); reason {
+	case AdjustmentReasonCorrection, AdjustmentReasonGoodwill, AdjustmentReasonFeeRefund,
+		AdjustmentReasonChargeback, AdjustmentReasonFraudRecovery, AdjustmentReasonOther:
+		return reason, nil
+	default:
+		return "", domain.NewValidationError("reason_code", fmt.Sprintf("unknown adjustment reason code %q", value))
+	}
+}
+
+// NewAdjustmentTransaction creates the transaction recording a manual credit
+// or debit of a wallet. Its reason is the reason code followed by the note,
+// if any; the note is required for AdjustmentReasonOther. Applying the amount
+// to the wallet is for the caller.
+func NewAdjustmentTransaction(walletID valueobject.UserID, direction AdjustmentDirection, amount valueobject.Money, reason AdjustmentReason, note string) (*Transaction, error) {
+	txType := TransactionTypeAdjustmentCredit
+	if direction == AdjustmentDirectionDebit {
+		txType = TransactionTypeAdjustmentDebit
+	}
+
+	if amount.IsZero() {
+		return nil, domain.NewValidationError("amount", "adjustment amount must be greater than zero")
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" && reason == AdjustmentReasonOther {
+		return nil, domain.NewValidationError("note", "a note is required for adjustments with reason code OTHER")
+	}
+	if len(note) > MaxAdjustmentNoteLength {
+		return nil, domain.NewValidationError("note", fmt.Sprintf("adjustment note must be at most %d characters", MaxAdjustmentNoteLength))
+	}
+
+	transaction := NewTransaction(walletID, txType, amount)
+	transaction.reason = string(reason)
+	if note != "" {
+		transaction.reason += ": " + note
+	}
+	return transaction, nil
+}
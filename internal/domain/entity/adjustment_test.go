@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewAdjustmentTransaction(t *testing.T) {
+	amount, _ := valueobject.NewMoney(2500, valueobject.DefaultCurrency())
+
+	successTests := []struct {
+		name           string
+		direction      AdjustmentDirection
+		reason         AdjustmentReason
+		note           string
+		expectedType   TransactionType
+		expectedReason string
+	}{
+		{
+			name:           "should create a credit recording the reason code",
+			direction:      AdjustmentDirectionCredit,
+			reason:         AdjustmentReasonGoodwill,
+			expectedType:   TransactionTypeAdjustmentCredit,
+			expectedReason: "GOODWILL",
+		},
+		{
+			name:           "should create a debit recording the reason code and note",
+			direction:      AdjustmentDirectionDebit,
+			reason:         AdjustmentReasonOther,
+			note:           " duplicate payout ",
+			expectedType:   TransactionTypeAdjustmentDebit,
+			expectedReason: "OTHER: duplicate payout",
+		},
+	}
+
+	for _, tt := range successTests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			walletID := valueobject.NewUserIDRandom()
+
+			// Act
+			transaction, err := NewAdjustmentTransaction(walletID, tt.direction, amount, tt.reason, tt.note)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if transaction.Type() != tt.expectedType || !transaction.WalletID().Equals(walletID) {
+				t.Errorf("expected a %s of wallet %v, got a %s of %v", tt.expectedType, walletID, transaction.Type(), transaction.WalletID())
+			}
+			if transaction.Reason() != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, transaction.Reason())
+			}
+			if transaction.Status() != TransactionStatusPending {
+				t.Errorf("expected pending status, got %s", transaction.Status())
+			}
+		})
+	}
+
+	zero, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+
+	errorTests := []struct {
+		name   string
+		amount valueobject.Money
+		reason AdjustmentReason
+		note   string
+		field  string
+	}{
+		{name: "should reject a zero amount", amount: zero, reason: AdjustmentReasonCorrection, field: "amount"},
+		{name: "should require a note for reason code OTHER", amount: amount, reason: AdjustmentReasonOther, note: " ", field: "note"},
+		{name: "should reject a note that is too long", amount: amount, reason: AdjustmentReasonCorrection, note: strings.Repeat("n", MaxAdjustmentNoteLength+1), field: "note"},
+	}
+
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewAdjustmentTransaction(valueobject.NewUserIDRandom(), AdjustmentDirectionCredit, tt.amount, tt.reason, tt.note)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("expected a validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestParseAdjustmentReason(t *testing.T) {
+	t.Run("should reject an unknown reason code", func(t *testing.T) {
+		// Act
+		_, err := ParseAdjustmentReason("BECAUSE")
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "reason_code" {
+			t.Errorf("expected a validation error on reason_code, got %v", err)
+		}
+	})
+}
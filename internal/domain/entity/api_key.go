@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// Scopes an API key may be granted.
+const (
+	// APIKeyScopeBalanceRead allows reading the balance of the owner's
+	// wallets.
+	APIKeyScopeBalanceRead = "balance:read"
+	// APIKeyScopeWithdraw allows withdrawing from the owner's wallets.
+	APIKeyScopeWithdraw = "withdraw"
+	// APIKeyScopeDeposit allows crediting any wallet, as a payment processor
+	// does when funds arrive.
+	APIKeyScopeDeposit = "deposit"
+	// APIKeyScopeAdmin allows every call, on any wallet.
+	APIKeyScopeAdmin = "admin"
+)
+
+// MaxAPIKeyNameLength bounds the name of an API key, in characters.
+const MaxAPIKeyNameLength = 50
+
+// APIKeySigningKeySize is the size of a signing key, that of a SHA-256
+// digest.
+const APIKeySigningKeySize = 32
+
+// APIKey is a credential for server-to-server clients. The client signs each
+// request with the key's secret; the service only keeps the signing key
+// derived from it, never the secret itself. The signing key is still enough
+// to sign requests, so it is as sensitive as the secret and only stored
+// encrypted. A key acts for the user owning it
+// and only for the calls its scopes allow. A revoked key stays on record but
+// is refused.
+type APIKey struct {
+	id         valueobject.UserID
+	userID     valueobject.UserID
+	name       string
+	signingKey []byte
+	scopes     []string
+	createdAt  time.Time
+	revokedAt  *time.Time
+}
+
+// NewAPIKey creates an active key for userID. The name is trimmed and must be
+// 1 to 50 printable characters; scopes must be non-empty and known, and are
+// stored without duplicates.
+func NewAPIKey(userID valueobject.UserID, name string, scopes []string, signingKey []byte, now time.Time) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxAPIKeyNameLength {
+		return nil, domain.NewValidationError("name", "API key name must be 1 to 50 characters")
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		return nil, domain.NewValidationError("name", "API key name must not contain control characters")
+	}
+
+	if len(scopes) == 0 {
+		return nil, domain.NewValidationError("scopes", "API key needs at least one scope")
+	}
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !IsAPIKeyScope(scope) {
+			return nil, domain.NewValidationError("scopes", "unknown scope "+scope+"; expected balance:read, withdraw, deposit or admin")
+		}
+		if !containsString(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+
+	if len(signingKey) != APIKeySigningKeySize {
+		return nil, domain.NewValidationError("signing_key", "signing key must be 32 bytes")
+	}
+
+	return &APIKey{
+		id:         valueobject.NewUserIDRandom(),
+		userID:     userID,
+		name:       name,
+		signingKey: append([]byte(nil), signingKey...),
+		scopes:     unique,
+		createdAt:  now.UTC(),
+	}, nil
+}
+
+func ReconstructAPIKey(id, userID valueobject.UserID, name string, signingKey []byte, scopes []string, createdAt time.Time, revokedAt *time.Time) *APIKey {
+	return &APIKey{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		signingKey: signingKey,
+		scopes:     scopes,
+		createdAt:  createdAt,
+		revokedAt:  revokedAt,
+	}
+}
+
+// DeriveAPIKeySigningKey computes the signing key of an API key secret: its
+// SHA-256 digest. Clients sign with the same derived key.
+func DeriveAPIKeySigningKey(secret string) []byte {
+	digest := sha256.Sum256([]byte(secret))
+	return digest[:]
+}
+
+// IsAPIKeyScope reports whether scope can be granted to an API key.
+func IsAPIKeyScope(scope string) bool {
+	switch scope {
+	case APIKeyScopeBalanceRead, APIKeyScopeWithdraw, APIKeyScopeDeposit, APIKeyScopeAdmin:
+		return true
+	}
+	return false
+}
+
+func (k *APIKey) ID() valueobject.UserID {
+	return k.id
+}
+
+// UserID is the user the key acts for.
+func (k *APIKey) UserID() valueobject.UserID {
+	return k.userID
+}
+
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+// SigningKey is the HMAC key requests are signed with, the SHA-256 digest of
+// the secret handed to the client.
+func (k *APIKey) SigningKey() []byte {
+	return k.signingKey
+}
+
+func (k *APIKey) Scopes() []string {
+	return k.scopes
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	return containsString(k.scopes, scope)
+}
+
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// RevokedAt is nil while the key is active.
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.revokedAt != nil
+}
+
+// Revoke disables the key for good.
+func (k *APIKey) Revoke(now time.Time) error {
+	if k.IsRevoked() {
+		return domain.ErrAPIKeyRevoked
+	}
+
+	revokedAt := now.UTC()
+	k.revokedAt = &revokedAt
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
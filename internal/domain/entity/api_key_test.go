@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	signingKey := bytes.Repeat([]byte{7}, APIKeySigningKeySize)
+
+	t.Run("should create an active key without duplicate scopes", func(t *testing.T) {
+		// Arrange
+		userID := valueobject.NewUserIDRandom()
+
+		// Act
+		key, err := NewAPIKey(userID, " payouts backend ", []string{APIKeyScopeWithdraw, APIKeyScopeBalanceRead, APIKeyScopeWithdraw}, signingKey, time.Now())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if key.Name() != "payouts backend" || !key.UserID().Equals(userID) {
+			t.Errorf("expected payouts backend of %v, got %q of %v", userID, key.Name(), key.UserID())
+		}
+		if len(key.Scopes()) != 2 || !key.HasScope(APIKeyScopeWithdraw) || !key.HasScope(APIKeyScopeBalanceRead) || key.HasScope(APIKeyScopeAdmin) {
+			t.Errorf("expected withdraw and balance:read, got %v", key.Scopes())
+		}
+		if key.IsRevoked() {
+			t.Error("expected an active key")
+		}
+	})
+
+	tests := []struct {
+		name       string
+		keyName    string
+		scopes     []string
+		signingKey []byte
+		field      string
+	}{
+		{name: "should reject an empty name", keyName: " ", scopes: []string{APIKeyScopeAdmin}, signingKey: signingKey, field: "name"},
+		{name: "should reject a name that is too long", keyName: strings.Repeat("k", MaxAPIKeyNameLength+1), scopes: []string{APIKeyScopeAdmin}, signingKey: signingKey, field: "name"},
+		{name: "should reject a key without scopes", keyName: "backend", signingKey: signingKey, field: "scopes"},
+		{name: "should reject an unknown scope", keyName: "backend", scopes: []string{"transfer"}, signingKey: signingKey, field: "scopes"},
+		{name: "should reject a short signing key", keyName: "backend", scopes: []string{APIKeyScopeAdmin}, signingKey: []byte("short"), field: "signing_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewAPIKey(valueobject.NewUserIDRandom(), tt.keyName, tt.scopes, tt.signingKey, time.Now())
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("expected a validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRevoke(t *testing.T) {
+	t.Run("should revoke an active key once", func(t *testing.T) {
+		// Arrange
+		key, _ := NewAPIKey(valueobject.NewUserIDRandom(), "backend", []string{APIKeyScopeAdmin}, bytes.Repeat([]byte{1}, APIKeySigningKeySize), time.Now())
+		now := time.Now()
+
+		// Act
+		err := key.Revoke(now)
+		again := key.Revoke(now.Add(time.Minute))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !key.IsRevoked() || !key.RevokedAt().Equal(now) {
+			t.Errorf("expected the key to be revoked at %v, got %v", now, key.RevokedAt())
+		}
+		if !errors.Is(again, domain.ErrAPIKeyRevoked) {
+			t.Errorf("expected ErrAPIKeyRevoked, got %v", again)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// AuditAction names a change made through the admin API.
+type AuditAction string
+
+const (
+	AuditActionBalanceAdjusted     AuditAction = "BALANCE_ADJUSTED"
+	AuditActionWalletStatusChanged AuditAction = "WALLET_STATUS_CHANGED"
+	AuditActionOverdraftLimitSet   AuditAction = "OVERDRAFT_LIMIT_SET"
+	AuditActionWalletLimitsSet     AuditAction = "WALLET_LIMITS_SET"
+	AuditActionTierLimitsSet       AuditAction = "TIER_LIMITS_SET"
+	AuditActionAPIKeyIssued        AuditAction = "API_KEY_ISSUED"
+	AuditActionAPIKeyRevoked       AuditAction = "API_KEY_REVOKED"
+	AuditActionWithdrawalApproved  AuditAction = "WITHDRAWAL_APPROVED"
+	AuditActionWithdrawalRejected  AuditAction = "WITHDRAWAL_REJECTED"
+)
+
+// ParseAuditAction converts a raw value, e.g. a query parameter, into a known
+// audit action.
+func ParseAuditAction(value string) (AuditAction, error) {
+	switch action := AuditAction(value); action {
+	case AuditActionBalanceAdjusted, AuditActionWalletStatusChanged, AuditActionOverdraftLimitSet,
+		AuditActionWalletLimitsSet, AuditActionTierLimitsSet, AuditActionAPIKeyIssued, AuditActionAPIKeyRevoked,
+		AuditActionWithdrawalApproved, AuditActionWithdrawalRejected:
+		return action, nil
+	default:
+		return "", domain.NewValidationError("action", fmt.Sprintf("unknown audit action %q", value))
+	}
+}
+
+// AuditTargetType says what kind of record an audited action changed.
+type AuditTargetType string
+
+const (
+	AuditTargetWallet AuditTargetType = "WALLET"
+	AuditTargetTier   AuditTargetType = "TIER"
+	AuditTargetAPIKey AuditTargetType = "API_KEY"
+
+	AuditTargetWithdrawalRequest AuditTargetType = "WITHDRAWAL_REQUEST"
+)
+
+// MaxAuditReasonLength bounds the reason recorded with an audit entry.
+const MaxAuditReasonLength = 255
+
+// AuditEntry records who changed what through the admin API, and why. Entries
+// are only ever appended. Details hold the values the action set, such as the
+// new status or the adjusted amount.
+type AuditEntry struct {
+	id         valueobject.UserID
+	actor      string
+	action     AuditAction
+	targetType AuditTargetType
+	targetID   string
+	reason     string
+	details    map[string]string
+	createdAt  time.Time
+}
+
+// NewAuditEntry records action by actor, the subject of the caller, on the
+// target. The reason may be empty for actions that do not ask for one.
+func NewAuditEntry(actor string, action AuditAction, targetType AuditTargetType, targetID, reason string, details map[string]string, now time.Time) (*AuditEntry, error) {
+	if actor == "" {
+		return nil, domain.NewValidationError("actor", "an audit entry needs an actor")
+	}
+	if targetID == "" {
+		return nil, domain.NewValidationError("target_id", "an audit entry needs a target")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if len(reason) > MaxAuditReasonLength {
+		return nil, domain.NewValidationError("reason", fmt.Sprintf("audit reason must be at most %d characters", MaxAuditReasonLength))
+	}
+
+	return &AuditEntry{
+		id:         valueobject.NewUserIDRandom(),
+		actor:      actor,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		reason:     reason,
+		details:    maps.Clone(details),
+		createdAt:  now.UTC(),
+	}, nil
+}
+
+func ReconstructAuditEntry(id valueobject.UserID, actor string, action AuditAction, targetType AuditTargetType, targetID, reason string, details map[string]string, createdAt time.Time) *AuditEntry {
+	return &AuditEntry{
+		id:         id,
+		actor:      actor,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		reason:     reason,
+		details:    details,
+		createdAt:  createdAt,
+	}
+}
+
+func (e *AuditEntry) ID() valueobject.UserID {
+	return e.id
+}
+
+// Actor is the subject of the principal who made the change.
+func (e *AuditEntry) Actor() string {
+	return e.actor
+}
+
+func (e *AuditEntry) Action() AuditAction {
+	return e.action
+}
+
+func (e *AuditEntry) TargetType() AuditTargetType {
+	return e.targetType
+}
+
+// TargetID identifies the changed record: a wallet or API key ID, or a tier
+// and currency such as STANDARD/USD.
+func (e *AuditEntry) TargetID() string {
+	return e.targetID
+}
+
+func (e *AuditEntry) Reason() string {
+	return e.reason
+}
+
+func (e *AuditEntry) Details() map[string]string {
+	return e.details
+}
+
+func (e *AuditEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
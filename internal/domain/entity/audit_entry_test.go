@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	t.Run("should record the action with a copy of its details", func(t *testing.T) {
+		// Arrange
+		details := map[string]string{"status": "FROZEN"}
+		now := time.Now()
+
+		// Act
+		entry, err := NewAuditEntry("ops-1", AuditActionWalletStatusChanged, AuditTargetWallet, "wallet-1", " fraud case ", details, now)
+		details["status"] = "CLOSED"
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if entry.Actor() != "ops-1" || entry.Action() != AuditActionWalletStatusChanged || entry.TargetID() != "wallet-1" {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+		if entry.Reason() != "fraud case" {
+			t.Errorf("expected reason %q, got %q", "fraud case", entry.Reason())
+		}
+		if entry.Details()["status"] != "FROZEN" {
+			t.Errorf("expected the details as given, got %v", entry.Details())
+		}
+		if !entry.CreatedAt().Equal(now) {
+			t.Errorf("expected created at %v, got %v", now, entry.CreatedAt())
+		}
+	})
+
+	tests := []struct {
+		name     string
+		actor    string
+		targetID string
+		reason   string
+		field    string
+	}{
+		{name: "should require an actor", targetID: "wallet-1", field: "actor"},
+		{name: "should require a target", actor: "ops-1", field: "target_id"},
+		{name: "should reject a reason that is too long", actor: "ops-1", targetID: "wallet-1", reason: strings.Repeat("r", MaxAuditReasonLength+1), field: "reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewAuditEntry(tt.actor, AuditActionBalanceAdjusted, AuditTargetWallet, tt.targetID, tt.reason, nil, time.Now())
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("expected a validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
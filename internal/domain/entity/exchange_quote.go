@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// ExchangeQuote locks an exchange rate for converting an amount from one of a
+// user's wallets into another of a different currency. The spread is taken
+// from the source amount before conversion, so the customer sees the fee and
+// the amount credited up front. A quote can be redeemed once, until it
+// expires.
+type ExchangeQuote struct {
+	id           valueobject.UserID
+	userID       valueobject.UserID
+	fromWalletID valueobject.UserID
+	toWalletID   valueobject.UserID
+	rate         valueobject.ExchangeRate
+	sourceAmount valueobject.Money
+	fee          valueobject.Money
+	targetAmount valueobject.Money
+	createdAt    time.Time
+	expiresAt    time.Time
+	usedAt       *time.Time
+}
+
+// NewExchangeQuote prices the exchange of amount from one wallet into the
+// other at rate, less a spread given in basis points of the amount. Both
+// amounts are rounded with banker's rounding.
+func NewExchangeQuote(from, to *Wallet, amount valueobject.Money, rate valueobject.ExchangeRate, spreadBps int64, now time.Time, ttl time.Duration) (*ExchangeQuote, error) {
+	if from.ID().Equals(to.ID()) {
+		return nil, domain.ErrSameWallet
+	}
+	if !from.UserID().Equals(to.UserID()) {
+		return nil, domain.ErrWalletOwnerMismatch
+	}
+	if from.Currency().Equals(to.Currency()) {
+		return nil, domain.NewValidationError("to_wallet_id", "wallets hold the same currency, use a transfer instead")
+	}
+	if !amount.Currency().Equals(from.Currency()) || !rate.Base().Equals(from.Currency()) || !rate.Quote().Equals(to.Currency()) {
+		return nil, domain.ErrCurrencyMismatch
+	}
+	if amount.IsZero() {
+		return nil, domain.NewValidationError("amount", "amount must be greater than zero")
+	}
+
+	fee, err := amount.BasisPoints(spreadBps)
+	if err != nil {
+		return nil, err
+	}
+
+	net, err := amount.Subtract(fee)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := rate.Convert(net)
+	if err != nil {
+		return nil, err
+	}
+	if target.IsZero() {
+		return nil, domain.NewValidationError("amount", "amount is too small to exchange")
+	}
+
+	now = now.UTC()
+	return &ExchangeQuote{
+		id:           valueobject.NewUserIDRandom(),
+		userID:       from.UserID(),
+		fromWalletID: from.ID(),
+		toWalletID:   to.ID(),
+		rate:         rate,
+		sourceAmount: amount,
+		fee:          fee,
+		targetAmount: target,
+		createdAt:    now,
+		expiresAt:    now.Add(ttl),
+	}, nil
+}
+
+func ReconstructExchangeQuote(
+	id valueobject.UserID,
+	userID valueobject.UserID,
+	fromWalletID valueobject.UserID,
+	toWalletID valueobject.UserID,
+	rate valueobject.ExchangeRate,
+	sourceAmount valueobject.Money,
+	fee valueobject.Money,
+	targetAmount valueobject.Money,
+	createdAt time.Time,
+	expiresAt time.Time,
+	usedAt *time.Time,
+) *ExchangeQuote {
+	return &ExchangeQuote{
+		id:           id,
+		userID:       userID,
+		fromWalletID: fromWalletID,
+		toWalletID:   toWalletID,
+		rate:         rate,
+		sourceAmount: sourceAmount,
+		fee:          fee,
+		targetAmount: targetAmount,
+		createdAt:    createdAt,
+		expiresAt:    expiresAt,
+		usedAt:       usedAt,
+	}
+}
+
+// Redeem marks the quote as used. It fails once the quote has expired or has
+// already been redeemed.
+func (q *ExchangeQuote) Redeem(now time.Time) error {
+	if q.usedAt != nil {
+		return domain.ErrQuoteAlreadyUsed
+	}
+	if q.IsExpired(now) {
+		return domain.ErrQuoteExpired
+	}
+
+	usedAt := now.UTC()
+	q.usedAt = &usedAt
+	return nil
+}
+
+func (q *ExchangeQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.expiresAt)
+}
+
+func (q *ExchangeQuote) ID() valueobject.UserID {
+	return q.id
+}
+
+func (q *ExchangeQuote) UserID() valueobject.UserID {
+	return q.userID
+}
+
+func (q *ExchangeQuote) FromWalletID() valueobject.UserID {
+	return q.fromWalletID
+}
+
+func (q *ExchangeQuote) ToWalletID() valueobject.UserID {
+	return q.toWalletID
+}
+
+func (q *ExchangeQuote) Rate() valueobject.ExchangeRate {
+	return q.rate
+}
+
+// SourceAmount is debited from the source wallet, fee included.
+func (q *ExchangeQuote) SourceAmount() valueobject.Money {
+	return q.sourceAmount
+}
+
+// Fee is the spread kept by the service, in the source currency.
+func (q *ExchangeQuote) Fee() valueobject.Money {
+	return q.fee
+}
+
+// TargetAmount is credited to the target wallet.
+func (q *ExchangeQuote) TargetAmount() valueobject.Money {
+	return q.targetAmount
+}
+
+func (q *ExchangeQuote) CreatedAt() time.Time {
+	return q.createdAt
+}
+
+func (q *ExchangeQuote) ExpiresAt() time.Time {
+	return q.expiresAt
+}
+
+// UsedAt returns when the quote was redeemed, or nil while it is open.
+func (q *ExchangeQuote) UsedAt() *time.Time {
+	return q.usedAt
+}
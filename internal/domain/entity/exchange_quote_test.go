@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewExchangeQuote(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	eur, _ := valueobject.NewCurrency("EUR")
+	rate, _ := valueobject.NewExchangeRate(usd, eur, "0.92")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newWallets := func() (*Wallet, *Wallet) {
+		balance, _ := valueobject.NewMoney(100000, usd)
+		from := NewWalletWithBalance(valueobject.NewUserIDRandom(), balance)
+		to, _ := NewWallet(from.UserID(), "euros", eur)
+		return from, to
+	}
+
+	t.Run("should take the spread before converting", func(t *testing.T) {
+		// Arrange
+		from, to := newWallets()
+		amount, _ := valueobject.NewMoney(10000, usd)
+
+		// Act
+		quote, err := NewExchangeQuote(from, to, amount, rate, 50, now, 30*time.Second)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if quote.Fee().Amount() != 50 {
+			t.Errorf("expected fee 50, got %d", quote.Fee().Amount())
+		}
+		if quote.TargetAmount().Amount() != 9154 || !quote.TargetAmount().Currency().Equals(eur) {
+			t.Errorf("expected 91.54 EUR, got %s", quote.TargetAmount())
+		}
+		if !quote.UserID().Equals(from.UserID()) || !quote.FromWalletID().Equals(from.ID()) || !quote.ToWalletID().Equals(to.ID()) {
+			t.Error("expected the quote to reference the user and both wallets")
+		}
+		if !quote.ExpiresAt().Equal(now.Add(30 * time.Second)) {
+			t.Errorf("expected expiry %v, got %v", now.Add(30*time.Second), quote.ExpiresAt())
+		}
+		if quote.UsedAt() != nil {
+			t.Error("expected a new quote to be unused")
+		}
+	})
+
+	t.Run("should reject wallets of different users", func(t *testing.T) {
+		// Arrange
+		from, _ := newWallets()
+		to, _ := NewWallet(valueobject.NewUserIDRandom(), "euros", eur)
+		amount, _ := valueobject.NewMoney(10000, usd)
+
+		// Act
+		_, err := NewExchangeQuote(from, to, amount, rate, 50, now, time.Minute)
+
+		// Assert
+		if !errors.Is(err, domain.ErrWalletOwnerMismatch) {
+			t.Errorf("expected ErrWalletOwnerMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject wallets of the same currency", func(t *testing.T) {
+		// Arrange
+		from, _ := newWallets()
+		to, _ := NewWallet(from.UserID(), "spare", usd)
+		amount, _ := valueobject.NewMoney(10000, usd)
+
+		// Act
+		_, err := NewExchangeQuote(from, to, amount, rate, 50, now, time.Minute)
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "to_wallet_id" {
+			t.Errorf("expected validation error on to_wallet_id, got %v", err)
+		}
+	})
+
+	t.Run("should reject an amount not in the source currency", func(t *testing.T) {
+		// Arrange
+		from, to := newWallets()
+		amount, _ := valueobject.NewMoney(10000, eur)
+
+		// Act
+		_, err := NewExchangeQuote(from, to, amount, rate, 50, now, time.Minute)
+
+		// Assert
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject an amount that converts to nothing", func(t *testing.T) {
+		// Arrange
+		from, to := newWallets()
+		amount, _ := valueobject.NewMoney(1, usd)
+		halfRate, _ := valueobject.NewExchangeRate(usd, eur, "0.4")
+
+		// Act
+		_, err := NewExchangeQuote(from, to, amount, halfRate, 0, now, time.Minute)
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "amount" {
+			t.Errorf("expected validation error on amount, got %v", err)
+		}
+	})
+}
+
+func TestExchangeQuoteRedeem(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	eur, _ := valueobject.NewCurrency("EUR")
+	rate, _ := valueobject.NewExchangeRate(usd, eur, "0.92")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newQuote := func() *ExchangeQuote {
+		balance, _ := valueobject.NewMoney(100000, usd)
+		from := NewWalletWithBalance(valueobject.NewUserIDRandom(), balance)
+		to, _ := NewWallet(from.UserID(), "euros", eur)
+		amount, _ := valueobject.NewMoney(10000, usd)
+		quote, _ := NewExchangeQuote(from, to, amount, rate, 50, now, 30*time.Second)
+		return quote
+	}
+
+	t.Run("should redeem an open quote once", func(t *testing.T) {
+		// Arrange
+		quote := newQuote()
+
+		// Act
+		first := quote.Redeem(now.Add(10 * time.Second))
+		second := quote.Redeem(now.Add(20 * time.Second))
+
+		// Assert
+		if first != nil {
+			t.Fatalf("expected no error, got %v", first)
+		}
+		if quote.UsedAt() == nil || !quote.UsedAt().Equal(now.Add(10*time.Second)) {
+			t.Errorf("expected used at %v, got %v", now.Add(10*time.Second), quote.UsedAt())
+		}
+		if !errors.Is(second, domain.ErrQuoteAlreadyUsed) {
+			t.Errorf("expected ErrQuoteAlreadyUsed, got %v", second)
+		}
+	})
+
+	t.Run("should refuse an expired quote", func(t *testing.T) {
+		// Arrange
+		quote := newQuote()
+
+		// Act
+		err := quote.Redeem(now.Add(30 * time.Second))
+
+		// Assert
+		if !errors.Is(err, domain.ErrQuoteExpired) {
+			t.Errorf("expected ErrQuoteExpired, got %v", err)
+		}
+		if quote.UsedAt() != nil {
+			t.Error("expected an expired quote to stay unused")
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "ACTIVE"
+	HoldStatusCaptured HoldStatus = "CAPTURED"
+	HoldStatusReleased HoldStatus = "RELEASED"
+	HoldStatusExpired  HoldStatus = "EXPIRED"
+)
+
+// Hold reserves part of a wallet's available balance, as a card
+// authorisation does, until it is captured, released or expires. Capturing
+// settles all or part of the held amount as a withdrawal; anything not
+// captured is returned to the wallet.
+type Hold struct {
+	id             valueobject.UserID
+	walletID       valueobject.UserID
+	amount         valueobject.Money
+	capturedAmount valueobject.Money
+	status         HoldStatus
+	transactionID  *valueobject.UserID
+	expiresAt      time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewHold creates an active hold of amount that expires after ttl.
+func NewHold(walletID valueobject.UserID, amount valueobject.Money, now time.Time, ttl time.Duration) (*Hold, error) {
+	if amount.IsZero() {
+		return nil, domain.NewValidationError("amount", "hold amount must be greater than zero")
+	}
+	if ttl <= 0 {
+		return nil, domain.NewValidationError("expires_in_seconds", "hold must expire in the future")
+	}
+
+	captured, _ := valueobject.NewMoney(0, amount.Currency())
+	now = now.UTC()
+	return &Hold{
+		id:             valueobject.NewUserIDRandom(),
+		walletID:       walletID,
+		amount:         amount,
+		capturedAmount: captured,
+		status:         HoldStatusActive,
+		expiresAt:      now.Add(ttl),
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+func ReconstructHold(
+	id valueobject.UserID,
+	walletID valueobject.UserID,
+	amount valueobject.Money,
+	capturedAmount valueobject.Money,
+	status HoldStatus,
+	transactionID *valueobject.UserID,
+	expiresAt time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Hold {
+	return &Hold{
+		id:             id,
+		walletID:       walletID,
+		amount:         amount,
+		capturedAmount: capturedAmount,
+		status:         status,
+		transactionID:  transactionID,
+		expiresAt:      expiresAt,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+// Capture settles amount, at most the held amount, through the withdrawal
+// transaction transactionID. An expired hold can no longer be captured.
+func (h *Hold) Capture(amount valueobject.Money, transactionID valueobject.UserID, now time.Time) error {
+	if h.status != HoldStatusActive {
+		return h.transitionTo(HoldStatusCaptured, now)
+	}
+	if h.IsExpired(now) {
+		return domain.ErrHoldExpired
+	}
+	if !amount.Currency().Equals(h.amount.Currency()) {
+		return domain.ErrCurrencyMismatch
+	}
+	if amount.IsZero() {
+		return domain.NewValidationError("amount", "capture amount must be greater than zero")
+	}
+	if !amount.LessThanOrEqual(h.amount) {
+		return domain.NewValidationError("amount", "capture amount cannot exceed the held amount")
+	}
+
+	if err := h.transitionTo(HoldStatusCaptured, now); err != nil {
+		return err
+	}
+
+	h.capturedAmount = amount
+	h.transactionID = &transactionID
+	return nil
+}
+
+// Release returns the held amount to the wallet.
+func (h *Hold) Release(now time.Time) error {
+	return h.transitionTo(HoldStatusReleased, now)
+}
+
+// Expire releases a hold whose expiry has passed.
+func (h *Hold) Expire(now time.Time) error {
+	if h.status == HoldStatusActive && !h.IsExpired(now) {
+		return fmt.Errorf("%w: hold %s has not expired yet", domain.ErrInvalidStatusTransition, h.id.String())
+	}
+	return h.transitionTo(HoldStatusExpired, now)
+}
+
+// transitionTo enforces the lifecycle ACTIVE -> CAPTURED | RELEASED | EXPIRED.
+// Every other status is final.
+func (h *Hold) transitionTo(status HoldStatus, now time.Time) error {
+	if h.status != HoldStatusActive {
+		return fmt.Errorf("%w from %s to %s", domain.ErrInvalidStatusTransition, h.status, status)
+	}
+
+	h.status = status
+	h.updatedAt = now.UTC()
+	return nil
+}
+
+func (h *Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.expiresAt)
+}
+
+func (h *Hold) ID() valueobject.UserID {
+	return h.id
+}
+
+func (h *Hold) WalletID() valueobject.UserID {
+	return h.walletID
+}
+
+func (h *Hold) Amount() valueobject.Money {
+	return h.amount
+}
+
+// CapturedAmount is zero unless the hold has been captured.
+func (h *Hold) CapturedAmount() valueobject.Money {
+	return h.capturedAmount
+}
+
+func (h *Hold) Status() HoldStatus {
+	return h.status
+}
+
+// TransactionID returns the withdrawal a captured hold was settled by, or nil.
+func (h *Hold) TransactionID() *valueobject.UserID {
+	return h.transactionID
+}
+
+func (h *Hold) ExpiresAt() time.Time {
+	return h.expiresAt
+}
+
+func (h *Hold) CreatedAt() time.Time {
+	return h.createdAt
+}
+
+func (h *Hold) UpdatedAt() time.Time {
+	return h.updatedAt
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewHold(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should create an active hold expiring after the ttl", func(t *testing.T) {
+		// Arrange
+		walletID := valueobject.NewUserIDRandom()
+		amount, _ := valueobject.NewMoney(5000, valueobject.DefaultCurrency())
+
+		// Act
+		hold, err := NewHold(walletID, amount, now, time.Hour)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if hold.Status() != HoldStatusActive {
+			t.Errorf("expected status ACTIVE, got %s", hold.Status())
+		}
+		if !hold.WalletID().Equals(walletID) {
+			t.Error("expected the hold to reference its wallet")
+		}
+		if !hold.ExpiresAt().Equal(now.Add(time.Hour)) {
+			t.Errorf("expected expiry %v, got %v", now.Add(time.Hour), hold.ExpiresAt())
+		}
+		if !hold.CapturedAmount().IsZero() || hold.TransactionID() != nil {
+			t.Error("expected a new hold to have nothing captured")
+		}
+	})
+
+	tests := []struct {
+		name   string
+		amount int64
+		ttl    time.Duration
+		field  string
+	}{
+		{"should reject a zero amount", 0, time.Hour, "amount"},
+		{"should reject a ttl that is not positive", 5000, 0, "expires_in_seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			amount, _ := valueobject.NewMoney(tt.amount, valueobject.DefaultCurrency())
+
+			// Act
+			_, err := NewHold(valueobject.NewUserIDRandom(), amount, now, tt.ttl)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("expected a validation error on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestHoldLifecycle(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	usd := valueobject.DefaultCurrency()
+
+	newHold := func() *Hold {
+		amount, _ := valueobject.NewMoney(5000, usd)
+		hold, _ := NewHold(valueobject.NewUserIDRandom(), amount, now, time.Hour)
+		return hold
+	}
+
+	t.Run("should capture part of the hold", func(t *testing.T) {
+		// Arrange
+		hold := newHold()
+		amount, _ := valueobject.NewMoney(2000, usd)
+		transactionID := valueobject.NewUserIDRandom()
+
+		// Act
+		err := hold.Capture(amount, transactionID, now.Add(time.Minute))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if hold.Status() != HoldStatusCaptured {
+			t.Errorf("expected status CAPTURED, got %s", hold.Status())
+		}
+		if hold.CapturedAmount().Amount() != 2000 {
+			t.Errorf("expected captured amount 2000, got %d", hold.CapturedAmount().Amount())
+		}
+		if hold.TransactionID() == nil || !hold.TransactionID().Equals(transactionID) {
+			t.Error("expected the hold to reference its capture transaction")
+		}
+	})
+
+	t.Run("should not capture more than was held", func(t *testing.T) {
+		// Arrange
+		hold := newHold()
+		amount, _ := valueobject.NewMoney(5001, usd)
+
+		// Act
+		err := hold.Capture(amount, valueobject.NewUserIDRandom(), now)
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+		if hold.Status() != HoldStatusActive {
+			t.Errorf("expected status ACTIVE, got %s", hold.Status())
+		}
+	})
+
+	t.Run("should not capture an expired hold", func(t *testing.T) {
+		// Arrange
+		hold := newHold()
+
+		// Act
+		err := hold.Capture(hold.Amount(), valueobject.NewUserIDRandom(), now.Add(time.Hour))
+
+		// Assert
+		if !errors.Is(err, domain.ErrHoldExpired) {
+			t.Errorf("expected ErrHoldExpired, got %v", err)
+		}
+	})
+
+	t.Run("should not capture a released hold", func(t *testing.T) {
+		// Arrange
+		hold := newHold()
+		_ = hold.Release(now)
+
+		// Act
+		err := hold.Capture(hold.Amount(), valueobject.NewUserIDRandom(), now)
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+		if hold.Status() != HoldStatusReleased {
+			t.Errorf("expected status RELEASED, got %s", hold.Status())
+		}
+	})
+
+	t.Run("should only expire a hold past its expiry", func(t *testing.T) {
+		// Arrange
+		hold := newHold()
+
+		// Act
+		early := hold.Expire(now.Add(time.Minute))
+		late := hold.Expire(now.Add(time.Hour))
+
+		// Assert
+		if !errors.Is(early, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition before expiry, got %v", early)
+		}
+		if late != nil {
+			t.Errorf("expected no error after expiry, got %v", late)
+		}
+		if hold.Status() != HoldStatusExpired {
+			t.Errorf("expected status EXPIRED, got %s", hold.Status())
+		}
+	})
+}
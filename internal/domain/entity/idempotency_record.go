@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+)
+
+// IdempotencyRecord remembers the outcome of a money-moving request so that a
+// client retry carrying the same Idempotency-Key is answered with the original
+// response instead of being executed twice.
+type IdempotencyRecord struct {
+	key          string
+	requestHash  string
+	responseBody []byte
+	createdAt    time.Time
+	expiresAt    time.Time
+}
+
+func NewIdempotencyRecord(key, requestHash string, responseBody []byte, ttl time.Duration) *IdempotencyRecord {
+	now := time.Now().UTC()
+	return &IdempotencyRecord{
+		key:          key,
+		requestHash:  requestHash,
+		responseBody: responseBody,
+		createdAt:    now,
+		expiresAt:    now.Add(ttl),
+	}
+}
+
+func ReconstructIdempotencyRecord(key, requestHash string, responseBody []byte, createdAt, expiresAt time.Time) *IdempotencyRecord {
+	return &IdempotencyRecord{
+		key:          key,
+		requestHash:  requestHash,
+		responseBody: responseBody,
+		createdAt:    createdAt,
+		expiresAt:    expiresAt,
+	}
+}
+
+func (r *IdempotencyRecord) Key() string {
+	return r.key
+}
+
+func (r *IdempotencyRecord) RequestHash() string {
+	return r.requestHash
+}
+
+func (r *IdempotencyRecord) ResponseBody() []byte {
+	return r.responseBody
+}
+
+func (r *IdempotencyRecord) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *IdempotencyRecord) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+// Matches reports whether the record was created for the same request payload.
+func (r *IdempotencyRecord) Matches(requestHash string) bool {
+	return r.requestHash == requestHash
+}
+
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.expiresAt)
+}
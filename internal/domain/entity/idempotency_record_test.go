@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewIdempotencyRecord(t *testing.T) {
+	t.Run("should create record expiring after ttl", func(t *testing.T) {
+		// Arrange
+		ttl := time.Hour
+
+		// Act
+		record := NewIdempotencyRecord("key-1", "hash-1", []byte(`{"success":true}`), ttl)
+
+		// Assert
+		if record.Key() != "key-1" {
+			t.Errorf("expected key 'key-1', got '%s'", record.Key())
+		}
+		if record.ExpiresAt().Sub(record.CreatedAt()) != ttl {
+			t.Errorf("expected expiry %v after creation, got %v", ttl, record.ExpiresAt().Sub(record.CreatedAt()))
+		}
+		if string(record.ResponseBody()) != `{"success":true}` {
+			t.Errorf("unexpected response body %s", record.ResponseBody())
+		}
+	})
+}
+
+func TestIdempotencyRecordMatches(t *testing.T) {
+	t.Run("should match only the original request hash", func(t *testing.T) {
+		// Arrange
+		record := NewIdempotencyRecord("key-1", "hash-1", nil, time.Hour)
+
+		// Act & Assert
+		if !record.Matches("hash-1") {
+			t.Error("expected record to match original hash")
+		}
+		if record.Matches("hash-2") {
+			t.Error("expected record not to match a different hash")
+		}
+	})
+}
+
+func TestIdempotencyRecordIsExpired(t *testing.T) {
+	t.Run("should expire once expiry time is reached", func(t *testing.T) {
+		// Arrange
+		createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		expiresAt := createdAt.Add(time.Hour)
+		record := ReconstructIdempotencyRecord("key-1", "hash-1", nil, createdAt, expiresAt)
+
+		// Act & Assert
+		if record.IsExpired(createdAt.Add(30 * time.Minute)) {
+			t.Error("expected record to be valid before expiry")
+		}
+		if !record.IsExpired(expiresAt) {
+			t.Error("expected record to be expired at expiry time")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"bank/internal/domain/valueobject"
+)
+
+type PostingDirection string
+
+const (
+	PostingDirectionDebit  PostingDirection = "DEBIT"
+	PostingDirectionCredit PostingDirection = "CREDIT"
+)
+
+// Posting moves an amount into (credit) or out of (debit) a ledger account.
+// Wallet accounts are liabilities of the service, so a wallet's balance is
+// its credits minus its debits.
+type Posting struct {
+	accountID valueobject.UserID
+	direction PostingDirection
+	amount    valueobject.Money
+}
+
+func NewDebit(accountID valueobject.UserID, amount valueobject.Money) Posting {
+	return Posting{accountID: accountID, direction: PostingDirectionDebit, amount: amount}
+}
+
+func NewCredit(accountID valueobject.UserID, amount valueobject.Money) Posting {
+	return Posting{accountID: accountID, direction: PostingDirectionCredit, amount: amount}
+}
+
+func (p Posting) AccountID() valueobject.UserID {
+	return p.accountID
+}
+
+func (p Posting) Direction() PostingDirection {
+	return p.direction
+}
+
+func (p Posting) Amount() valueobject.Money {
+	return p.amount
+}
+
+// JournalEntry is an immutable, balanced set of postings describing a single
+// money movement.
+type JournalEntry struct {
+	id          valueobject.UserID
+	reference   string
+	description string
+	postings    []Posting
+	createdAt   time.Time
+}
+
+// NewJournalEntry validates that the postings balance: in each currency the
+// debits must sum to the credits, so the postings of an entry always sum to
+// zero.
+func NewJournalEntry(reference, description string, postings ...Posting) (*JournalEntry, error) {
+	if len(postings) < 2 {
+		return nil, errors.New("journal entry must have at least two postings")
+	}
+
+	type sides struct {
+		debits  valueobject.Money
+		credits valueobject.Money
+	}
+	totals := make(map[string]*sides)
+
+	for _, posting := range postings {
+		if posting.amount.IsZero() {
+			return nil, errors.New("posting amount must be greater than zero")
+		}
+
+		currency := posting.amount.Currency()
+		total, ok := totals[currency.Code()]
+		if !ok {
+			zero, _ := valueobject.NewMoney(0, currency)
+			total = &sides{debits: zero, credits: zero}
+			totals[currency.Code()] = total
+		}
+
+		var err error
+		switch posting.direction {
+		case PostingDirectionDebit:
+			total.debits, err = total.debits.Add(posting.amount)
+		case PostingDirectionCredit:
+			total.credits, err = total.credits.Add(posting.amount)
+		default:
+			return nil, errors.New("invalid posting direction")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, total := range totals {
+		if total.debits.Amount() != total.credits.Amount() {
+			return nil, errors.New("journal entry is unbalanced")
+		}
+	}
+
+	return &JournalEntry{
+		id:          valueobject.NewUserIDRandom(),
+		reference:   reference,
+		description: description,
+		postings:    append([]Posting(nil), postings...),
+		createdAt:   time.Now().UTC(),
+	}, nil
+}
+
+func (e *JournalEntry) ID() valueobject.UserID {
+	return e.id
+}
+
+// Reference links the entry to the business record it accounts for, such as
+// a transaction or transfer ID.
+func (e *JournalEntry) Reference() string {
+	return e.reference
+}
+
+func (e *JournalEntry) Description() string {
+	return e.description
+}
+
+// Postings returns a copy of the entry's postings.
+func (e *JournalEntry) Postings() []Posting {
+	return append([]Posting(nil), e.postings...)
+}
+
+func (e *JournalEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewJournalEntry(t *testing.T) {
+	walletAccount := valueobject.NewUserIDRandom()
+	cashOutAccount := valueobject.NewUserIDRandom()
+	feesAccount := valueobject.NewUserIDRandom()
+
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, valueobject.DefaultCurrency())
+		return m
+	}
+	eur, _ := valueobject.NewCurrency("EUR")
+	euros := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, eur)
+		return m
+	}
+
+	tests := []struct {
+		name          string
+		postings      []Posting
+		expectedError string
+	}{
+		{
+			name: "balanced two-legged entry",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10000)),
+				NewCredit(cashOutAccount, money(10000)),
+			},
+		},
+		{
+			name: "balanced multi-legged entry",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10100)),
+				NewCredit(cashOutAccount, money(10000)),
+				NewCredit(feesAccount, money(100)),
+			},
+		},
+		{
+			name: "unbalanced entry",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10000)),
+				NewCredit(cashOutAccount, money(9000)),
+			},
+			expectedError: "journal entry is unbalanced",
+		},
+		{
+			name: "entry balanced in each currency",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10000)),
+				NewCredit(cashOutAccount, money(10000)),
+				NewDebit(feesAccount, euros(9200)),
+				NewCredit(walletAccount, euros(9200)),
+			},
+		},
+		{
+			name: "entry balanced only across currencies",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10000)),
+				NewCredit(cashOutAccount, euros(10000)),
+			},
+			expectedError: "journal entry is unbalanced",
+		},
+		{
+			name: "single posting",
+			postings: []Posting{
+				NewDebit(walletAccount, money(10000)),
+			},
+			expectedError: "journal entry must have at least two postings",
+		},
+		{
+			name: "zero amount posting",
+			postings: []Posting{
+				NewDebit(walletAccount, money(0)),
+				NewCredit(cashOutAccount, money(0)),
+			},
+			expectedError: "posting amount must be greater than zero",
+		},
+		{
+			name: "overflowing postings",
+			postings: []Posting{
+				NewDebit(walletAccount, money(math.MaxInt64)),
+				NewDebit(walletAccount, money(1)),
+				NewCredit(cashOutAccount, money(math.MaxInt64)),
+			},
+			expectedError: "money amount overflow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			entry, err := NewJournalEntry("ref-1", "test entry", tt.postings...)
+
+			// Assert
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error '%s', got nil", tt.expectedError)
+				}
+				if err.Error() != tt.expectedError {
+					t.Errorf("expected '%s', got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(entry.Postings()) != len(tt.postings) {
+				t.Errorf("expected %d postings, got %d", len(tt.postings), len(entry.Postings()))
+			}
+			if entry.Reference() != "ref-1" {
+				t.Errorf("expected reference 'ref-1', got '%s'", entry.Reference())
+			}
+		})
+	}
+}
+
+func TestJournalEntryImmutability(t *testing.T) {
+	t.Run("should not expose internal postings slice", func(t *testing.T) {
+		// Arrange
+		amount, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		debitAccount := valueobject.NewUserIDRandom()
+		entry, _ := NewJournalEntry("ref-1", "test entry",
+			NewDebit(debitAccount, amount),
+			NewCredit(valueobject.NewUserIDRandom(), amount),
+		)
+
+		// Act
+		postings := entry.Postings()
+		postings[0] = NewCredit(valueobject.NewUserIDRandom(), amount)
+
+		// Assert
+		if !entry.Postings()[0].AccountID().Equals(debitAccount) {
+			t.Error("expected entry postings to be unaffected by caller mutation")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"bank/internal/domain/valueobject"
+)
+
+type LedgerAccountKind string
+
+const (
+	LedgerAccountKindWallet LedgerAccountKind = "WALLET"
+	LedgerAccountKindSystem LedgerAccountKind = "SYSTEM"
+)
+
+// System accounts are the counterparties of money entering or leaving the
+// wallets held by the service. Currency exchanges pass through FX_POSITION,
+// which holds the service's position in each currency, and book their spread
+// to FX_REVENUE. Manual adjustments made by back-office staff are booked
+// against ADJUSTMENTS.
+const (
+	SystemAccountCashIn         = "CASH_IN"
+	SystemAccountCashOut        = "CASH_OUT"
+	SystemAccountFees           = "FEES"
+	SystemAccountOpeningBalance = "OPENING_BALANCE"
+	SystemAccountFXPosition     = "FX_POSITION"
+	SystemAccountFXRevenue      = "FX_REVENUE"
+	SystemAccountAdjustments    = "ADJUSTMENTS"
+)
+
+// LedgerAccount is an account of the double-entry ledger. Every wallet owns
+// exactly one account; system accounts have no wallet.
+type LedgerAccount struct {
+	id        valueobject.UserID
+	code      string
+	kind      LedgerAccountKind
+	walletID  *valueobject.UserID
+	createdAt time.Time
+}
+
+func NewWalletLedgerAccount(walletID valueobject.UserID) *LedgerAccount {
+	return &LedgerAccount{
+		id:        valueobject.NewUserIDRandom(),
+		code:      WalletAccountCode(walletID),
+		kind:      LedgerAccountKindWallet,
+		walletID:  &walletID,
+		createdAt: time.Now().UTC(),
+	}
+}
+
+func ReconstructLedgerAccount(id valueobject.UserID, code string, kind LedgerAccountKind, walletID *valueobject.UserID, createdAt time.Time) *LedgerAccount {
+	return &LedgerAccount{
+		id:        id,
+		code:      code,
+		kind:      kind,
+		walletID:  walletID,
+		createdAt: createdAt,
+	}
+}
+
+// WalletAccountCode is the ledger account code of a wallet.
+func WalletAccountCode(walletID valueobject.UserID) string {
+	return "WALLET:" + walletID.String()
+}
+
+func (a *LedgerAccount) ID() valueobject.UserID {
+	return a.id
+}
+
+func (a *LedgerAccount) Code() string {
+	return a.code
+}
+
+func (a *LedgerAccount) Kind() LedgerAccountKind {
+	return a.kind
+}
+
+// WalletID returns the owning wallet, or nil for system accounts.
+func (a *LedgerAccount) WalletID() *valueobject.UserID {
+	return a.walletID
+}
+
+func (a *LedgerAccount) CreatedAt() time.Time {
+	return a.createdAt
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"bank/internal/domain/valueobject"
+)
+
+func TestNewWalletLedgerAccount(t *testing.T) {
+	t.Run("should create wallet account linked to the wallet", func(t *testing.T) {
+		// Arrange
+		walletID := valueobject.NewUserIDRandom()
+
+		// Act
+		account := NewWalletLedgerAccount(walletID)
+
+		// Assert
+		if account.Kind() != LedgerAccountKindWallet {
+			t.Errorf("expected kind %s, got %s", LedgerAccountKindWallet, account.Kind())
+		}
+		if account.WalletID() == nil || !account.WalletID().Equals(walletID) {
+			t.Error("wallet ID mismatch")
+		}
+		if account.Code() != "WALLET:"+walletID.String() {
+			t.Errorf("unexpected account code %s", account.Code())
+		}
+	})
+}
@@ -1,18 +1,45 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
+	"bank/internal/domain"
 	"bank/internal/domain/valueobject"
 )
 
 type TransactionType string
 
 const (
-	TransactionTypeWithdrawal TransactionType = "WITHDRAWAL"
-	TransactionTypeDeposit    TransactionType = "DEPOSIT"
+	TransactionTypeWithdrawal  TransactionType = "WITHDRAWAL"
+	TransactionTypeDeposit     TransactionType = "DEPOSIT"
+	TransactionTypeTransferOut TransactionType = "TRANSFER_OUT"
+	TransactionTypeTransferIn  TransactionType = "TRANSFER_IN"
+	TransactionTypeExchangeOut TransactionType = "EXCHANGE_OUT"
+	TransactionTypeExchangeIn  TransactionType = "EXCHANGE_IN"
+	TransactionTypeReversal    TransactionType = "REVERSAL"
+	// TransactionTypeAdjustmentCredit and TransactionTypeAdjustmentDebit are
+	// manual corrections made by back-office staff.
+	TransactionTypeAdjustmentCredit TransactionType = "ADJUSTMENT_CREDIT"
+	TransactionTypeAdjustmentDebit  TransactionType = "ADJUSTMENT_DEBIT"
 )
 
+// MaxReversalReasonLength bounds the reason recorded with a reversal.
+const MaxReversalReasonLength = 255
+
+// ParseTransactionType converts a raw value, e.g. a query parameter, into a
+// known transaction type.
+func ParseTransactionType(value string) (TransactionType, error) {
+	switch txType := TransactionType(value); txType {
+	case TransactionTypeWithdrawal, TransactionTypeDeposit, TransactionTypeTransferOut, TransactionTypeTransferIn,
+		TransactionTypeExchangeOut, TransactionTypeExchangeIn, TransactionTypeReversal,
+		TransactionTypeAdjustmentCredit, TransactionTypeAdjustmentDebit:
+		return txType, nil
+	default:
+		return "", domain.NewValidationError("type", fmt.Sprintf("unknown transaction type %q", value))
+	}
+}
+
 type TransactionStatus string
 
 const (
@@ -21,6 +48,16 @@ const (
 	TransactionStatusFailed    TransactionStatus = "FAILED"
 )
 
+// ParseTransactionStatus converts a raw value into a known transaction status.
+func ParseTransactionStatus(value string) (TransactionStatus, error) {
+	switch status := TransactionStatus(value); status {
+	case TransactionStatusPending, TransactionStatusCompleted, TransactionStatusFailed:
+		return status, nil
+	default:
+		return "", domain.NewValidationError("status", fmt.Sprintf("unknown transaction status %q", value))
+	}
+}
+
 type Transaction struct {
 	id              valueobject.UserID
 	walletID        valueobject.UserID
@@ -28,6 +65,9 @@ type Transaction struct {
 	amount          valueobject.Money
 	status          TransactionStatus
 	failureReason   string
+	transferID      *valueobject.UserID
+	reversalOf      *valueobject.UserID
+	reason          string
 	createdAt       time.Time
 }
 
@@ -43,6 +83,49 @@ func NewTransaction(walletID valueobject.UserID, txType TransactionType, amount
 	}
 }
 
+// NewTransferTransaction creates one leg of a wallet-to-wallet transfer. Both
+// legs of the same transfer share the given transfer ID. The legs of a
+// currency exchange are linked the same way, by the ID of its quote.
+func NewTransferTransaction(walletID valueobject.UserID, txType TransactionType, amount valueobject.Money, transferID valueobject.UserID) *Transaction {
+	transaction := NewTransaction(walletID, txType, amount)
+	transaction.transferID = &transferID
+	return transaction
+}
+
+// NewReversalTransaction creates the compensating transaction that returns
+// amount, all or part of a completed withdrawal, to its wallet. The reversal
+// is linked to the withdrawal and records why it was made. Whether the
+// withdrawal was already reversed is for the caller to check.
+func NewReversalTransaction(original *Transaction, amount valueobject.Money, reason string) (*Transaction, error) {
+	if original.Type() != TransactionTypeWithdrawal || original.Status() != TransactionStatusCompleted {
+		return nil, fmt.Errorf("%w: only completed withdrawals can be reversed, transaction %s is a %s %s",
+			domain.ErrTransactionNotReversible, original.ID().String(), original.Status(), original.Type())
+	}
+
+	if reason == "" {
+		return nil, domain.NewValidationError("reason", "reversal reason is required")
+	}
+	if len(reason) > MaxReversalReasonLength {
+		return nil, domain.NewValidationError("reason", fmt.Sprintf("reversal reason must be at most %d characters", MaxReversalReasonLength))
+	}
+
+	if !amount.Currency().Equals(original.Amount().Currency()) {
+		return nil, domain.ErrCurrencyMismatch
+	}
+	if amount.IsZero() {
+		return nil, domain.NewValidationError("amount", "reversal amount must be greater than zero")
+	}
+	if !amount.LessThanOrEqual(original.Amount()) {
+		return nil, domain.NewValidationError("amount", "reversal amount cannot exceed the original amount")
+	}
+
+	originalID := original.ID()
+	transaction := NewTransaction(original.WalletID(), TransactionTypeReversal, amount)
+	transaction.reversalOf = &originalID
+	transaction.reason = reason
+	return transaction, nil
+}
+
 func ReconstructTransaction(
 	id valueobject.UserID,
 	walletID valueobject.UserID,
@@ -51,6 +134,9 @@ func ReconstructTransaction(
 	status TransactionStatus,
 	createdAt string,
 	failureReason string,
+	transferID *valueobject.UserID,
+	reversalOf *valueobject.UserID,
+	reason string,
 ) *Transaction {
 	parsedTime, _ := time.Parse(time.RFC3339, createdAt)
 
@@ -61,10 +147,48 @@ func ReconstructTransaction(
 		amount:          amount,
 		status:          status,
 		failureReason:   failureReason,
+		transferID:      transferID,
+		reversalOf:      reversalOf,
+		reason:          reason,
 		createdAt:       parsedTime,
 	}
 }
 
+// Complete marks a pending transaction as successfully applied.
+func (t *Transaction) Complete() error {
+	if err := t.transitionTo(TransactionStatusCompleted); err != nil {
+		return err
+	}
+
+	t.failureReason = ""
+	return nil
+}
+
+// Fail marks a pending transaction as declined or errored with the reason.
+func (t *Transaction) Fail(reason string) error {
+	if reason == "" {
+		return domain.NewValidationError("reason", "failure reason is required")
+	}
+
+	if err := t.transitionTo(TransactionStatusFailed); err != nil {
+		return err
+	}
+
+	t.failureReason = reason
+	return nil
+}
+
+// transitionTo enforces the lifecycle PENDING -> COMPLETED | FAILED. Completed
+// and failed transactions are final.
+func (t *Transaction) transitionTo(status TransactionStatus) error {
+	if t.status != TransactionStatusPending {
+		return fmt.Errorf("%w from %s to %s", domain.ErrInvalidStatusTransition, t.status, status)
+	}
+
+	t.status = status
+	return nil
+}
+
 func (t *Transaction) ID() valueobject.UserID {
 	return t.id
 }
@@ -89,7 +213,24 @@ func (t *Transaction) FailureReason() string {
 	return t.failureReason
 }
 
+// TransferID returns the ID linking both legs of a transfer, or nil when the
+// transaction is not part of a transfer.
+func (t *Transaction) TransferID() *valueobject.UserID {
+	return t.transferID
+}
+
+// ReversalOf returns the withdrawal a reversal compensates, or nil for any
+// other transaction.
+func (t *Transaction) ReversalOf() *valueobject.UserID {
+	return t.reversalOf
+}
+
+// Reason is why a reversal or an adjustment was made; it is empty for other
+// transactions.
+func (t *Transaction) Reason() string {
+	return t.reason
+}
+
 func (t *Transaction) CreatedAt() time.Time {
 	return t.createdAt
 }
-
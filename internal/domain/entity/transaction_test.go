@@ -1,8 +1,10 @@
 package entity
 
 import (
+	"errors"
 	"testing"
 
+	"bank/internal/domain"
 	"bank/internal/domain/valueobject"
 )
 
@@ -41,7 +43,7 @@ func TestNewTransaction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			walletID := valueobject.NewUserIDRandom()
-			amount, _ := valueobject.NewMoney(tt.amount)
+			amount, _ := valueobject.NewMoney(tt.amount, valueobject.DefaultCurrency())
 
 			// Act
 			tx := NewTransaction(walletID, tt.txType, amount)
@@ -76,7 +78,7 @@ func TestNewTransaction_UniqueIDs(t *testing.T) {
 	t.Run("should generate unique transaction IDs", func(t *testing.T) {
 		// Arrange
 		walletID := valueobject.NewUserIDRandom()
-		amount, _ := valueobject.NewMoney(10000)
+		amount, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
 
 		// Act
 		tx1 := NewTransaction(walletID, TransactionTypeWithdrawal, amount)
@@ -96,6 +98,144 @@ func TestNewTransaction_UniqueIDs(t *testing.T) {
 	})
 }
 
+func TestNewTransferTransaction(t *testing.T) {
+	t.Run("should link both transfer legs with the same transfer ID", func(t *testing.T) {
+		// Arrange
+		fromWalletID := valueobject.NewUserIDRandom()
+		toWalletID := valueobject.NewUserIDRandom()
+		transferID := valueobject.NewUserIDRandom()
+		amount, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+
+		// Act
+		out := NewTransferTransaction(fromWalletID, TransactionTypeTransferOut, amount, transferID)
+		in := NewTransferTransaction(toWalletID, TransactionTypeTransferIn, amount, transferID)
+
+		// Assert
+		if out.TransferID() == nil || in.TransferID() == nil {
+			t.Fatal("expected transfer ID to be set on both legs")
+		}
+		if !out.TransferID().Equals(*in.TransferID()) {
+			t.Error("expected both legs to share the transfer ID")
+		}
+		if out.ID().Equals(in.ID()) {
+			t.Error("expected different transaction IDs for each leg")
+		}
+		if out.Type() != TransactionTypeTransferOut || in.Type() != TransactionTypeTransferIn {
+			t.Errorf("unexpected leg types %s and %s", out.Type(), in.Type())
+		}
+	})
+
+	t.Run("should not set transfer ID on regular transactions", func(t *testing.T) {
+		// Arrange
+		amount, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+
+		// Act
+		tx := NewTransaction(valueobject.NewUserIDRandom(), TransactionTypeWithdrawal, amount)
+
+		// Assert
+		if tx.TransferID() != nil {
+			t.Error("expected nil transfer ID")
+		}
+	})
+}
+
+func TestNewReversalTransaction(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, usd)
+		return m
+	}
+	completedWithdrawal := func() *Transaction {
+		tx := NewTransaction(valueobject.NewUserIDRandom(), TransactionTypeWithdrawal, money(10000))
+		_ = tx.Complete()
+		return tx
+	}
+
+	t.Run("should link a partial reversal to the withdrawal", func(t *testing.T) {
+		// Arrange
+		original := completedWithdrawal()
+
+		// Act
+		reversal, err := NewReversalTransaction(original, money(4000), "duplicate charge")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if reversal.Type() != TransactionTypeReversal {
+			t.Errorf("expected type REVERSAL, got %s", reversal.Type())
+		}
+		if !reversal.WalletID().Equals(original.WalletID()) {
+			t.Error("expected the reversal to credit the withdrawal's wallet")
+		}
+		if reversal.ReversalOf() == nil || !reversal.ReversalOf().Equals(original.ID()) {
+			t.Error("expected the reversal to reference the withdrawal")
+		}
+		if reversal.Amount().Amount() != 4000 || reversal.Reason() != "duplicate charge" {
+			t.Errorf("unexpected reversal of %s for %q", reversal.Amount(), reversal.Reason())
+		}
+		if reversal.Status() != TransactionStatusPending {
+			t.Errorf("expected status PENDING, got %s", reversal.Status())
+		}
+	})
+
+	t.Run("should not reverse more than the original amount", func(t *testing.T) {
+		// Arrange
+		original := completedWithdrawal()
+
+		// Act
+		_, err := NewReversalTransaction(original, money(10001), "refund")
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "amount" {
+			t.Errorf("expected a validation error on amount, got %v", err)
+		}
+	})
+
+	t.Run("should require a reason", func(t *testing.T) {
+		// Arrange
+		original := completedWithdrawal()
+
+		// Act
+		_, err := NewReversalTransaction(original, money(100), "")
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "reason" {
+			t.Errorf("expected a validation error on reason, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		original func() *Transaction
+	}{
+		{"should not reverse a deposit", func() *Transaction {
+			tx := NewTransaction(valueobject.NewUserIDRandom(), TransactionTypeDeposit, money(10000))
+			_ = tx.Complete()
+			return tx
+		}},
+		{"should not reverse a failed withdrawal", func() *Transaction {
+			tx := NewTransaction(valueobject.NewUserIDRandom(), TransactionTypeWithdrawal, money(10000))
+			_ = tx.Fail("insufficient funds")
+			return tx
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewReversalTransaction(tt.original(), money(100), "refund")
+
+			// Assert
+			if !errors.Is(err, domain.ErrTransactionNotReversible) {
+				t.Errorf("expected ErrTransactionNotReversible, got %v", err)
+			}
+		})
+	}
+}
+
 func TestReconstructTransaction(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -140,7 +280,7 @@ func TestReconstructTransaction(t *testing.T) {
 			// Arrange
 			id := valueobject.NewUserIDRandom()
 			walletID := valueobject.NewUserIDRandom()
-			amount, _ := valueobject.NewMoney(tt.amount)
+			amount, _ := valueobject.NewMoney(tt.amount, valueobject.DefaultCurrency())
 
 			// Act
 			tx := ReconstructTransaction(
@@ -151,6 +291,9 @@ func TestReconstructTransaction(t *testing.T) {
 				tt.status,
 				tt.createdAt,
 				tt.failureReason,
+				nil,
+				nil,
+				"",
 			)
 
 			// Assert
@@ -179,6 +322,185 @@ func TestReconstructTransaction(t *testing.T) {
 	}
 }
 
+func TestTransactionLifecycle(t *testing.T) {
+	newPending := func() *Transaction {
+		amount, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		return NewTransaction(valueobject.NewUserIDRandom(), TransactionTypeWithdrawal, amount)
+	}
+
+	t.Run("should complete a pending transaction", func(t *testing.T) {
+		// Arrange
+		tx := newPending()
+
+		// Act
+		err := tx.Complete()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if tx.Status() != TransactionStatusCompleted {
+			t.Errorf("expected completed status, got %s", tx.Status())
+		}
+	})
+
+	t.Run("should fail a pending transaction with reason", func(t *testing.T) {
+		// Arrange
+		tx := newPending()
+
+		// Act
+		err := tx.Fail("insufficient funds")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if tx.Status() != TransactionStatusFailed {
+			t.Errorf("expected failed status, got %s", tx.Status())
+		}
+		if tx.FailureReason() != "insufficient funds" {
+			t.Errorf("expected failure reason 'insufficient funds', got '%s'", tx.FailureReason())
+		}
+	})
+
+	t.Run("should require a failure reason", func(t *testing.T) {
+		// Arrange
+		tx := newPending()
+
+		// Act
+		err := tx.Fail("")
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error for empty reason, got nil")
+		}
+		if tx.Status() != TransactionStatusPending {
+			t.Errorf("expected status to remain pending, got %s", tx.Status())
+		}
+	})
+
+	tests := []struct {
+		name       string
+		prepare    func(tx *Transaction)
+		transition func(tx *Transaction) error
+		expected   string
+	}{
+		{
+			name:       "complete after complete",
+			prepare:    func(tx *Transaction) { _ = tx.Complete() },
+			transition: func(tx *Transaction) error { return tx.Complete() },
+			expected:   "invalid transaction status transition from COMPLETED to COMPLETED",
+		},
+		{
+			name:       "fail after complete",
+			prepare:    func(tx *Transaction) { _ = tx.Complete() },
+			transition: func(tx *Transaction) error { return tx.Fail("late failure") },
+			expected:   "invalid transaction status transition from COMPLETED to FAILED",
+		},
+		{
+			name:       "complete after fail",
+			prepare:    func(tx *Transaction) { _ = tx.Fail("declined") },
+			transition: func(tx *Transaction) error { return tx.Complete() },
+			expected:   "invalid transaction status transition from FAILED to COMPLETED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			// Arrange
+			tx := newPending()
+			tt.prepare(tx)
+			before := tx.Status()
+
+			// Act
+			err := tt.transition(tx)
+
+			// Assert
+			if err == nil {
+				t.Fatal("expected illegal transition error, got nil")
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("expected '%s', got %v", tt.expected, err)
+			}
+			if tx.Status() != before {
+				t.Errorf("expected status to remain %s, got %s", before, tx.Status())
+			}
+		})
+	}
+}
+
+func TestParseTransactionType(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    TransactionType
+		expectError bool
+	}{
+		{"withdrawal", "WITHDRAWAL", TransactionTypeWithdrawal, false},
+		{"deposit", "DEPOSIT", TransactionTypeDeposit, false},
+		{"transfer out", "TRANSFER_OUT", TransactionTypeTransferOut, false},
+		{"transfer in", "TRANSFER_IN", TransactionTypeTransferIn, false},
+		{"lowercase is rejected", "withdrawal", "", true},
+		{"unknown type", "REFUND", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			txType, err := ParseTransactionType(tt.value)
+
+			// Assert
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error for %q, got nil", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if txType != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, txType)
+			}
+		})
+	}
+}
+
+func TestParseTransactionStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    TransactionStatus
+		expectError bool
+	}{
+		{"pending", "PENDING", TransactionStatusPending, false},
+		{"completed", "COMPLETED", TransactionStatusCompleted, false},
+		{"failed", "FAILED", TransactionStatusFailed, false},
+		{"unknown status", "DONE", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			status, err := ParseTransactionStatus(tt.value)
+
+			// Assert
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error for %q, got nil", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if status != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, status)
+			}
+		})
+	}
+}
+
 func TestTransaction_Constants(t *testing.T) {
 	t.Run("should have correct transaction type constants", func(t *testing.T) {
 		tests := []struct {
@@ -188,6 +510,8 @@ func TestTransaction_Constants(t *testing.T) {
 		}{
 			{"withdrawal constant", TransactionTypeWithdrawal, "WITHDRAWAL"},
 			{"deposit constant", TransactionTypeDeposit, "DEPOSIT"},
+			{"transfer out constant", TransactionTypeTransferOut, "TRANSFER_OUT"},
+			{"transfer in constant", TransactionTypeTransferIn, "TRANSFER_IN"},
 		}
 
 		for _, tt := range tests {
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// MaxUserNameLength bounds a user's display name, in characters.
+const MaxUserNameLength = 50
+
+var externalRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]{0,63}$`)
+
+// User owns wallets. The optional external reference ties the user to a
+// record in another system, such as a CRM customer number; no two users share
+// one.
+type User struct {
+	id          valueobject.UserID
+	name        string
+	externalRef string
+	createdAt   time.Time
+}
+
+// NewUser creates a user. The name is trimmed and must be 1 to 50 printable
+// characters; an external reference, when given, is 1 to 64 letters, digits,
+// dots, underscores, colons or dashes.
+func NewUser(name, externalRef string, now time.Time) (*User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxUserNameLength {
+		return nil, domain.NewValidationError("name", "user name must be 1 to 50 characters")
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		return nil, domain.NewValidationError("name", "user name must not contain control characters")
+	}
+
+	if externalRef != "" && !externalRefPattern.MatchString(externalRef) {
+		return nil, domain.NewValidationError("external_ref", "external reference must be 1 to 64 letters, digits, dots, underscores, colons or dashes")
+	}
+
+	return &User{
+		id:          valueobject.NewUserIDRandom(),
+		name:        name,
+		externalRef: externalRef,
+		createdAt:   now.UTC(),
+	}, nil
+}
+
+func ReconstructUser(id valueobject.UserID, name, externalRef string, createdAt time.Time) *User {
+	return &User{
+		id:          id,
+		name:        name,
+		externalRef: externalRef,
+		createdAt:   createdAt,
+	}
+}
+
+func (u *User) ID() valueobject.UserID {
+	return u.id
+}
+
+func (u *User) Name() string {
+	return u.name
+}
+
+// ExternalRef is empty when the user has no external reference.
+func (u *User) ExternalRef() string {
+	return u.externalRef
+}
+
+func (u *User) CreatedAt() time.Time {
+	return u.createdAt
+}
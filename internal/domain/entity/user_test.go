@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+)
+
+func TestNewUser(t *testing.T) {
+	t.Run("should create a user with a trimmed name", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+		// Act
+		user, err := NewUser("  Ada Lovelace ", "crm:1815-12", now)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.Name() != "Ada Lovelace" || user.ExternalRef() != "crm:1815-12" {
+			t.Errorf("expected Ada Lovelace with crm:1815-12, got %q with %q", user.Name(), user.ExternalRef())
+		}
+		if user.ID().String() == "" || !user.CreatedAt().Equal(now) || user.CreatedAt().Location() != time.UTC {
+			t.Errorf("expected an ID and a UTC creation time, got %v at %v", user.ID(), user.CreatedAt())
+		}
+	})
+
+	t.Run("should accept a user without an external reference", func(t *testing.T) {
+		// Act
+		user, err := NewUser("Grace", "", time.Now())
+
+		// Assert
+		if err != nil || user.ExternalRef() != "" {
+			t.Errorf("expected a user without a reference, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		userName    string
+		externalRef string
+		field       string
+	}{
+		{name: "should reject an empty name", userName: "   ", field: "name"},
+		{name: "should reject a name that is too long", userName: strings.Repeat("é", MaxUserNameLength+1), field: "name"},
+		{name: "should reject a name with control characters", userName: "Ada\nLovelace", field: "name"},
+		{name: "should reject a reference with spaces", userName: "Ada", externalRef: "crm 1815", field: "external_ref"},
+		{name: "should reject a reference that is too long", userName: "Ada", externalRef: strings.Repeat("a", 65), field: "external_ref"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewUser(tt.userName, tt.externalRef, time.Now())
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("expected a ValidationError on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
@@ -1,39 +1,93 @@
 package entity
 
 import (
-	"errors"
+	"math"
+	"regexp"
 
+	"bank/internal/domain"
 	"bank/internal/domain/valueobject"
 )
 
+// DefaultWalletName names the wallet every user starts with.
+const DefaultWalletName = "main"
+
+var walletNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// Wallet holds a balance in a single currency. A user may own several wallets,
+// told apart by name; exactly one of them is the user's default wallet, which
+// receives the operations addressed by user ID alone.
+//
+// A wallet's tier selects its default withdrawal limits.
+//
+// Part of the balance may be reserved by holds until they are captured or
+// released. The balance is the ledger balance, including held funds; only the
+// available balance, the balance less held funds, can be spent.
+//
+// A wallet with an overdraft limit may spend that much beyond its balance,
+// which then turns negative. Without one, the balance never drops below zero.
+//
+// A wallet's status decides whether money may move in or out of it; see
+// WalletStatus.
 type Wallet struct {
-	id      valueobject.UserID // Using UserID as wallet ID for simplicity
-	userID  valueobject.UserID
-	balance valueobject.Money
+	id        valueobject.UserID // Using UserID as wallet ID for simplicity
+	userID    valueobject.UserID
+	name      string
+	isDefault bool
+	tier      WalletTier
+	status    WalletStatus
+	balance   valueobject.Balance
+	held      valueobject.Money
+	overdraft valueobject.Money
 }
 
-func NewWallet(userID valueobject.UserID) *Wallet {
-	balance, _ := valueobject.NewMoney(0)
-	return &Wallet{
-		id:      valueobject.NewUserIDRandom(),
-		userID:  userID,
-		balance: balance,
+// NewWallet creates an empty, non-default wallet. Names are 1 to 50 lower
+// case letters, digits, dashes and underscores, such as "savings".
+func NewWallet(userID valueobject.UserID, name string, currency valueobject.Currency) (*Wallet, error) {
+	if !walletNamePattern.MatchString(name) {
+		return nil, domain.NewValidationError("name", "wallet name must be 1 to 50 lower case letters, digits, dashes or underscores")
 	}
+
+	zero, _ := valueobject.NewMoney(0, currency)
+	return &Wallet{
+		id:        valueobject.NewUserIDRandom(),
+		userID:    userID,
+		name:      name,
+		tier:      WalletTierStandard,
+		status:    WalletStatusActive,
+		balance:   valueobject.BalanceOf(zero),
+		held:      zero,
+		overdraft: zero,
+	}, nil
 }
 
+// NewWalletWithBalance creates a user's default wallet holding an initial
+// balance.
 func NewWalletWithBalance(userID valueobject.UserID, initialBalance valueobject.Money) *Wallet {
+	zero, _ := valueobject.NewMoney(0, initialBalance.Currency())
 	return &Wallet{
-		id:      valueobject.NewUserIDRandom(),
-		userID:  userID,
-		balance: initialBalance,
+		id:        valueobject.NewUserIDRandom(),
+		userID:    userID,
+		name:      DefaultWalletName,
+		isDefault: true,
+		tier:      WalletTierStandard,
+		status:    WalletStatusActive,
+		balance:   valueobject.BalanceOf(initialBalance),
+		held:      zero,
+		overdraft: zero,
 	}
 }
 
-func ReconstructWallet(id, userID valueobject.UserID, balance valueobject.Money) *Wallet {
+func ReconstructWallet(id, userID valueobject.UserID, name string, isDefault bool, tier WalletTier, status WalletStatus, balance valueobject.Balance, held, overdraftLimit valueobject.Money) *Wallet {
 	return &Wallet{
-		id:      id,
-		userID:  userID,
-		balance: balance,
+		id:        id,
+		userID:    userID,
+		name:      name,
+		isDefault: isDefault,
+		tier:      tier,
+		status:    status,
+		balance:   balance,
+		held:      held,
+		overdraft: overdraftLimit,
 	}
 }
 
@@ -45,17 +99,105 @@ func (w *Wallet) UserID() valueobject.UserID {
 	return w.userID
 }
 
-func (w *Wallet) Balance() valueobject.Money {
+func (w *Wallet) Name() string {
+	return w.name
+}
+
+func (w *Wallet) IsDefault() bool {
+	return w.isDefault
+}
+
+// MarkDefault makes a wallet that has not been stored yet its user's default
+// wallet, as is done for the first wallet a user opens.
+func (w *Wallet) MarkDefault() {
+	w.isDefault = true
+}
+
+func (w *Wallet) Tier() WalletTier {
+	return w.tier
+}
+
+// ChangeTier moves the wallet to another tier, and so to its limits.
+func (w *Wallet) ChangeTier(tier WalletTier) {
+	w.tier = tier
+}
+
+func (w *Wallet) Status() WalletStatus {
+	return w.status
+}
+
+// Balance is negative while the wallet is overdrawn.
+func (w *Wallet) Balance() valueobject.Balance {
 	return w.balance
 }
 
+// OverdraftLimit is how far the balance may go below zero.
+func (w *Wallet) OverdraftLimit() valueobject.Money {
+	return w.overdraft
+}
+
+// SetOverdraftLimit changes how far the balance may go below zero. The limit
+// cannot be lowered below what the wallet already owes and holds.
+func (w *Wallet) SetOverdraftLimit(limit valueobject.Money) error {
+	if !limit.Currency().Equals(w.Currency()) {
+		return domain.ErrCurrencyMismatch
+	}
+
+	if spendable(w.balance, limit, w.held) < 0 {
+		return domain.NewValidationError("overdraft_limit", "overdraft limit cannot be lower than the overdrawn and held amount")
+	}
+
+	w.overdraft = limit
+	return nil
+}
+
+// HeldBalance is the part of the balance reserved by active holds.
+func (w *Wallet) HeldBalance() valueobject.Money {
+	return w.held
+}
+
+// AvailableBalance is what can be withdrawn, transferred or held: the balance
+// plus the overdraft limit, less held funds.
+func (w *Wallet) AvailableBalance() valueobject.Money {
+	available, err := valueobject.NewMoney(spendable(w.balance, w.overdraft, w.held), w.Currency())
+	if err != nil {
+		// Held funds never exceed the balance plus the overdraft limit; treat
+		// a broken invariant as nothing available rather than guessing.
+		available, _ = valueobject.NewMoney(0, w.Currency())
+	}
+	return available
+}
+
+// spendable computes balance + overdraft - held, capped at math.MaxInt64.
+func spendable(balance valueobject.Balance, overdraft, held valueobject.Money) int64 {
+	total := balance.Amount() - held.Amount()
+	if total > 0 && overdraft.Amount() > math.MaxInt64-total {
+		return math.MaxInt64
+	}
+	return total + overdraft.Amount()
+}
+
+// Currency is the currency the wallet's balance is held in; it only accepts
+// amounts in that currency.
+func (w *Wallet) Currency() valueobject.Currency {
+	return w.balance.Currency()
+}
+
 func (w *Wallet) Withdraw(amount valueobject.Money) error {
 	if amount.IsZero() {
-		return errors.New("withdraw amount must be greater than zero")
+		return domain.NewValidationError("amount", "withdraw amount must be greater than zero")
+	}
+
+	if err := w.status.checkDebit(); err != nil {
+		return err
+	}
+
+	if !amount.Currency().Equals(w.Currency()) {
+		return domain.ErrCurrencyMismatch
 	}
 
 	if !w.CanWithdraw(amount) {
-		return errors.New("insufficient funds")
+		return domain.ErrInsufficientFunds
 	}
 
 	newBalance, err := w.balance.Subtract(amount)
@@ -67,9 +209,95 @@ func (w *Wallet) Withdraw(amount valueobject.Money) error {
 	return nil
 }
 
+func (w *Wallet) Deposit(amount valueobject.Money) error {
+	if amount.IsZero() {
+		return domain.NewValidationError("amount", "deposit amount must be greater than zero")
+	}
+
+	if err := w.status.checkCredit(); err != nil {
+		return err
+	}
+
+	newBalance, err := w.balance.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	w.balance = newBalance
+	return nil
+}
+
+// CanWithdraw reports whether amount is covered by the available balance,
+// which includes the overdraft limit; funds reserved by holds cannot be
+// withdrawn.
 func (w *Wallet) CanWithdraw(amount valueobject.Money) bool {
 	if amount.IsZero() {
 		return false
 	}
-	return amount.LessThanOrEqual(w.balance)
+	return amount.LessThanOrEqual(w.AvailableBalance())
+}
+
+// PlaceHold reserves amount of the available balance.
+func (w *Wallet) PlaceHold(amount valueobject.Money) error {
+	if amount.IsZero() {
+		return domain.NewValidationError("amount", "hold amount must be greater than zero")
+	}
+
+	if err := w.status.checkDebit(); err != nil {
+		return err
+	}
+
+	if !amount.Currency().Equals(w.Currency()) {
+		return domain.ErrCurrencyMismatch
+	}
+
+	if !w.CanWithdraw(amount) {
+		return domain.ErrInsufficientFunds
+	}
+
+	newHeld, err := w.held.Add(amount)
+	if err != nil {
+		return err
+	}
+
+	w.held = newHeld
+	return nil
+}
+
+// ReleaseHold returns a held amount to the available balance. It is allowed
+// whatever the wallet's status, since no money leaves the wallet.
+func (w *Wallet) ReleaseHold(amount valueobject.Money) error {
+	newHeld, err := w.held.Subtract(amount)
+	if err != nil {
+		return err
+	}
+
+	w.held = newHeld
+	return nil
+}
+
+// CaptureHold settles a hold of held by taking captured, at most held, from
+// the balance. Whatever was held but not captured becomes available again.
+func (w *Wallet) CaptureHold(held, captured valueobject.Money) error {
+	if !captured.LessThanOrEqual(held) {
+		return domain.NewValidationError("amount", "capture amount cannot exceed the held amount")
+	}
+
+	if err := w.status.checkDebit(); err != nil {
+		return err
+	}
+
+	newHeld, err := w.held.Subtract(held)
+	if err != nil {
+		return err
+	}
+
+	newBalance, err := w.balance.Subtract(captured)
+	if err != nil {
+		return err
+	}
+
+	w.held = newHeld
+	w.balance = newBalance
+	return nil
 }
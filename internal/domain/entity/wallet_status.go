@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// WalletStatus controls which money movements a wallet accepts.
+type WalletStatus string
+
+const (
+	// WalletStatusActive accepts every movement.
+	WalletStatusActive WalletStatus = "ACTIVE"
+	// WalletStatusFrozen blocks every movement, for example while a
+	// compromised account is investigated.
+	WalletStatusFrozen WalletStatus = "FROZEN"
+	// WalletStatusDebitBlocked accepts money in but lets none out.
+	WalletStatusDebitBlocked WalletStatus = "DEBIT_BLOCKED"
+	// WalletStatusClosed is final; only an empty wallet can be closed.
+	WalletStatusClosed WalletStatus = "CLOSED"
+)
+
+// MaxStatusReasonLength bounds the reason recorded with a status change.
+const MaxStatusReasonLength = 255
+
+// ParseWalletStatus converts a raw value, e.g. a request field, into a known
+// wallet status.
+func ParseWalletStatus(value string) (WalletStatus, error) {
+	switch status := WalletStatus(value); status {
+	case WalletStatusActive, WalletStatusFrozen, WalletStatusDebitBlocked, WalletStatusClosed:
+		return status, nil
+	default:
+		return "", domain.NewValidationError("status", fmt.Sprintf("unknown wallet status %q", value))
+	}
+}
+
+// checkDebit reports why a wallet in this status cannot pay money out.
+func (s WalletStatus) checkDebit() error {
+	switch s {
+	case WalletStatusFrozen:
+		return domain.ErrWalletFrozen
+	case WalletStatusDebitBlocked:
+		return domain.ErrWalletDebitBlocked
+	case WalletStatusClosed:
+		return domain.ErrWalletClosed
+	}
+	return nil
+}
+
+// checkCredit reports why a wallet in this status cannot receive money.
+func (s WalletStatus) checkCredit() error {
+	switch s {
+	case WalletStatusFrozen:
+		return domain.ErrWalletFrozen
+	case WalletStatusClosed:
+		return domain.ErrWalletClosed
+	}
+	return nil
+}
+
+// WalletStatusChange records one change of a wallet's status and why it was
+// made. Changes are only ever appended.
+type WalletStatusChange struct {
+	id        valueobject.UserID
+	walletID  valueobject.UserID
+	from      WalletStatus
+	to        WalletStatus
+	reason    string
+	changedAt time.Time
+}
+
+func ReconstructWalletStatusChange(id, walletID valueobject.UserID, from, to WalletStatus, reason string, changedAt time.Time) *WalletStatusChange {
+	return &WalletStatusChange{
+		id:        id,
+		walletID:  walletID,
+		from:      from,
+		to:        to,
+		reason:    reason,
+		changedAt: changedAt,
+	}
+}
+
+func (c *WalletStatusChange) ID() valueobject.UserID {
+	return c.id
+}
+
+func (c *WalletStatusChange) WalletID() valueobject.UserID {
+	return c.walletID
+}
+
+func (c *WalletStatusChange) From() WalletStatus {
+	return c.from
+}
+
+func (c *WalletStatusChange) To() WalletStatus {
+	return c.to
+}
+
+func (c *WalletStatusChange) Reason() string {
+	return c.reason
+}
+
+func (c *WalletStatusChange) ChangedAt() time.Time {
+	return c.changedAt
+}
+
+// ChangeStatus moves the wallet to status and returns the record of the
+// change. A closed wallet cannot change again, and a wallet can only be
+// closed once it holds nothing, owes nothing and has no active holds.
+func (w *Wallet) ChangeStatus(status WalletStatus, reason string, now time.Time) (*WalletStatusChange, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domain.NewValidationError("reason", "a reason is required to change a wallet's status")
+	}
+	if len(reason) > MaxStatusReasonLength {
+		return nil, domain.NewValidationError("reason", fmt.Sprintf("status reason must be at most %d characters", MaxStatusReasonLength))
+	}
+
+	if w.status == WalletStatusClosed || w.status == status {
+		return nil, fmt.Errorf("%w from %s to %s", domain.ErrInvalidWalletStatusTransition, w.status, status)
+	}
+
+	if status == WalletStatusClosed && (!w.balance.IsZero() || !w.held.IsZero()) {
+		return nil, domain.ErrWalletNotEmpty
+	}
+
+	change := &WalletStatusChange{
+		id:        valueobject.NewUserIDRandom(),
+		walletID:  w.id,
+		from:      w.status,
+		to:        status,
+		reason:    reason,
+		changedAt: now,
+	}
+
+	w.status = status
+	return change, nil
+}
@@ -0,0 +1,263 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func TestWalletStatusRules(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, usd)
+		return m
+	}
+
+	tests := []struct {
+		name           string
+		status         WalletStatus
+		expectedDebit  error
+		expectedCredit error
+	}{
+		{
+			name:   "should move money both ways while active",
+			status: WalletStatusActive,
+		},
+		{
+			name:           "should block both ways while frozen",
+			status:         WalletStatusFrozen,
+			expectedDebit:  domain.ErrWalletFrozen,
+			expectedCredit: domain.ErrWalletFrozen,
+		},
+		{
+			name:          "should only accept deposits while debit blocked",
+			status:        WalletStatusDebitBlocked,
+			expectedDebit: domain.ErrWalletDebitBlocked,
+		},
+		{
+			name:           "should block both ways once closed",
+			status:         WalletStatusClosed,
+			expectedDebit:  domain.ErrWalletClosed,
+			expectedCredit: domain.ErrWalletClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			newWallet := func() *Wallet {
+				return ReconstructWallet(valueobject.NewUserIDRandom(), valueobject.NewUserIDRandom(), DefaultWalletName, true,
+					WalletTierStandard, tt.status, valueobject.BalanceOf(money(1000)), money(0), money(0))
+			}
+
+			// Act
+			withdrawErr := newWallet().Withdraw(money(100))
+			holdErr := newWallet().PlaceHold(money(100))
+			depositErr := newWallet().Deposit(money(100))
+
+			// Assert
+			if !errors.Is(withdrawErr, tt.expectedDebit) {
+				t.Errorf("expected withdrawal error %v, got %v", tt.expectedDebit, withdrawErr)
+			}
+			if !errors.Is(holdErr, tt.expectedDebit) {
+				t.Errorf("expected hold error %v, got %v", tt.expectedDebit, holdErr)
+			}
+			if !errors.Is(depositErr, tt.expectedCredit) {
+				t.Errorf("expected deposit error %v, got %v", tt.expectedCredit, depositErr)
+			}
+		})
+	}
+
+	t.Run("should release but not capture holds while frozen", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		_ = wallet.PlaceHold(money(400))
+		_, _ = wallet.ChangeStatus(WalletStatusFrozen, "fraud review", time.Now())
+
+		// Act
+		captureErr := wallet.CaptureHold(money(400), money(400))
+		releaseErr := wallet.ReleaseHold(money(400))
+
+		// Assert
+		if !errors.Is(captureErr, domain.ErrWalletFrozen) {
+			t.Errorf("expected ErrWalletFrozen, got %v", captureErr)
+		}
+		if releaseErr != nil {
+			t.Errorf("expected the hold to be released, got %v", releaseErr)
+		}
+		if wallet.AvailableBalance().Amount() != 1000 {
+			t.Errorf("expected available balance 1000, got %d", wallet.AvailableBalance().Amount())
+		}
+	})
+}
+
+func TestWalletChangeStatus(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, usd)
+		return m
+	}
+
+	t.Run("should record the change and its reason", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		// Act
+		change, err := wallet.ChangeStatus(WalletStatusFrozen, "  suspected fraud  ", now)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Status() != WalletStatusFrozen {
+			t.Errorf("expected status FROZEN, got %s", wallet.Status())
+		}
+		if !change.WalletID().Equals(wallet.ID()) || change.From() != WalletStatusActive || change.To() != WalletStatusFrozen {
+			t.Errorf("expected a change from ACTIVE to FROZEN of the wallet, got %s to %s", change.From(), change.To())
+		}
+		if change.Reason() != "suspected fraud" || !change.ChangedAt().Equal(now) {
+			t.Errorf("expected the trimmed reason at %v, got %q at %v", now, change.Reason(), change.ChangedAt())
+		}
+	})
+
+	t.Run("should close an empty wallet", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+
+		// Act
+		_, err := wallet.ChangeStatus(WalletStatusClosed, "customer request", time.Now())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Status() != WalletStatusClosed {
+			t.Errorf("expected status CLOSED, got %s", wallet.Status())
+		}
+	})
+
+	tests := []struct {
+		name     string
+		wallet   func() *Wallet
+		status   WalletStatus
+		reason   string
+		expected error
+	}{
+		{
+			name: "should refuse to close a wallet with a balance",
+			wallet: func() *Wallet {
+				return NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1))
+			},
+			status:   WalletStatusClosed,
+			reason:   "customer request",
+			expected: domain.ErrWalletNotEmpty,
+		},
+		{
+			name: "should refuse to close an overdrawn wallet",
+			wallet: func() *Wallet {
+				wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+				_ = wallet.SetOverdraftLimit(money(500))
+				_ = wallet.Withdraw(money(100))
+				return wallet
+			},
+			status:   WalletStatusClosed,
+			reason:   "customer request",
+			expected: domain.ErrWalletNotEmpty,
+		},
+		{
+			name: "should refuse to close a wallet with held funds",
+			wallet: func() *Wallet {
+				wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+				_ = wallet.SetOverdraftLimit(money(500))
+				_ = wallet.PlaceHold(money(100))
+				return wallet
+			},
+			status:   WalletStatusClosed,
+			reason:   "customer request",
+			expected: domain.ErrWalletNotEmpty,
+		},
+		{
+			name: "should refuse to reopen a closed wallet",
+			wallet: func() *Wallet {
+				wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+				_, _ = wallet.ChangeStatus(WalletStatusClosed, "customer request", time.Now())
+				return wallet
+			},
+			status:   WalletStatusActive,
+			reason:   "reopen",
+			expected: domain.ErrInvalidWalletStatusTransition,
+		},
+		{
+			name: "should refuse a change to the current status",
+			wallet: func() *Wallet {
+				return NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+			},
+			status:   WalletStatusActive,
+			reason:   "no-op",
+			expected: domain.ErrInvalidWalletStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			wallet := tt.wallet()
+			before := wallet.Status()
+
+			// Act
+			_, err := wallet.ChangeStatus(tt.status, tt.reason, time.Now())
+
+			// Assert
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if wallet.Status() != before {
+				t.Errorf("status should remain %s, got %s", before, wallet.Status())
+			}
+		})
+	}
+
+	for _, reason := range []string{"", "   ", strings.Repeat("x", MaxStatusReasonLength+1)} {
+		t.Run("should reject an invalid reason", func(t *testing.T) {
+			// Arrange
+			wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(0))
+
+			// Act
+			_, err := wallet.ChangeStatus(WalletStatusFrozen, reason, time.Now())
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "reason" {
+				t.Errorf("expected a ValidationError on reason, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseWalletStatus(t *testing.T) {
+	t.Run("should parse a known status", func(t *testing.T) {
+		// Act
+		status, err := ParseWalletStatus("DEBIT_BLOCKED")
+
+		// Assert
+		if err != nil || status != WalletStatusDebitBlocked {
+			t.Errorf("expected DEBIT_BLOCKED, got %s (%v)", status, err)
+		}
+	})
+
+	t.Run("should reject an unknown status", func(t *testing.T) {
+		// Act
+		_, err := ParseWalletStatus("SUSPENDED")
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected a ValidationError, got %v", err)
+		}
+	})
+}
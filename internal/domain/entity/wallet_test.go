@@ -1,8 +1,12 @@
 package entity
 
 import (
+	"errors"
+	"math"
+	"strings"
 	"testing"
 
+	"bank/internal/domain"
 	"bank/internal/domain/valueobject"
 )
 
@@ -12,21 +16,45 @@ func TestNewWallet(t *testing.T) {
 		userID := valueobject.NewUserIDRandom()
 
 		// Act
-		wallet := NewWallet(userID)
+		wallet, err := NewWallet(userID, "savings", valueobject.DefaultCurrency())
 
 		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
 		if !wallet.UserID().Equals(userID) {
 			t.Error("userID mismatch")
 		}
+		if wallet.Name() != "savings" {
+			t.Errorf("expected name savings, got %s", wallet.Name())
+		}
+		if wallet.IsDefault() {
+			t.Error("expected a new wallet not to be the default")
+		}
 		if !wallet.Balance().IsZero() {
 			t.Errorf("expected zero balance, got %d", wallet.Balance().Amount())
 		}
 	})
 
+	t.Run("should reject invalid wallet names", func(t *testing.T) {
+		names := []string{"", "Savings", "my wallet", "-savings", strings.Repeat("a", 51)}
+
+		for _, name := range names {
+			// Act
+			_, err := NewWallet(valueobject.NewUserIDRandom(), name, valueobject.DefaultCurrency())
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error for name %q, got %v", name, err)
+			}
+		}
+	})
+
 	t.Run("should create a wallet with initial balance", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(50000)
+		initialBalance, _ := valueobject.NewMoney(50000, valueobject.DefaultCurrency())
 
 		// Act
 		wallet := NewWalletWithBalance(userID, initialBalance)
@@ -35,6 +63,9 @@ func TestNewWallet(t *testing.T) {
 		if !wallet.UserID().Equals(userID) {
 			t.Error("userID mismatch")
 		}
+		if !wallet.IsDefault() || wallet.Name() != DefaultWalletName {
+			t.Errorf("expected the default %s wallet, got %s (default %t)", DefaultWalletName, wallet.Name(), wallet.IsDefault())
+		}
 		if wallet.Balance().Amount() != initialBalance.Amount() {
 			t.Errorf("expected balance %d, got %d", initialBalance.Amount(), wallet.Balance().Amount())
 		}
@@ -45,8 +76,8 @@ func TestWalletWithdraw(t *testing.T) {
 	t.Run("should withdraw money successfully", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(50000)
-		withdrawAmount, _ := valueobject.NewMoney(20000)
+		initialBalance, _ := valueobject.NewMoney(50000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(20000, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -57,7 +88,7 @@ func TestWalletWithdraw(t *testing.T) {
 			t.Fatalf("expected no error, got %v", err)
 		}
 
-		expectedBalance, _ := valueobject.NewMoney(30000)
+		expectedBalance, _ := valueobject.NewMoney(30000, valueobject.DefaultCurrency())
 		if wallet.Balance().Amount() != expectedBalance.Amount() {
 			t.Errorf("expected balance %d, got %d", expectedBalance.Amount(), wallet.Balance().Amount())
 		}
@@ -66,8 +97,8 @@ func TestWalletWithdraw(t *testing.T) {
 	t.Run("should fail when withdrawing more than balance", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(10000)
-		withdrawAmount, _ := valueobject.NewMoney(20000)
+		initialBalance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(20000, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -77,8 +108,8 @@ func TestWalletWithdraw(t *testing.T) {
 		if err == nil {
 			t.Error("expected error for insufficient funds, got nil")
 		}
-		if err.Error() != "insufficient funds" {
-			t.Errorf("expected 'insufficient funds', got %v", err)
+		if !errors.Is(err, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
 		}
 
 		// Balance should remain unchanged
@@ -90,8 +121,8 @@ func TestWalletWithdraw(t *testing.T) {
 	t.Run("should fail when withdrawing zero amount", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(10000)
-		withdrawAmount, _ := valueobject.NewMoney(0)
+		initialBalance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -104,6 +135,95 @@ func TestWalletWithdraw(t *testing.T) {
 		if err.Error() != "withdraw amount must be greater than zero" {
 			t.Errorf("expected 'withdraw amount must be greater than zero', got %v", err)
 		}
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "amount" {
+			t.Errorf("expected a ValidationError for amount, got %v", err)
+		}
+	})
+}
+
+func TestWalletDeposit(t *testing.T) {
+	t.Run("should deposit money successfully", func(t *testing.T) {
+		// Arrange
+		userID := valueobject.NewUserIDRandom()
+		initialBalance, _ := valueobject.NewMoney(50000, valueobject.DefaultCurrency())
+		depositAmount, _ := valueobject.NewMoney(20000, valueobject.DefaultCurrency())
+		wallet := NewWalletWithBalance(userID, initialBalance)
+
+		// Act
+		err := wallet.Deposit(depositAmount)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Balance().Amount() != 70000 {
+			t.Errorf("expected balance %d, got %d", 70000, wallet.Balance().Amount())
+		}
+	})
+
+	t.Run("should fail when depositing zero amount", func(t *testing.T) {
+		// Arrange
+		userID := valueobject.NewUserIDRandom()
+		depositAmount, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+		wallet, _ := NewWallet(userID, DefaultWalletName, valueobject.DefaultCurrency())
+
+		// Act
+		err := wallet.Deposit(depositAmount)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected error for zero amount, got nil")
+		}
+		if err.Error() != "deposit amount must be greater than zero" {
+			t.Errorf("expected 'deposit amount must be greater than zero', got %v", err)
+		}
+	})
+
+	t.Run("should fail when balance would overflow", func(t *testing.T) {
+		// Arrange
+		userID := valueobject.NewUserIDRandom()
+		initialBalance, _ := valueobject.NewMoney(math.MaxInt64, valueobject.DefaultCurrency())
+		depositAmount, _ := valueobject.NewMoney(1, valueobject.DefaultCurrency())
+		wallet := NewWalletWithBalance(userID, initialBalance)
+
+		// Act
+		err := wallet.Deposit(depositAmount)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected overflow error, got nil")
+		}
+
+		// Balance should remain unchanged
+		if wallet.Balance().Amount() != initialBalance.Amount() {
+			t.Errorf("balance should remain unchanged, expected %d, got %d", initialBalance.Amount(), wallet.Balance().Amount())
+		}
+	})
+}
+
+func TestWalletCurrencyMismatch(t *testing.T) {
+	t.Run("should refuse amounts in another currency", func(t *testing.T) {
+		// Arrange
+		eur, _ := valueobject.NewCurrency("EUR")
+		initialBalance, _ := valueobject.NewMoney(50000, valueobject.DefaultCurrency())
+		amount, _ := valueobject.NewMoney(100, eur)
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), initialBalance)
+
+		// Act
+		withdrawErr := wallet.Withdraw(amount)
+		depositErr := wallet.Deposit(amount)
+
+		// Assert
+		if !errors.Is(withdrawErr, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch from Withdraw, got %v", withdrawErr)
+		}
+		if !errors.Is(depositErr, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch from Deposit, got %v", depositErr)
+		}
+		if wallet.Balance() != valueobject.BalanceOf(initialBalance) {
+			t.Errorf("balance should remain unchanged, expected %s, got %s", initialBalance, wallet.Balance())
+		}
 	})
 }
 
@@ -111,8 +231,8 @@ func TestWalletCanWithdraw(t *testing.T) {
 	t.Run("should return true when sufficient balance", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(50000)
-		withdrawAmount, _ := valueobject.NewMoney(20000)
+		initialBalance, _ := valueobject.NewMoney(50000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(20000, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -127,8 +247,8 @@ func TestWalletCanWithdraw(t *testing.T) {
 	t.Run("should return false when insufficient balance", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(10000)
-		withdrawAmount, _ := valueobject.NewMoney(20000)
+		initialBalance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(20000, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -143,8 +263,8 @@ func TestWalletCanWithdraw(t *testing.T) {
 	t.Run("should return false when zero amount", func(t *testing.T) {
 		// Arrange
 		userID := valueobject.NewUserIDRandom()
-		initialBalance, _ := valueobject.NewMoney(10000)
-		withdrawAmount, _ := valueobject.NewMoney(0)
+		initialBalance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		withdrawAmount, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
 		wallet := NewWalletWithBalance(userID, initialBalance)
 
 		// Act
@@ -157,15 +277,211 @@ func TestWalletCanWithdraw(t *testing.T) {
 	})
 }
 
+func TestWalletHolds(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, usd)
+		return m
+	}
+
+	t.Run("should exclude held funds from the available balance", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(10000))
+
+		// Act
+		err := wallet.PlaceHold(money(3000))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Balance().Amount() != 10000 {
+			t.Errorf("expected balance 10000, got %d", wallet.Balance().Amount())
+		}
+		if wallet.HeldBalance().Amount() != 3000 {
+			t.Errorf("expected held balance 3000, got %d", wallet.HeldBalance().Amount())
+		}
+		if wallet.AvailableBalance().Amount() != 7000 {
+			t.Errorf("expected available balance 7000, got %d", wallet.AvailableBalance().Amount())
+		}
+	})
+
+	t.Run("should not withdraw held funds", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(10000))
+		_ = wallet.PlaceHold(money(3000))
+
+		// Act
+		err := wallet.Withdraw(money(8000))
+
+		// Assert
+		if !errors.Is(err, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+		if wallet.Balance().Amount() != 10000 {
+			t.Errorf("balance should remain unchanged, got %d", wallet.Balance().Amount())
+		}
+	})
+
+	t.Run("should not hold more than the available balance", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(10000))
+		_ = wallet.PlaceHold(money(6000))
+
+		// Act
+		err := wallet.PlaceHold(money(5000))
+
+		// Assert
+		if !errors.Is(err, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+		if wallet.HeldBalance().Amount() != 6000 {
+			t.Errorf("expected held balance 6000, got %d", wallet.HeldBalance().Amount())
+		}
+	})
+
+	t.Run("should take the captured amount and release the rest", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(10000))
+		_ = wallet.PlaceHold(money(3000))
+
+		// Act
+		err := wallet.CaptureHold(money(3000), money(1000))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Balance().Amount() != 9000 {
+			t.Errorf("expected balance 9000, got %d", wallet.Balance().Amount())
+		}
+		if wallet.HeldBalance().Amount() != 0 {
+			t.Errorf("expected held balance 0, got %d", wallet.HeldBalance().Amount())
+		}
+	})
+
+	t.Run("should make released funds available again", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(10000))
+		_ = wallet.PlaceHold(money(3000))
+
+		// Act
+		err := wallet.ReleaseHold(money(3000))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.AvailableBalance().Amount() != 10000 {
+			t.Errorf("expected available balance 10000, got %d", wallet.AvailableBalance().Amount())
+		}
+	})
+}
+
+func TestWalletOverdraft(t *testing.T) {
+	usd := valueobject.DefaultCurrency()
+	money := func(amount int64) valueobject.Money {
+		m, _ := valueobject.NewMoney(amount, usd)
+		return m
+	}
+
+	t.Run("should withdraw into the overdraft", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		_ = wallet.SetOverdraftLimit(money(5000))
+
+		// Act
+		err := wallet.Withdraw(money(4000))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if wallet.Balance().Amount() != -3000 {
+			t.Errorf("expected balance -3000, got %d", wallet.Balance().Amount())
+		}
+		if wallet.AvailableBalance().Amount() != 2000 {
+			t.Errorf("expected available balance 2000, got %d", wallet.AvailableBalance().Amount())
+		}
+	})
+
+	t.Run("should refuse to withdraw beyond the overdraft limit", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		_ = wallet.SetOverdraftLimit(money(5000))
+
+		// Act
+		err := wallet.Withdraw(money(6001))
+
+		// Assert
+		if !errors.Is(err, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+		if wallet.Balance().Amount() != 1000 {
+			t.Errorf("balance should remain unchanged, got %d", wallet.Balance().Amount())
+		}
+	})
+
+	t.Run("should count held funds against the overdraft", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		_ = wallet.SetOverdraftLimit(money(5000))
+		_ = wallet.PlaceHold(money(4000))
+
+		// Act
+		canWithdraw := wallet.CanWithdraw(money(2001))
+
+		// Assert
+		if canWithdraw {
+			t.Error("expected the withdrawal to exceed the available balance")
+		}
+		if !wallet.CanWithdraw(money(2000)) {
+			t.Error("expected the rest of the overdraft to be available")
+		}
+	})
+
+	t.Run("should refuse a limit below what the wallet owes", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+		_ = wallet.SetOverdraftLimit(money(5000))
+		_ = wallet.Withdraw(money(4000))
+
+		// Act
+		err := wallet.SetOverdraftLimit(money(2999))
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "overdraft_limit" {
+			t.Errorf("expected a ValidationError for overdraft_limit, got %v", err)
+		}
+		if wallet.OverdraftLimit().Amount() != 5000 {
+			t.Errorf("expected the limit to remain 5000, got %d", wallet.OverdraftLimit().Amount())
+		}
+	})
+
+	t.Run("should not go below zero without an overdraft", func(t *testing.T) {
+		// Arrange
+		wallet := NewWalletWithBalance(valueobject.NewUserIDRandom(), money(1000))
+
+		// Act
+		err := wallet.Withdraw(money(1001))
+
+		// Assert
+		if !errors.Is(err, domain.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+	})
+}
+
 func TestWalletID(t *testing.T) {
 	t.Run("should generate unique wallet IDs", func(t *testing.T) {
 		// Act
-		wallet1 := NewWallet(valueobject.NewUserIDRandom())
-		wallet2 := NewWallet(valueobject.NewUserIDRandom())
+		wallet1, _ := NewWallet(valueobject.NewUserIDRandom(), DefaultWalletName, valueobject.DefaultCurrency())
+		wallet2, _ := NewWallet(valueobject.NewUserIDRandom(), DefaultWalletName, valueobject.DefaultCurrency())
 
 		// Assert
 		if wallet1.ID().String() == wallet2.ID().String() {
 			t.Error("expected different wallet IDs")
 		}
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// WalletTier groups wallets that share default withdrawal limits.
+type WalletTier string
+
+const (
+	WalletTierStandard WalletTier = "STANDARD"
+	WalletTierPremium  WalletTier = "PREMIUM"
+)
+
+// ParseWalletTier converts a raw value, e.g. a path parameter, into a known
+// wallet tier.
+func ParseWalletTier(value string) (WalletTier, error) {
+	switch tier := WalletTier(value); tier {
+	case WalletTierStandard, WalletTierPremium:
+		return tier, nil
+	default:
+		return "", domain.NewValidationError("tier", fmt.Sprintf("unknown wallet tier %q", value))
+	}
+}
+
+// Names of the individual withdrawal limits, as reported in
+// domain.LimitExceededError.
+const (
+	LimitMinAmount      = "min_amount"
+	LimitPerTransaction = "per_transaction"
+	LimitDaily          = "daily"
+	LimitMonthly        = "monthly"
+)
+
+// WithdrawalLimits caps withdrawals, in minor units of the wallet's currency.
+// A nil limit does not apply. Daily and monthly limits cover the calendar day
+// and month in UTC.
+//
+// The same type holds a tier's limits and a wallet's overrides of them; in an
+// override, a nil limit keeps the tier's.
+type WithdrawalLimits struct {
+	MinAmount      *int64
+	PerTransaction *int64
+	Daily          *int64
+	Monthly        *int64
+}
+
+// Validate checks that the limits are positive and consistent with each
+// other.
+func (l WithdrawalLimits) Validate() error {
+	for _, limit := range []struct {
+		name  string
+		value *int64
+	}{
+		{LimitMinAmount, l.MinAmount},
+		{LimitPerTransaction, l.PerTransaction},
+		{LimitDaily, l.Daily},
+		{LimitMonthly, l.Monthly},
+	} {
+		if limit.value != nil && *limit.value <= 0 {
+			return domain.NewValidationError(limit.name, "limit must be greater than zero")
+		}
+	}
+
+	if l.MinAmount != nil && l.PerTransaction != nil && *l.MinAmount > *l.PerTransaction {
+		return domain.NewValidationError(LimitMinAmount, "minimum amount cannot exceed the per-transaction limit")
+	}
+	if l.Daily != nil && l.Monthly != nil && *l.Daily > *l.Monthly {
+		return domain.NewValidationError(LimitDaily, "daily limit cannot exceed the monthly limit")
+	}
+	return nil
+}
+
+// Override returns the limits with every limit set in override replacing the
+// corresponding one.
+func (l WithdrawalLimits) Override(override WithdrawalLimits) WithdrawalLimits {
+	if override.MinAmount != nil {
+		l.MinAmount = override.MinAmount
+	}
+	if override.PerTransaction != nil {
+		l.PerTransaction = override.PerTransaction
+	}
+	if override.Daily != nil {
+		l.Daily = override.Daily
+	}
+	if override.Monthly != nil {
+		l.Monthly = override.Monthly
+	}
+	return l
+}
+
+// IsZero reports whether no limit is set.
+func (l WithdrawalLimits) IsZero() bool {
+	return l.MinAmount == nil && l.PerTransaction == nil && l.Daily == nil && l.Monthly == nil
+}
+
+// Check tests a withdrawal of amount given what the wallet already withdrew
+// today and this month. It returns a *domain.LimitExceededError naming the
+// first limit the withdrawal breaks.
+func (l WithdrawalLimits) Check(amount valueobject.Money, withdrawnToday, withdrawnThisMonth int64) error {
+	value := amount.Amount()
+
+	if l.MinAmount != nil && value < *l.MinAmount {
+		return &domain.LimitExceededError{Limit: LimitMinAmount, Threshold: *l.MinAmount, Remaining: 0}
+	}
+	if l.PerTransaction != nil && value > *l.PerTransaction {
+		return &domain.LimitExceededError{Limit: LimitPerTransaction, Threshold: *l.PerTransaction, Remaining: *l.PerTransaction}
+	}
+	if l.Daily != nil && value > remaining(*l.Daily, withdrawnToday) {
+		return &domain.LimitExceededError{Limit: LimitDaily, Threshold: *l.Daily, Remaining: remaining(*l.Daily, withdrawnToday)}
+	}
+	if l.Monthly != nil && value > remaining(*l.Monthly, withdrawnThisMonth) {
+		return &domain.LimitExceededError{Limit: LimitMonthly, Threshold: *l.Monthly, Remaining: remaining(*l.Monthly, withdrawnThisMonth)}
+	}
+	return nil
+}
+
+func remaining(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// LimitWindows returns the start of the UTC day and month that now falls in,
+// over which the daily and monthly limits are counted.
+func LimitWindows(now time.Time) (dayStart, monthStart time.Time) {
+	now = now.UTC()
+	dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
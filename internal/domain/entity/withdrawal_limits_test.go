@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func limitOf(value int64) *int64 {
+	return &value
+}
+
+func TestWithdrawalLimitsCheck(t *testing.T) {
+	limits := WithdrawalLimits{
+		MinAmount:      limitOf(100),
+		PerTransaction: limitOf(5000),
+		Daily:          limitOf(10000),
+		Monthly:        limitOf(20000),
+	}
+
+	tests := []struct {
+		name          string
+		amount        int64
+		today         int64
+		thisMonth     int64
+		wantLimit     string
+		wantRemaining int64
+	}{
+		{name: "should allow an amount within every limit", amount: 5000, today: 5000, thisMonth: 15000},
+		{name: "should reject an amount below the minimum", amount: 99, wantLimit: LimitMinAmount, wantRemaining: 0},
+		{name: "should reject an amount above the per-transaction limit", amount: 5001, wantLimit: LimitPerTransaction, wantRemaining: 5000},
+		{name: "should reject an amount above what is left today", amount: 3000, today: 8000, thisMonth: 8000, wantLimit: LimitDaily, wantRemaining: 2000},
+		{name: "should reject an amount above what is left this month", amount: 3000, today: 0, thisMonth: 18000, wantLimit: LimitMonthly, wantRemaining: 2000},
+		{name: "should report nothing remaining once a limit is used up", amount: 100, today: 12000, thisMonth: 12000, wantLimit: LimitDaily, wantRemaining: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			amount, _ := valueobject.NewMoney(tt.amount, valueobject.DefaultCurrency())
+
+			// Act
+			err := limits.Check(amount, tt.today, tt.thisMonth)
+
+			// Assert
+			if tt.wantLimit == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var limitErr *domain.LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("expected a LimitExceededError, got %v", err)
+			}
+			if !errors.Is(err, domain.ErrLimitExceeded) {
+				t.Error("expected the error to match ErrLimitExceeded")
+			}
+			if limitErr.Limit != tt.wantLimit {
+				t.Errorf("expected limit %s, got %s", tt.wantLimit, limitErr.Limit)
+			}
+			if limitErr.Remaining != tt.wantRemaining {
+				t.Errorf("expected %d remaining, got %d", tt.wantRemaining, limitErr.Remaining)
+			}
+		})
+	}
+
+	t.Run("should allow any amount without limits", func(t *testing.T) {
+		// Arrange
+		amount, _ := valueobject.NewMoney(1000000000, valueobject.DefaultCurrency())
+
+		// Act
+		err := WithdrawalLimits{}.Check(amount, 1000000000, 1000000000)
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func TestWithdrawalLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    WithdrawalLimits
+		wantField string
+	}{
+		{name: "should accept consistent limits", limits: WithdrawalLimits{MinAmount: limitOf(100), PerTransaction: limitOf(500), Daily: limitOf(1000), Monthly: limitOf(5000)}},
+		{name: "should accept no limits", limits: WithdrawalLimits{}},
+		{name: "should reject a zero limit", limits: WithdrawalLimits{Daily: limitOf(0)}, wantField: LimitDaily},
+		{name: "should reject a minimum above the per-transaction limit", limits: WithdrawalLimits{MinAmount: limitOf(600), PerTransaction: limitOf(500)}, wantField: LimitMinAmount},
+		{name: "should reject a daily limit above the monthly limit", limits: WithdrawalLimits{Daily: limitOf(6000), Monthly: limitOf(5000)}, wantField: LimitDaily},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.limits.Validate()
+
+			// Assert
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("expected field %s, got %s", tt.wantField, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestWithdrawalLimitsOverride(t *testing.T) {
+	t.Run("should replace only the limits set in the override", func(t *testing.T) {
+		// Arrange
+		tier := WithdrawalLimits{PerTransaction: limitOf(500), Daily: limitOf(1000)}
+
+		// Act
+		effective := tier.Override(WithdrawalLimits{Daily: limitOf(2000), Monthly: limitOf(9000)})
+
+		// Assert
+		if *effective.PerTransaction != 500 {
+			t.Errorf("expected the tier's per-transaction limit, got %d", *effective.PerTransaction)
+		}
+		if *effective.Daily != 2000 || *effective.Monthly != 9000 {
+			t.Errorf("expected the overridden limits, got daily %d and monthly %d", *effective.Daily, *effective.Monthly)
+		}
+		if effective.MinAmount != nil {
+			t.Error("expected no minimum amount")
+		}
+	})
+}
+
+func TestLimitWindows(t *testing.T) {
+	t.Run("should start the windows at the UTC day and month", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2025, 3, 15, 1, 30, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+
+		// Act
+		dayStart, monthStart := LimitWindows(now)
+
+		// Assert
+		if want := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC); !dayStart.Equal(want) {
+			t.Errorf("expected day start %v, got %v", want, dayStart)
+		}
+		if want := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC); !monthStart.Equal(want) {
+			t.Errorf("expected month start %v, got %v", want, monthStart)
+		}
+	})
+}
+
+func TestParseWalletTier(t *testing.T) {
+	t.Run("should reject an unknown tier", func(t *testing.T) {
+		// Act
+		_, err := ParseWalletTier("GOLD")
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected a ValidationError, got %v", err)
+		}
+	})
+}
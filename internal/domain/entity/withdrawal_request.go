@@ -0,0 +1,318 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+type WithdrawalRequestStatus string
+
+const (
+	// WithdrawalRequestStatusPending holds the amount until the request is
+	// decided or expires.
+	WithdrawalRequestStatusPending  WithdrawalRequestStatus = "PENDING"
+	WithdrawalRequestStatusApproved WithdrawalRequestStatus = "APPROVED"
+	WithdrawalRequestStatusRejected WithdrawalRequestStatus = "REJECTED"
+	WithdrawalRequestStatusExpired  WithdrawalRequestStatus = "EXPIRED"
+)
+
+// ParseWithdrawalRequestStatus converts a raw value, e.g. a query parameter,
+// into a known withdrawal request status.
+func ParseWithdrawalRequestStatus(value string) (WithdrawalRequestStatus, error) {
+	switch status := WithdrawalRequestStatus(value); status {
+	case WithdrawalRequestStatusPending, WithdrawalRequestStatusApproved,
+		WithdrawalRequestStatusRejected, WithdrawalRequestStatusExpired:
+		return status, nil
+	default:
+		return "", domain.NewValidationError("status", fmt.Sprintf("unknown withdrawal request status %q", value))
+	}
+}
+
+// WithdrawalRequestAction names one step in the history of a withdrawal
+// request.
+type WithdrawalRequestAction string
+
+const (
+	WithdrawalRequestActionRequested WithdrawalRequestAction = "REQUESTED"
+	WithdrawalRequestActionApproved  WithdrawalRequestAction = "APPROVED"
+	WithdrawalRequestActionRejected  WithdrawalRequestAction = "REJECTED"
+	WithdrawalRequestActionExpired   WithdrawalRequestAction = "EXPIRED"
+)
+
+// WithdrawalRequestSystemActor is the actor of steps nobody took, such as
+// expiry.
+const WithdrawalRequestSystemActor = "system"
+
+// MaxWithdrawalDecisionReasonLength bounds the reason recorded with an
+// approval or rejection.
+const MaxWithdrawalDecisionReasonLength = 255
+
+// WithdrawalRequest is a withdrawal too large to execute straight away. Its
+// amount is held on the wallet while it waits for someone other than its
+// requester to approve it, which executes the withdrawal, or to reject it,
+// which releases the amount. A request nobody decides expires and releases
+// the amount too.
+type WithdrawalRequest struct {
+	id            valueobject.UserID
+	walletID      valueobject.UserID
+	amount        valueobject.Money
+	status        WithdrawalRequestStatus
+	requestedBy   string
+	decidedBy     string
+	transactionID *valueobject.UserID
+	expiresAt     time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewWithdrawalRequest creates a pending request by requestedBy that expires
+// after ttl, together with the first step of its history.
+func NewWithdrawalRequest(walletID valueobject.UserID, amount valueobject.Money, requestedBy string, now time.Time, ttl time.Duration) (*WithdrawalRequest, *WithdrawalRequestEvent, error) {
+	if amount.IsZero() {
+		return nil, nil, domain.NewValidationError("amount", "withdraw amount must be greater than zero")
+	}
+	if strings.TrimSpace(requestedBy) == "" {
+		return nil, nil, domain.NewValidationError("requested_by", "a withdrawal request needs a requester")
+	}
+	if ttl <= 0 {
+		return nil, nil, domain.NewValidationError("expires_at", "withdrawal request must expire in the future")
+	}
+
+	now = now.UTC()
+	request := &WithdrawalRequest{
+		id:          valueobject.NewUserIDRandom(),
+		walletID:    walletID,
+		amount:      amount,
+		status:      WithdrawalRequestStatusPending,
+		requestedBy: requestedBy,
+		expiresAt:   now.Add(ttl),
+		createdAt:   now,
+		updatedAt:   now,
+	}
+
+	return request, request.newEvent(WithdrawalRequestActionRequested, requestedBy, "", now), nil
+}
+
+func ReconstructWithdrawalRequest(
+	id valueobject.UserID,
+	walletID valueobject.UserID,
+	amount valueobject.Money,
+	status WithdrawalRequestStatus,
+	requestedBy string,
+	decidedBy string,
+	transactionID *valueobject.UserID,
+	expiresAt time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *WithdrawalRequest {
+	return &WithdrawalRequest{
+		id:            id,
+		walletID:      walletID,
+		amount:        amount,
+		status:        status,
+		requestedBy:   requestedBy,
+		decidedBy:     decidedBy,
+		transactionID: transactionID,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Approve records that approver let the request execute as the withdrawal
+// transactionID. The requester cannot approve their own request, and an
+// expired request can no longer be approved.
+func (r *WithdrawalRequest) Approve(approver, reason string, transactionID valueobject.UserID, now time.Time) (*WithdrawalRequestEvent, error) {
+	reason, err := decisionReason(reason, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.CheckApprover(approver, now); err != nil {
+		return nil, err
+	}
+
+	if err := r.transitionTo(WithdrawalRequestStatusApproved, now); err != nil {
+		return nil, err
+	}
+
+	r.decidedBy = approver
+	r.transactionID = &transactionID
+	return r.newEvent(WithdrawalRequestActionApproved, approver, reason, now), nil
+}
+
+// CheckApprover tells why approver cannot approve the request at now: it is
+// no longer pending, it has expired or approver requested it.
+func (r *WithdrawalRequest) CheckApprover(approver string, now time.Time) error {
+	if r.status != WithdrawalRequestStatusPending {
+		return fmt.Errorf("%w from %s to %s", domain.ErrInvalidStatusTransition, r.status, WithdrawalRequestStatusApproved)
+	}
+	if r.IsExpired(now) {
+		return domain.ErrWithdrawalRequestExpired
+	}
+	if approver == r.requestedBy {
+		return domain.ErrSelfApproval
+	}
+	return nil
+}
+
+// Reject records that rejecter refused the request; its amount is released.
+// A reason is required.
+func (r *WithdrawalRequest) Reject(rejecter, reason string, now time.Time) (*WithdrawalRequestEvent, error) {
+	reason, err := decisionReason(reason, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.transitionTo(WithdrawalRequestStatusRejected, now); err != nil {
+		return nil, err
+	}
+
+	r.decidedBy = rejecter
+	return r.newEvent(WithdrawalRequestActionRejected, rejecter, reason, now), nil
+}
+
+// Expire releases a request nobody decided before its expiry.
+func (r *WithdrawalRequest) Expire(now time.Time) (*WithdrawalRequestEvent, error) {
+	if r.status == WithdrawalRequestStatusPending && !r.IsExpired(now) {
+		return nil, fmt.Errorf("%w: withdrawal request %s has not expired yet", domain.ErrInvalidStatusTransition, r.id.String())
+	}
+
+	if err := r.transitionTo(WithdrawalRequestStatusExpired, now); err != nil {
+		return nil, err
+	}
+
+	return r.newEvent(WithdrawalRequestActionExpired, WithdrawalRequestSystemActor, "", now), nil
+}
+
+// transitionTo enforces the lifecycle PENDING -> APPROVED | REJECTED |
+// EXPIRED. Every other status is final.
+func (r *WithdrawalRequest) transitionTo(status WithdrawalRequestStatus, now time.Time) error {
+	if r.status != WithdrawalRequestStatusPending {
+		return fmt.Errorf("%w from %s to %s", domain.ErrInvalidStatusTransition, r.status, status)
+	}
+
+	r.status = status
+	r.updatedAt = now.UTC()
+	return nil
+}
+
+func (r *WithdrawalRequest) newEvent(action WithdrawalRequestAction, actor, reason string, now time.Time) *WithdrawalRequestEvent {
+	return &WithdrawalRequestEvent{
+		id:         valueobject.NewUserIDRandom(),
+		requestID:  r.id,
+		action:     action,
+		actor:      actor,
+		reason:     reason,
+		occurredAt: now.UTC(),
+	}
+}
+
+func decisionReason(reason string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if required && reason == "" {
+		return "", domain.NewValidationError("reason", "a reason is required to reject a withdrawal request")
+	}
+	if len(reason) > MaxWithdrawalDecisionReasonLength {
+		return "", domain.NewValidationError("reason", fmt.Sprintf("reason must be at most %d characters", MaxWithdrawalDecisionReasonLength))
+	}
+	return reason, nil
+}
+
+func (r *WithdrawalRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.expiresAt)
+}
+
+func (r *WithdrawalRequest) ID() valueobject.UserID {
+	return r.id
+}
+
+func (r *WithdrawalRequest) WalletID() valueobject.UserID {
+	return r.walletID
+}
+
+func (r *WithdrawalRequest) Amount() valueobject.Money {
+	return r.amount
+}
+
+func (r *WithdrawalRequest) Status() WithdrawalRequestStatus {
+	return r.status
+}
+
+// RequestedBy is the subject of the caller who asked for the withdrawal.
+func (r *WithdrawalRequest) RequestedBy() string {
+	return r.requestedBy
+}
+
+// DecidedBy is the subject of the caller who approved or rejected the
+// request, or empty.
+func (r *WithdrawalRequest) DecidedBy() string {
+	return r.decidedBy
+}
+
+// TransactionID returns the withdrawal an approved request executed as, or
+// nil.
+func (r *WithdrawalRequest) TransactionID() *valueobject.UserID {
+	return r.transactionID
+}
+
+func (r *WithdrawalRequest) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+func (r *WithdrawalRequest) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *WithdrawalRequest) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// WithdrawalRequestEvent records one step in the history of a withdrawal
+// request, who took it and why. Events are only ever appended.
+type WithdrawalRequestEvent struct {
+	id         valueobject.UserID
+	requestID  valueobject.UserID
+	action     WithdrawalRequestAction
+	actor      string
+	reason     string
+	occurredAt time.Time
+}
+
+func ReconstructWithdrawalRequestEvent(id, requestID valueobject.UserID, action WithdrawalRequestAction, actor, reason string, occurredAt time.Time) *WithdrawalRequestEvent {
+	return &WithdrawalRequestEvent{
+		id:         id,
+		requestID:  requestID,
+		action:     action,
+		actor:      actor,
+		reason:     reason,
+		occurredAt: occurredAt,
+	}
+}
+
+func (e *WithdrawalRequestEvent) ID() valueobject.UserID {
+	return e.id
+}
+
+func (e *WithdrawalRequestEvent) RequestID() valueobject.UserID {
+	return e.requestID
+}
+
+func (e *WithdrawalRequestEvent) Action() WithdrawalRequestAction {
+	return e.action
+}
+
+func (e *WithdrawalRequestEvent) Actor() string {
+	return e.actor
+}
+
+func (e *WithdrawalRequestEvent) Reason() string {
+	return e.reason
+}
+
+func (e *WithdrawalRequestEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func newTestWithdrawalRequest(t *testing.T, now time.Time) *WithdrawalRequest {
+	t.Helper()
+
+	amount, _ := valueobject.NewMoney(1500000, valueobject.DefaultCurrency())
+	request, _, err := NewWithdrawalRequest(valueobject.NewUserIDRandom(), amount, "customer-1", now, time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return request
+}
+
+func TestNewWithdrawalRequest(t *testing.T) {
+	t.Run("should create a pending request with its first history step", func(t *testing.T) {
+		// Arrange
+		amount, _ := valueobject.NewMoney(1500000, valueobject.DefaultCurrency())
+		now := time.Now()
+
+		// Act
+		request, event, err := NewWithdrawalRequest(valueobject.NewUserIDRandom(), amount, "customer-1", now, time.Hour)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if request.Status() != WithdrawalRequestStatusPending {
+			t.Errorf("expected pending status, got %s", request.Status())
+		}
+		if !request.ExpiresAt().Equal(now.Add(time.Hour)) {
+			t.Errorf("expected expiry %v, got %v", now.Add(time.Hour), request.ExpiresAt())
+		}
+		if event.Action() != WithdrawalRequestActionRequested || event.Actor() != "customer-1" || !event.RequestID().Equals(request.ID()) {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	t.Run("should reject a zero amount", func(t *testing.T) {
+		// Arrange
+		zero, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+
+		// Act
+		_, _, err := NewWithdrawalRequest(valueobject.NewUserIDRandom(), zero, "customer-1", time.Now(), time.Hour)
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "amount" {
+			t.Errorf("expected a validation error on amount, got %v", err)
+		}
+	})
+}
+
+func TestWithdrawalRequestApprove(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should record the approver and the executed withdrawal", func(t *testing.T) {
+		// Arrange
+		request := newTestWithdrawalRequest(t, now)
+		transactionID := valueobject.NewUserIDRandom()
+
+		// Act
+		event, err := request.Approve("ops-1", " verified by phone ", transactionID, now.Add(time.Minute))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if request.Status() != WithdrawalRequestStatusApproved || request.DecidedBy() != "ops-1" {
+			t.Errorf("expected approval by ops-1, got %s by %q", request.Status(), request.DecidedBy())
+		}
+		if request.TransactionID() == nil || !request.TransactionID().Equals(transactionID) {
+			t.Errorf("expected transaction %v, got %v", transactionID, request.TransactionID())
+		}
+		if event.Action() != WithdrawalRequestActionApproved || event.Reason() != "verified by phone" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		approver string
+		at       time.Time
+		expected error
+	}{
+		{name: "should refuse approval by the requester", approver: "customer-1", at: now, expected: domain.ErrSelfApproval},
+		{name: "should refuse approval once expired", approver: "ops-1", at: now.Add(time.Hour), expected: domain.ErrWithdrawalRequestExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := newTestWithdrawalRequest(t, now)
+
+			// Act
+			_, err := request.Approve(tt.approver, "", valueobject.NewUserIDRandom(), tt.at)
+
+			// Assert
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			if request.Status() != WithdrawalRequestStatusPending {
+				t.Errorf("expected the request to stay pending, got %s", request.Status())
+			}
+		})
+	}
+
+	t.Run("should refuse approval of a decided request", func(t *testing.T) {
+		// Arrange
+		request := newTestWithdrawalRequest(t, now)
+		if _, err := request.Reject("ops-1", "not verified", now); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// Act
+		_, err := request.Approve("ops-2", "", valueobject.NewUserIDRandom(), now)
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+	})
+}
+
+func TestWithdrawalRequestReject(t *testing.T) {
+	t.Run("should require a reason", func(t *testing.T) {
+		// Arrange
+		request := newTestWithdrawalRequest(t, time.Now())
+
+		// Act
+		_, err := request.Reject("ops-1", " ", time.Now())
+
+		// Assert
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "reason" {
+			t.Errorf("expected a validation error on reason, got %v", err)
+		}
+	})
+}
+
+func TestWithdrawalRequestExpire(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should expire a request past its expiry as the system", func(t *testing.T) {
+		// Arrange
+		request := newTestWithdrawalRequest(t, now)
+
+		// Act
+		event, err := request.Expire(now.Add(time.Hour))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if request.Status() != WithdrawalRequestStatusExpired || event.Actor() != WithdrawalRequestSystemActor {
+			t.Errorf("expected expiry by the system, got %s by %q", request.Status(), event.Actor())
+		}
+	})
+
+	t.Run("should not expire a request before its expiry", func(t *testing.T) {
+		// Arrange
+		request := newTestWithdrawalRequest(t, now)
+
+		// Act
+		_, err := request.Expire(now.Add(time.Minute))
+
+		// Assert
+		if !errors.Is(err, domain.ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Package domain holds the errors shared by the domain layer. Callers match
+// them with errors.Is and errors.As; their messages are not part of the API.
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrWalletNotFound      = errors.New("wallet not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrQuoteNotFound       = errors.New("exchange quote not found")
+	ErrHoldNotFound        = errors.New("hold not found")
+	ErrAPIKeyNotFound      = errors.New("API key not found")
+
+	ErrWithdrawalRequestNotFound = errors.New("withdrawal request not found")
+
+	ErrWalletAlreadyExists = errors.New("wallet already exists")
+	ErrUserAlreadyExists   = errors.New("user already exists")
+
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrNegativeAmount    = errors.New("money amount cannot be negative")
+	ErrAmountOverflow    = errors.New("money amount overflow")
+	ErrInvalidUserID     = errors.New("invalid user ID format")
+	ErrSameWallet        = errors.New("cannot transfer to the same wallet")
+	ErrWalletFrozen      = errors.New("wallet is frozen")
+
+	ErrWalletDebitBlocked = errors.New("wallet is blocked for debits")
+	ErrWalletClosed       = errors.New("wallet is closed")
+	ErrWalletNotEmpty     = errors.New("wallet still holds funds")
+
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrRateUnavailable     = errors.New("exchange rate unavailable")
+
+	ErrQuoteExpired        = errors.New("exchange quote expired")
+	ErrQuoteAlreadyUsed    = errors.New("exchange quote already used")
+	ErrWalletOwnerMismatch = errors.New("wallets belong to different users")
+	ErrHoldExpired         = errors.New("hold expired")
+
+	ErrWithdrawalRequestExpired = errors.New("withdrawal request expired")
+
+	ErrTransactionNotReversible   = errors.New("transaction cannot be reversed")
+	ErrTransactionAlreadyReversed = errors.New("transaction already reversed")
+
+	ErrLimitExceeded    = errors.New("withdrawal limit exceeded")
+	ErrApprovalRequired = errors.New("amount requires approval")
+
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("not allowed to act on this resource")
+	ErrAPIKeyRevoked   = errors.New("API key revoked")
+	ErrSelfApproval    = errors.New("requester cannot approve their own request")
+
+	ErrInvalidStatusTransition       = errors.New("invalid transaction status transition")
+	ErrInvalidWalletStatusTransition = errors.New("invalid wallet status transition")
+	ErrIdempotencyKeyReused          = errors.New("idempotency key reused with a different request")
+)
+
+// ValidationError reports a request value that breaks a domain rule.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func NewValidationError(field, message string) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// LimitExceededError reports the withdrawal limit an amount breaks. It matches
+// ErrLimitExceeded with errors.Is.
+type LimitExceededError struct {
+	Limit     string // min_amount, per_transaction, daily or monthly
+	Threshold int64  // the configured limit, in minor units
+	Remaining int64  // the largest amount the limit still allows; 0 for min_amount
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit is %d, %d remaining", ErrLimitExceeded, e.Limit, e.Threshold, e.Remaining)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"time"
+
+	"bank/internal/domain/entity"
+)
+
+// AuditCursor identifies the last entry of a page of the audit trail. Entries
+// are ordered newest first by (created_at, id), like transactions.
+type AuditCursor TransactionCursor
+
+// AuditFilter narrows an audit trail listing. Zero values mean "no filter" for
+// every field except Limit.
+type AuditFilter struct {
+	Actor    string
+	Actions  []entity.AuditAction
+	TargetID string
+	From     *time.Time
+	To       *time.Time
+	After    *AuditCursor
+	Limit    int
+}
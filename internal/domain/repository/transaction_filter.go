@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// TransactionCursor identifies the last transaction of a page. Transactions
+// are ordered newest first by (created_at, id), so the next page starts
+// strictly after this position.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        valueobject.UserID
+}
+
+// TransactionFilter narrows a transaction listing. Zero values mean "no
+// filter" for every field except Limit.
+type TransactionFilter struct {
+	Types     []entity.TransactionType
+	Statuses  []entity.TransactionStatus
+	MinAmount *int64
+	MaxAmount *int64
+	From      *time.Time
+	To        *time.Time
+	After     *TransactionCursor
+	Limit     int
+}
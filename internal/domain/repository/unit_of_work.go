@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+)
+
+// Repositories groups the repositories bound to a single unit of work. Every
+// call made through them takes part in the same transaction.
+type Repositories struct {
+	Users        UserRepository
+	APIKeys      APIKeyRepository
+	Wallets      WalletRepository
+	Transactions TransactionRepository
+	Idempotency  IdempotencyRepository
+	Ledger       LedgerRepository
+	Quotes       ExchangeQuoteRepository
+	Holds        HoldRepository
+	Limits       LimitRepository
+	Audit        AuditRepository
+
+	WithdrawalRequests WithdrawalRequestRepository
+}
+
+// UnitOfWork runs a block of repository calls atomically.
+type UnitOfWork interface {
+	// RunInTx runs fn inside a new transaction. The transaction commits when
+	// fn returns nil and rolls back when it returns an error or panics; fn's
+	// error is returned unchanged so callers can match it with errors.Is.
+	RunInTx(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// WalletFilter narrows a wallet search. Zero values mean "no filter" for every
+// field except Limit. Wallets are ordered by ID, so the next page starts
+// strictly after the ID in After.
+type WalletFilter struct {
+	UserID   *valueobject.UserID
+	Statuses []entity.WalletStatus
+	Tiers    []entity.WalletTier
+	Currency *valueobject.Currency
+	// Name matches wallet names containing it.
+	Name  string
+	After *valueobject.UserID
+	Limit int
+}
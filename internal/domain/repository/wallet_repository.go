@@ -2,18 +2,184 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"time"
 
 	"bank/internal/domain/entity"
 	"bank/internal/domain/valueobject"
 )
 
+// The repositories below are used in two ways: the instances handed out by a
+// UnitOfWork run every call in its transaction, while standalone instances run
+// each call on its own. Locking reads such as GetWalletForUpdate only hold
+// their locks when used inside a UnitOfWork.
+
 type WalletRepository interface {
-	GetWallet(ctx context.Context, userID valueobject.UserID) (*entity.Wallet, error)
-	GetWalletForUpdate(ctx context.Context, tx *sql.Tx, userID valueobject.UserID) (*entity.Wallet, error)
-	UpdateWalletBalance(ctx context.Context, tx *sql.Tx, walletID valueobject.UserID, newBalance int64) error
+	// GetWallet and GetWalletForUpdate resolve a reference by user to the
+	// user's default wallet.
+	GetWallet(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error)
+	GetWalletForUpdate(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error)
+	// ListWallets returns a user's wallets, the default wallet first and the
+	// others by name.
+	ListWallets(ctx context.Context, userID valueobject.UserID) ([]*entity.Wallet, error)
+	// SearchWallets returns the wallets of every user that match the filter,
+	// ordered by wallet ID.
+	SearchWallets(ctx context.Context, filter WalletFilter) ([]*entity.Wallet, error)
+	// CreateWallet fails with domain.ErrWalletAlreadyExists when the user
+	// already owns a wallet of the same name, or a default wallet when the new
+	// one is marked default, and with domain.ErrUserNotFound when the user
+	// does not exist.
+	CreateWallet(ctx context.Context, wallet *entity.Wallet) error
+	UpdateWalletBalance(ctx context.Context, walletID valueobject.UserID, newBalance int64) error
+	// UpdateWalletHeldBalance stores the total of the wallet's active holds.
+	// The held balance may never exceed the balance plus the overdraft limit,
+	// so when both shrink the held balance must be updated first.
+	UpdateWalletHeldBalance(ctx context.Context, walletID valueobject.UserID, newHeldBalance int64) error
+	UpdateWalletTier(ctx context.Context, walletID valueobject.UserID, tier entity.WalletTier) error
+	UpdateWalletOverdraftLimit(ctx context.Context, walletID valueobject.UserID, overdraftLimit int64) error
+	UpdateWalletStatus(ctx context.Context, walletID valueobject.UserID, status entity.WalletStatus) error
+	InsertWalletStatusChange(ctx context.Context, change *entity.WalletStatusChange) error
+	// ListWalletStatusChanges returns the status history of a wallet, oldest
+	// change first.
+	ListWalletStatusChanges(ctx context.Context, walletID valueobject.UserID) ([]*entity.WalletStatusChange, error)
+}
+
+type UserRepository interface {
+	// CreateUser fails with domain.ErrUserAlreadyExists when another user has
+	// the same external reference.
+	CreateUser(ctx context.Context, user *entity.User) error
+	// GetUser fails with domain.ErrUserNotFound when no user has the ID.
+	GetUser(ctx context.Context, userID valueobject.UserID) (*entity.User, error)
+}
+
+type APIKeyRepository interface {
+	// CreateAPIKey fails with domain.ErrUserNotFound when the owning user
+	// does not exist.
+	CreateAPIKey(ctx context.Context, key *entity.APIKey) error
+	// GetAPIKey and GetAPIKeyForUpdate fail with domain.ErrAPIKeyNotFound
+	// when no key has the ID.
+	GetAPIKey(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error)
+	GetAPIKeyForUpdate(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error)
+	// UpdateAPIKey stores the revocation of a key.
+	UpdateAPIKey(ctx context.Context, key *entity.APIKey) error
+	// ListAPIKeys returns the keys of a user, revoked ones included, oldest
+	// first.
+	ListAPIKeys(ctx context.Context, userID valueobject.UserID) ([]*entity.APIKey, error)
 }
 
 type TransactionRepository interface {
-	InsertTransaction(ctx context.Context, tx *sql.Tx, transaction *entity.Transaction) error
+	InsertTransaction(ctx context.Context, transaction *entity.Transaction) error
+	ListTransactions(ctx context.Context, walletID valueobject.UserID, filter TransactionFilter) ([]*entity.Transaction, error)
+	// GetTransaction and GetTransactionForUpdate fail with
+	// domain.ErrTransactionNotFound when no transaction has the ID. The
+	// latter locks the transaction for the rest of the unit of work.
+	GetTransaction(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error)
+	GetTransactionForUpdate(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error)
+	// GetReversal returns the reversal of a transaction, or nil when it has
+	// not been reversed.
+	GetReversal(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error)
+	// SumWithdrawals totals the completed withdrawals and outgoing transfers
+	// of a wallet created in [from, to).
+	SumWithdrawals(ctx context.Context, walletID valueobject.UserID, from, to time.Time) (int64, error)
+}
+
+type IdempotencyRepository interface {
+	// GetIdempotencyRecordForUpdate serialises concurrent requests sharing the
+	// same key for the rest of the unit of work and returns the stored record,
+	// or nil when the key has not been used yet.
+	GetIdempotencyRecordForUpdate(ctx context.Context, key string) (*entity.IdempotencyRecord, error)
+	SaveIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error
+	DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error)
+}
+
+type ExchangeQuoteRepository interface {
+	InsertQuote(ctx context.Context, quote *entity.ExchangeQuote) error
+	// GetQuoteForUpdate serialises concurrent redemptions of the same quote
+	// for the rest of the unit of work. It fails with domain.ErrQuoteNotFound
+	// when no quote has the ID.
+	GetQuoteForUpdate(ctx context.Context, quoteID valueobject.UserID) (*entity.ExchangeQuote, error)
+	// MarkQuoteUsed stores the redemption time of a redeemed quote.
+	MarkQuoteUsed(ctx context.Context, quote *entity.ExchangeQuote) error
+}
+
+type HoldRepository interface {
+	InsertHold(ctx context.Context, hold *entity.Hold) error
+	// GetHold and GetHoldForUpdate fail with domain.ErrHoldNotFound when no
+	// hold has the ID.
+	GetHold(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error)
+	GetHoldForUpdate(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error)
+	UpdateHold(ctx context.Context, hold *entity.Hold) error
+	// ListExpiredHolds returns up to limit active holds whose expiry is at or
+	// before now, oldest expiry first.
+	ListExpiredHolds(ctx context.Context, now time.Time, limit int) ([]*entity.Hold, error)
+}
+
+// LimitRepository stores withdrawal limits. Limits are in minor units of the
+// currency they are configured for.
+type LimitRepository interface {
+	// GetTierLimits returns the limits of a tier in a currency; they are
+	// empty when none are configured.
+	GetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency) (entity.WithdrawalLimits, error)
+	SetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency, limits entity.WithdrawalLimits) error
+	// ListTierLimits returns the limits of every configured tier, keyed by
+	// tier and then by currency code.
+	ListTierLimits(ctx context.Context) (map[entity.WalletTier]map[string]entity.WithdrawalLimits, error)
+	// GetWalletLimits returns the overrides of a wallet; they are empty when
+	// the wallet has none.
+	GetWalletLimits(ctx context.Context, walletID valueobject.UserID) (entity.WithdrawalLimits, error)
+	// SetWalletLimits replaces the overrides of a wallet. Empty limits
+	// remove them.
+	SetWalletLimits(ctx context.Context, walletID valueobject.UserID, limits entity.WithdrawalLimits) error
+}
+
+// WithdrawalRequestRepository stores the withdrawals waiting for approval and
+// their history.
+type WithdrawalRequestRepository interface {
+	InsertWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error
+	// GetWithdrawalRequest and GetWithdrawalRequestForUpdate fail with
+	// domain.ErrWithdrawalRequestNotFound when no request has the ID.
+	GetWithdrawalRequest(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error)
+	GetWithdrawalRequestForUpdate(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error)
+	// UpdateWithdrawalRequest stores the decision on, or expiry of, a request.
+	UpdateWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error
+	// ListWithdrawalRequests returns the requests matching the filter, newest
+	// first, starting after the filter's cursor.
+	ListWithdrawalRequests(ctx context.Context, filter WithdrawalRequestFilter) ([]*entity.WithdrawalRequest, error)
+	// ListExpiredWithdrawalRequests returns up to limit pending requests whose
+	// expiry is at or before now, oldest expiry first.
+	ListExpiredWithdrawalRequests(ctx context.Context, now time.Time, limit int) ([]*entity.WithdrawalRequest, error)
+	InsertWithdrawalRequestEvent(ctx context.Context, event *entity.WithdrawalRequestEvent) error
+	// ListWithdrawalRequestEvents returns the history of a request, oldest
+	// step first.
+	ListWithdrawalRequestEvents(ctx context.Context, requestID valueobject.UserID) ([]*entity.WithdrawalRequestEvent, error)
+}
+
+// AuditRepository stores the audit trail of the admin API. Entries are only
+// ever appended.
+type AuditRepository interface {
+	InsertAuditEntry(ctx context.Context, entry *entity.AuditEntry) error
+	// ListAuditEntries returns the entries matching the filter, newest first,
+	// starting after the filter's cursor.
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]*entity.AuditEntry, error)
+}
+
+type LedgerRepository interface {
+	// GetWalletAccount returns the ledger account of a wallet, or nil when the
+	// wallet has not been opened in the ledger yet.
+	GetWalletAccount(ctx context.Context, walletID valueobject.UserID) (*entity.LedgerAccount, error)
+	GetSystemAccount(ctx context.Context, code string) (*entity.LedgerAccount, error)
+	CreateLedgerAccount(ctx context.Context, account *entity.LedgerAccount) error
+	InsertJournalEntry(ctx context.Context, entry *entity.JournalEntry) error
+	// GetAccountTotals sums the debits and credits posted to an account.
+	GetAccountTotals(ctx context.Context, accountID valueobject.UserID) (debits int64, credits int64, err error)
+	// GetLedgerTotals sums the debits and credits of every posting, per
+	// currency, ordered by currency code.
+	GetLedgerTotals(ctx context.Context) ([]LedgerTotals, error)
+}
+
+// LedgerTotals are the summed debits and credits of the postings in one
+// currency.
+type LedgerTotals struct {
+	Currency string
+	Debits   int64
+	Credits  int64
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// WithdrawalRequestCursor identifies the last request of a page. Requests are
+// ordered newest first by (created_at, id), like transactions.
+type WithdrawalRequestCursor TransactionCursor
+
+// WithdrawalRequestFilter narrows a withdrawal request listing. Zero values
+// mean "no filter" for every field except Limit.
+type WithdrawalRequestFilter struct {
+	WalletID    *valueobject.UserID
+	Statuses    []entity.WithdrawalRequestStatus
+	RequestedBy string
+	After       *WithdrawalRequestCursor
+	Limit       int
+}
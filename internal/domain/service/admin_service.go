@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+// AdminService gives back-office staff read access across all wallets and to
+// the audit trail of the admin API and to withdrawals waiting for approval.
+type AdminService interface {
+	SearchWallets(ctx context.Context, query dto.WalletSearchQuery) (*dto.WalletSearchResponse, error)
+	GetTransaction(ctx context.Context, transactionID valueobject.UserID) (*dto.TransactionResponse, error)
+	ListAuditEntries(ctx context.Context, query dto.AuditLogQuery) (*dto.AuditLogResponse, error)
+	// GetWithdrawalRequest returns a request with its history.
+	GetWithdrawalRequest(ctx context.Context, requestID valueobject.UserID) (*dto.WithdrawalRequestResponse, error)
+	ListWithdrawalRequests(ctx context.Context, query dto.WithdrawalRequestQuery) (*dto.WithdrawalRequestListResponse, error)
+}
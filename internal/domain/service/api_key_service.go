@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+// APIKeyService lets administrators issue and revoke the API keys of
+// server-to-server clients.
+type APIKeyService interface {
+	// IssueAPIKey creates a key acting for userID with scopes. The response
+	// carries the key's secret, which cannot be retrieved again.
+	IssueAPIKey(ctx context.Context, userID valueobject.UserID, name string, scopes []string) (*dto.IssuedAPIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, keyID valueobject.UserID) (*dto.APIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID valueobject.UserID) (*dto.APIKeyListResponse, error)
+}
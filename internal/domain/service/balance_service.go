@@ -8,5 +8,5 @@ import (
 )
 
 type BalanceService interface {
-	GetBalance(ctx context.Context, userID valueobject.UserID) (*dto.BalanceResponse, error)
+	GetBalance(ctx context.Context, ref valueobject.WalletRef) (*dto.BalanceResponse, error)
 }
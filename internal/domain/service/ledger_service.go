@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type LedgerService interface {
+	VerifyWalletBalance(ctx context.Context, ref valueobject.WalletRef) (*dto.LedgerVerificationResponse, error)
+	GetTrialBalance(ctx context.Context) (*dto.TrialBalanceResponse, error)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// LimitService lets administrators view and change withdrawal limits.
+type LimitService interface {
+	ListTierLimits(ctx context.Context) (*dto.TierLimitsListResponse, error)
+	SetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency, limits entity.WithdrawalLimits) (*dto.TierLimitsResponse, error)
+	GetWalletLimits(ctx context.Context, walletID valueobject.UserID) (*dto.WalletLimitsResponse, error)
+	// SetWalletLimits moves a wallet to tier and replaces its overrides; a nil
+	// argument leaves that setting unchanged.
+	SetWalletLimits(ctx context.Context, walletID valueobject.UserID, tier *entity.WalletTier, overrides *entity.WithdrawalLimits) (*dto.WalletLimitsResponse, error)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/domain/valueobject"
+)
+
+// RateProvider supplies the mid-market rates exchange quotes are priced at.
+type RateProvider interface {
+	// GetRate returns the rate from base to quote, or domain.ErrRateUnavailable
+	// when the provider has no rate for the pair.
+	GetRate(ctx context.Context, base, quote valueobject.Currency) (valueobject.ExchangeRate, error)
+}
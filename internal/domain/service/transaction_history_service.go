@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type TransactionHistoryService interface {
+	ListTransactions(ctx context.Context, ref valueobject.WalletRef, query dto.TransactionHistoryQuery) (*dto.TransactionHistoryResponse, error)
+}
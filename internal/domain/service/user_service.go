@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type UserService interface {
+	// RegisterUser creates a user together with their default wallet in
+	// currency.
+	RegisterUser(ctx context.Context, name, externalRef string, currency valueobject.Currency) (*dto.UserResponse, error)
+	GetUser(ctx context.Context, userID valueobject.UserID) (*dto.UserResponse, error)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type WalletService interface {
+	CreateWallet(ctx context.Context, userID valueobject.UserID, name string, currency valueobject.Currency) (*dto.WalletResponse, error)
+	GetWallet(ctx context.Context, walletID valueobject.UserID) (*dto.WalletResponse, error)
+	ListWallets(ctx context.Context, userID valueobject.UserID) (*dto.WalletListResponse, error)
+	SetOverdraftLimit(ctx context.Context, walletID valueobject.UserID, limit valueobject.Money) (*dto.WalletResponse, error)
+	ChangeStatus(ctx context.Context, walletID valueobject.UserID, status entity.WalletStatus, reason string) (*dto.WalletResponse, error)
+	ListStatusHistory(ctx context.Context, walletID valueobject.UserID) (*dto.WalletStatusHistoryResponse, error)
+}
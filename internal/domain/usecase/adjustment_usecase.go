@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type AdjustmentUseCase interface {
+	// Adjust credits or debits a wallet by hand, recording the reason code,
+	// an optional note and who made the adjustment.
+	Adjust(ctx context.Context, walletID valueobject.UserID, direction entity.AdjustmentDirection, amount valueobject.Money, reason entity.AdjustmentReason, note string) (*dto.AdjustmentResponse, error)
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type DepositUseCase interface {
+	Deposit(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money) (*dto.DepositResponse, error)
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type ExchangeUseCase interface {
+	// Quote prices the exchange of amount from one of a user's wallets into
+	// another holding a different currency, and locks the rate for a while.
+	Quote(ctx context.Context, fromWalletID, toWalletID valueobject.UserID, amount valueobject.Money) (*dto.ExchangeQuoteResponse, error)
+	// Exchange redeems a quote, moving the quoted amounts between the wallets.
+	Exchange(ctx context.Context, quoteID valueobject.UserID) (*dto.ExchangeResponse, error)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type HoldUseCase interface {
+	// PlaceHold reserves amount of a wallet's available balance until ttl
+	// has passed; a zero ttl applies the configured default.
+	PlaceHold(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money, ttl time.Duration) (*dto.HoldResponse, error)
+	GetHold(ctx context.Context, holdID valueobject.UserID) (*dto.HoldResponse, error)
+	// CaptureHold withdraws amount, or the whole hold when amount is nil,
+	// and releases the rest of the hold.
+	CaptureHold(ctx context.Context, holdID valueobject.UserID, amount *valueobject.Money) (*dto.HoldResponse, error)
+	ReleaseHold(ctx context.Context, holdID valueobject.UserID) (*dto.HoldResponse, error)
+	// ReleaseExpiredHolds expires the active holds whose expiry has passed
+	// by now and returns how many were released.
+	ReleaseExpiredHolds(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type ReversalUseCase interface {
+	// Reverse returns amount of a completed withdrawal, or all of it when
+	// amount is nil, to its wallet. A withdrawal can be reversed once.
+	Reverse(ctx context.Context, transactionID valueobject.UserID, amount *valueobject.Money, reason string) (*dto.ReversalResponse, error)
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+type TransferUseCase interface {
+	Transfer(ctx context.Context, from, to valueobject.WalletRef, amount valueobject.Money) (*dto.TransferResponse, error)
+}
@@ -8,5 +8,8 @@ import (
 )
 
 type WithdrawUseCase interface {
-	Withdraw(ctx context.Context, userID valueobject.UserID, amount valueobject.Money) (*dto.WithdrawResponse, error)
+	// Withdraw executes a withdrawal straight away, unless the amount needs
+	// approval: then the amount is held and the response names the
+	// withdrawal request waiting for it.
+	Withdraw(ctx context.Context, ref valueobject.WalletRef, amount valueobject.Money) (*dto.WithdrawResponse, error)
 }
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/valueobject"
+)
+
+// WithdrawalApprovalUseCase decides the withdrawals that WithdrawUseCase left
+// waiting for approval. Requests are read through AdminService.
+type WithdrawalApprovalUseCase interface {
+	// Approve executes the withdrawal from the held amount. The caller must
+	// not be the requester.
+	Approve(ctx context.Context, requestID valueobject.UserID, reason string) (*dto.WithdrawalRequestResponse, error)
+	// Reject releases the held amount; reason is required.
+	Reject(ctx context.Context, requestID valueobject.UserID, reason string) (*dto.WithdrawalRequestResponse, error)
+	// ExpireRequests expires the pending requests whose expiry has passed by
+	// now, releases their amounts and returns how many were expired.
+	ExpireRequests(ctx context.Context, now time.Time) (int, error)
+}
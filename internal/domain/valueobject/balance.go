@@ -0,0 +1,90 @@
+package valueobject
+
+import (
+	"math"
+
+	"bank/internal/domain"
+)
+
+// Balance is a signed amount in the minor unit of its currency, such as the
+// balance of a wallet that an overdraft has taken below zero. Amounts moved in
+// and out of a balance are Money, which is never negative.
+//
+// A balance stays within ±math.MaxInt64, so that it can always be negated.
+type Balance struct {
+	amount   int64
+	currency Currency
+}
+
+func NewBalance(amount int64, currency Currency) (Balance, error) {
+	if amount == math.MinInt64 {
+		return Balance{}, domain.ErrAmountOverflow
+	}
+
+	return Balance{amount: amount, currency: currency}, nil
+}
+
+// BalanceOf returns a balance holding money.
+func BalanceOf(money Money) Balance {
+	return Balance{amount: money.amount, currency: money.currency}
+}
+
+func (b Balance) Amount() int64 {
+	return b.amount
+}
+
+func (b Balance) Currency() Currency {
+	return b.currency
+}
+
+func (b Balance) IsZero() bool {
+	return b.amount == 0
+}
+
+func (b Balance) IsNegative() bool {
+	return b.amount < 0
+}
+
+func (b Balance) Add(money Money) (Balance, error) {
+	if !b.currency.Equals(money.currency) {
+		return Balance{}, domain.ErrCurrencyMismatch
+	}
+	if b.amount > 0 && money.amount > math.MaxInt64-b.amount {
+		return Balance{}, domain.ErrAmountOverflow
+	}
+
+	return Balance{amount: b.amount + money.amount, currency: b.currency}, nil
+}
+
+// Subtract may take the balance below zero; whether that is allowed is up to
+// the caller.
+func (b Balance) Subtract(money Money) (Balance, error) {
+	if !b.currency.Equals(money.currency) {
+		return Balance{}, domain.ErrCurrencyMismatch
+	}
+	if b.amount < -math.MaxInt64+money.amount {
+		return Balance{}, domain.ErrAmountOverflow
+	}
+
+	return Balance{amount: b.amount - money.amount, currency: b.currency}, nil
+}
+
+// Money returns the balance as an amount. It fails with
+// domain.ErrNegativeAmount when the balance is below zero.
+func (b Balance) Money() (Money, error) {
+	return NewMoney(b.amount, b.currency)
+}
+
+// Decimal formats the balance in major units, e.g. "-10.50" for -1050 USD
+// cents.
+func (b Balance) Decimal() string {
+	if b.amount < 0 {
+		return "-" + Money{amount: -b.amount, currency: b.currency}.Decimal()
+	}
+	return Money{amount: b.amount, currency: b.currency}.Decimal()
+}
+
+// String formats the balance with its currency, e.g. "-10.50 USD".
+func (b Balance) String() string {
+	return b.Decimal() + " " + b.currency.Code()
+}
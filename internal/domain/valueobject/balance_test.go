@@ -0,0 +1,131 @@
+package valueobject
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"bank/internal/domain"
+)
+
+func TestBalanceArithmetic(t *testing.T) {
+	usd := DefaultCurrency()
+
+	t.Run("should go below zero when subtracting more than the balance", func(t *testing.T) {
+		// Arrange
+		balance, _ := NewBalance(1000, usd)
+		amount, _ := NewMoney(2500, usd)
+
+		// Act
+		result, err := balance.Subtract(amount)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Amount() != -1500 || !result.IsNegative() {
+			t.Errorf("expected -1500, got %d", result.Amount())
+		}
+	})
+
+	t.Run("should come back above zero when adding", func(t *testing.T) {
+		// Arrange
+		balance, _ := NewBalance(-1500, usd)
+		amount, _ := NewMoney(2000, usd)
+
+		// Act
+		result, err := balance.Add(amount)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Amount() != 500 {
+			t.Errorf("expected 500, got %d", result.Amount())
+		}
+	})
+
+	tests := []struct {
+		name    string
+		balance int64
+		apply   func(Balance, Money) (Balance, error)
+		amount  int64
+		wantErr error
+	}{
+		{name: "should refuse to overflow when adding", balance: math.MaxInt64 - 1, apply: Balance.Add, amount: 2, wantErr: domain.ErrAmountOverflow},
+		{name: "should refuse to overflow when subtracting", balance: -math.MaxInt64 + 1, apply: Balance.Subtract, amount: 2, wantErr: domain.ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			balance, _ := NewBalance(tt.balance, usd)
+			amount, _ := NewMoney(tt.amount, usd)
+
+			// Act
+			_, err := tt.apply(balance, amount)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("should refuse amounts in another currency", func(t *testing.T) {
+		// Arrange
+		eur, _ := NewCurrency("EUR")
+		balance, _ := NewBalance(1000, usd)
+		amount, _ := NewMoney(100, eur)
+
+		// Act
+		_, err := balance.Subtract(amount)
+
+		// Assert
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+}
+
+func TestBalanceMoney(t *testing.T) {
+	t.Run("should refuse to turn a negative balance into money", func(t *testing.T) {
+		// Arrange
+		balance, _ := NewBalance(-1, DefaultCurrency())
+
+		// Act
+		_, err := balance.Money()
+
+		// Assert
+		if !errors.Is(err, domain.ErrNegativeAmount) {
+			t.Errorf("expected ErrNegativeAmount, got %v", err)
+		}
+	})
+}
+
+func TestBalanceString(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		want   string
+	}{
+		{name: "should format a positive balance", amount: 1050, want: "10.50 USD"},
+		{name: "should format a negative balance", amount: -1050, want: "-10.50 USD"},
+		{name: "should format a negative balance below one unit", amount: -5, want: "-0.05 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			balance, _ := NewBalance(tt.amount, DefaultCurrency())
+
+			// Act
+			got := balance.String()
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
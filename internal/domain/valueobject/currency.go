@@ -0,0 +1,69 @@
+package valueobject
+
+import (
+	"strings"
+
+	"bank/internal/domain"
+)
+
+// DefaultCurrencyCode is assumed for requests and records that predate
+// multi-currency support.
+const DefaultCurrencyCode = "USD"
+
+// currencyExponents lists the supported ISO 4217 currencies with the number of
+// decimal places of their minor unit.
+var currencyExponents = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"SGD": 2,
+	"USD": 2,
+}
+
+// Currency is an ISO 4217 currency. Money amounts are held in its minor unit,
+// e.g. cents for USD, so Exponent gives the position of the decimal point.
+type Currency struct {
+	code     string
+	exponent int
+}
+
+func NewCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	exponent, ok := currencyExponents[code]
+	if !ok {
+		return Currency{}, domain.ErrUnsupportedCurrency
+	}
+
+	return Currency{code: code, exponent: exponent}, nil
+}
+
+// DefaultCurrency returns the currency named by DefaultCurrencyCode.
+func DefaultCurrency() Currency {
+	return Currency{code: DefaultCurrencyCode, exponent: currencyExponents[DefaultCurrencyCode]}
+}
+
+func (c Currency) Code() string {
+	return c.code
+}
+
+func (c Currency) Exponent() int {
+	return c.exponent
+}
+
+func (c Currency) Equals(other Currency) bool {
+	return c.code == other.code
+}
+
+func (c Currency) String() string {
+	return c.code
+}
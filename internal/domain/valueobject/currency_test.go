@@ -0,0 +1,65 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+
+	"bank/internal/domain"
+)
+
+func TestNewCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+		exponent int
+		wantErr  bool
+	}{
+		{name: "should create USD with two decimals", code: "USD", expected: "USD", exponent: 2},
+		{name: "should create JPY with no decimals", code: "JPY", expected: "JPY", exponent: 0},
+		{name: "should create KWD with three decimals", code: "KWD", expected: "KWD", exponent: 3},
+		{name: "should normalise case and whitespace", code: " eur ", expected: "EUR", exponent: 2},
+		{name: "should reject an unknown code", code: "XYZ", wantErr: true},
+		{name: "should reject an empty code", code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			currency, err := NewCurrency(tt.code)
+
+			// Assert
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrUnsupportedCurrency) {
+					t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if currency.Code() != tt.expected {
+				t.Errorf("expected code %s, got %s", tt.expected, currency.Code())
+			}
+			if currency.Exponent() != tt.exponent {
+				t.Errorf("expected exponent %d, got %d", tt.exponent, currency.Exponent())
+			}
+		})
+	}
+}
+
+func TestCurrencyEquals(t *testing.T) {
+	t.Run("should compare currencies by code", func(t *testing.T) {
+		// Arrange
+		usd, _ := NewCurrency("usd")
+		eur, _ := NewCurrency("EUR")
+
+		// Act & Assert
+		if !usd.Equals(DefaultCurrency()) {
+			t.Error("expected usd to equal the default currency")
+		}
+		if usd.Equals(eur) {
+			t.Error("expected USD to differ from EUR")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package valueobject
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"bank/internal/domain"
+)
+
+// ExchangeRateScale is the number of decimal places an exchange rate is kept
+// to, matching the rate column of the exchange_quotes table.
+const ExchangeRateScale = 10
+
+var decimalPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ExchangeRate is the price of one major unit of the base currency in major
+// units of the quote currency, e.g. 0.92 for USD to EUR. The rate is held as
+// an exact fraction so that conversions only round once, at the end.
+type ExchangeRate struct {
+	base  Currency
+	quote Currency
+	rate  *big.Rat
+}
+
+// NewExchangeRate parses a positive decimal rate such as "0.92" between two
+// different currencies. Rates with more than ExchangeRateScale decimals are
+// rounded to that scale.
+func NewExchangeRate(base, quote Currency, rate string) (ExchangeRate, error) {
+	if base.Equals(quote) {
+		return ExchangeRate{}, domain.NewValidationError("rate", "exchange rate needs two different currencies")
+	}
+
+	rate = strings.TrimSpace(rate)
+	if !decimalPattern.MatchString(rate) {
+		return ExchangeRate{}, domain.NewValidationError("rate", "exchange rate must be a decimal number")
+	}
+
+	value, _ := new(big.Rat).SetString(rate)
+	value, _ = new(big.Rat).SetString(value.FloatString(ExchangeRateScale))
+	if value.Sign() <= 0 {
+		return ExchangeRate{}, domain.NewValidationError("rate", "exchange rate must be greater than zero")
+	}
+
+	return ExchangeRate{base: base, quote: quote, rate: value}, nil
+}
+
+func (r ExchangeRate) Base() Currency {
+	return r.base
+}
+
+func (r ExchangeRate) Quote() Currency {
+	return r.quote
+}
+
+// Inverse returns the rate from the quote currency back to the base currency,
+// rounded to ExchangeRateScale decimals.
+func (r ExchangeRate) Inverse() (ExchangeRate, error) {
+	return NewExchangeRate(r.quote, r.base, new(big.Rat).Inv(r.rate).FloatString(ExchangeRateScale))
+}
+
+// Convert turns an amount in the base currency into the quote currency,
+// rounding the result to the quote currency's minor unit with banker's
+// rounding.
+func (r ExchangeRate) Convert(amount Money) (Money, error) {
+	if !amount.Currency().Equals(r.base) {
+		return Money{}, domain.ErrCurrencyMismatch
+	}
+
+	// Minor units differ between currencies, e.g. 100 USD cents are one
+	// dollar while 100 JPY are one hundred yen.
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Amount()), r.rate)
+	converted.Mul(converted, pow10(r.quote.Exponent()))
+	converted.Quo(converted, pow10(r.base.Exponent()))
+
+	return moneyFromRat(converted, r.quote)
+}
+
+func (r ExchangeRate) Equals(other ExchangeRate) bool {
+	return r.base.Equals(other.base) && r.quote.Equals(other.quote) && r.rate.Cmp(other.rate) == 0
+}
+
+// String formats the rate as a decimal without trailing zeros, e.g. "0.92".
+// NewExchangeRate reads the same format back.
+func (r ExchangeRate) String() string {
+	value := r.rate.FloatString(ExchangeRateScale)
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+func pow10(exponent int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
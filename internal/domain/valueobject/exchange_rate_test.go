@@ -0,0 +1,153 @@
+package valueobject
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"bank/internal/domain"
+)
+
+func mustCurrency(t *testing.T, code string) Currency {
+	t.Helper()
+	currency, err := NewCurrency(code)
+	if err != nil {
+		t.Fatalf("unexpected error creating currency %s: %v", code, err)
+	}
+	return currency
+}
+
+func TestNewExchangeRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		quote    string
+		rate     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "should parse a decimal rate", base: "USD", quote: "EUR", rate: "0.92", expected: "0.92"},
+		{name: "should drop trailing zeros", base: "USD", quote: "JPY", rate: "151.200", expected: "151.2"},
+		{name: "should round to the rate scale", base: "USD", quote: "EUR", rate: "0.123456789012", expected: "0.123456789"},
+		{name: "should reject the same currency twice", base: "USD", quote: "USD", rate: "1", wantErr: true},
+		{name: "should reject a zero rate", base: "USD", quote: "EUR", rate: "0", wantErr: true},
+		{name: "should reject a negative rate", base: "USD", quote: "EUR", rate: "-0.92", wantErr: true},
+		{name: "should reject a fraction", base: "USD", quote: "EUR", rate: "23/25", wantErr: true},
+		{name: "should reject an exponent", base: "USD", quote: "EUR", rate: "9.2e-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rate, err := NewExchangeRate(mustCurrency(t, tt.base), mustCurrency(t, tt.quote), tt.rate)
+
+			// Assert
+			if tt.wantErr {
+				var validationErr *domain.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if rate.String() != tt.expected {
+				t.Errorf("expected rate %s, got %s", tt.expected, rate.String())
+			}
+		})
+	}
+}
+
+func TestExchangeRateConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		quote    string
+		rate     string
+		amount   int64
+		expected int64
+	}{
+		{name: "should convert between two-decimal currencies", base: "USD", quote: "EUR", rate: "0.92", amount: 10000, expected: 9200},
+		{name: "should round half down to an even unit", base: "USD", quote: "EUR", rate: "0.5", amount: 5, expected: 2},
+		{name: "should round half up to an even unit", base: "USD", quote: "EUR", rate: "0.5", amount: 7, expected: 4},
+		{name: "should round below half down", base: "USD", quote: "EUR", rate: "0.92", amount: 1, expected: 1},
+		{name: "should convert cents into yen", base: "USD", quote: "JPY", rate: "150", amount: 1, expected: 2},
+		{name: "should round yen half to even", base: "USD", quote: "JPY", rate: "150", amount: 3, expected: 4},
+		{name: "should convert yen into cents", base: "JPY", quote: "USD", rate: "0.0067", amount: 100, expected: 67},
+		{name: "should convert into a three-decimal currency", base: "USD", quote: "KWD", rate: "0.3075", amount: 100, expected: 308},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			base := mustCurrency(t, tt.base)
+			quote := mustCurrency(t, tt.quote)
+			rate, _ := NewExchangeRate(base, quote, tt.rate)
+			amount, _ := NewMoney(tt.amount, base)
+
+			// Act
+			converted, err := rate.Convert(amount)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if converted.Amount() != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, converted.Amount())
+			}
+			if !converted.Currency().Equals(quote) {
+				t.Errorf("expected currency %s, got %s", quote, converted.Currency())
+			}
+		})
+	}
+
+	t.Run("should reject an amount in another currency", func(t *testing.T) {
+		// Arrange
+		rate, _ := NewExchangeRate(mustCurrency(t, "USD"), mustCurrency(t, "EUR"), "0.92")
+		amount, _ := NewMoney(100, mustCurrency(t, "GBP"))
+
+		// Act
+		_, err := rate.Convert(amount)
+
+		// Assert
+		if !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+	})
+
+	t.Run("should reject a result that overflows", func(t *testing.T) {
+		// Arrange
+		rate, _ := NewExchangeRate(mustCurrency(t, "USD"), mustCurrency(t, "IDR"), "15650")
+		amount, _ := NewMoney(math.MaxInt64/1000, mustCurrency(t, "USD"))
+
+		// Act
+		_, err := rate.Convert(amount)
+
+		// Assert
+		if !errors.Is(err, domain.ErrAmountOverflow) {
+			t.Errorf("expected ErrAmountOverflow, got %v", err)
+		}
+	})
+}
+
+func TestExchangeRateInverse(t *testing.T) {
+	t.Run("should swap the currencies and invert the rate", func(t *testing.T) {
+		// Arrange
+		rate, _ := NewExchangeRate(mustCurrency(t, "USD"), mustCurrency(t, "EUR"), "0.8")
+
+		// Act
+		inverse, err := rate.Inverse()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if inverse.Base().Code() != "EUR" || inverse.Quote().Code() != "USD" {
+			t.Errorf("expected EUR to USD, got %s to %s", inverse.Base(), inverse.Quote())
+		}
+		if inverse.String() != "1.25" {
+			t.Errorf("expected rate 1.25, got %s", inverse.String())
+		}
+	})
+}
@@ -1,43 +1,167 @@
 package valueobject
 
 import (
-	"errors"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
+
+	"bank/internal/domain"
 )
 
+// Money is a non-negative amount in the minor unit of its currency; a signed
+// amount is a Balance. Arithmetic between different currencies is refused with
+// domain.ErrCurrencyMismatch.
 type Money struct {
-	amount int64
+	amount   int64
+	currency Currency
 }
 
-func NewMoney(amount int64) (Money, error) {
+func NewMoney(amount int64, currency Currency) (Money, error) {
 	if amount < 0 {
-		return Money{}, errors.New("money amount cannot be negative")
+		return Money{}, domain.ErrNegativeAmount
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// ParseMoney reads a decimal amount followed by a currency code, such as
+// "10.50 USD". The amount may not have more decimals than the currency's minor
+// unit allows.
+func ParseMoney(value string) (Money, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return Money{}, domain.NewValidationError("amount", "amount must look like \"10.50 USD\"")
+	}
+
+	currency, err := NewCurrency(fields[1])
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount, err := parseMinorUnits(fields[0], currency.Exponent())
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(amount, currency)
+}
+
+func parseMinorUnits(decimal string, exponent int) (int64, error) {
+	invalid := domain.NewValidationError("amount", "amount must be a non-negative decimal number")
+
+	whole, fraction, hasFraction := strings.Cut(decimal, ".")
+	if whole == "" || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") || (hasFraction && fraction == "") {
+		return 0, invalid
+	}
+	if len(fraction) > exponent {
+		return 0, domain.NewValidationError("amount", "amount has more decimal places than the currency allows")
+	}
+
+	digits := whole + fraction + strings.Repeat("0", exponent-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, invalid
+		}
 	}
 
-	return Money{amount: amount}, nil
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, domain.ErrAmountOverflow
+	}
+	return amount, nil
 }
 
 func (m Money) Amount() int64 {
 	return m.amount
 }
 
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
 func (m Money) Subtract(other Money) (Money, error) {
+	if !m.currency.Equals(other.currency) {
+		return Money{}, domain.ErrCurrencyMismatch
+	}
 	if m.amount < other.amount {
-		return Money{}, errors.New("insufficient funds")
+		return Money{}, domain.ErrInsufficientFunds
 	}
 
-	return Money{amount: m.amount - other.amount}, nil
+	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
 }
 
-func (m Money) LessThanOrEqual(other Money) bool {
-	return m.amount <= other.amount
+func (m Money) Add(other Money) (Money, error) {
+	if !m.currency.Equals(other.currency) {
+		return Money{}, domain.ErrCurrencyMismatch
+	}
+	if other.amount > math.MaxInt64-m.amount {
+		return Money{}, domain.ErrAmountOverflow
+	}
+
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
 }
 
+// BasisPoints returns the share of m given in basis points (hundredths of a
+// percent), rounded to the minor unit with banker's rounding.
+func (m Money) BasisPoints(bps int64) (Money, error) {
+	if bps < 0 {
+		return Money{}, domain.ErrNegativeAmount
+	}
+
+	share := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(bps)),
+		big.NewInt(10000),
+	)
+	return moneyFromRat(share, m.currency)
+}
+
+// moneyFromRat rounds an amount of minor units to a whole number with banker's
+// rounding: halves go to the nearest even unit, so rounding does not drift in
+// either direction over many operations.
+func moneyFromRat(minorUnits *big.Rat, currency Currency) (Money, error) {
+	quotient, remainder := new(big.Int).QuoRem(minorUnits.Num(), minorUnits.Denom(), new(big.Int))
+
+	// Denom is always positive, so the remainder has the sign of Num.
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if cmp := twice.Cmp(minorUnits.Denom()); cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, domain.ErrAmountOverflow
+	}
+	return NewMoney(quotient.Int64(), currency)
+}
+
+// LessThanOrEqual reports false for amounts in different currencies, which
+// cannot be compared.
+func (m Money) LessThanOrEqual(other Money) bool {
+	return m.currency.Equals(other.currency) && m.amount <= other.amount
+}
 
 func (m Money) IsZero() bool {
 	return m.amount == 0
 }
 
+// Decimal formats the amount in major units, e.g. "10.50" for 1050 USD cents.
+func (m Money) Decimal() string {
+	digits := strconv.FormatInt(m.amount, 10)
+
+	exponent := m.currency.Exponent()
+	if exponent == 0 {
+		return digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// String formats the amount with its currency, e.g. "10.50 USD". ParseMoney
+// reads the same format back.
 func (m Money) String() string {
-	return strconv.FormatInt(m.amount, 10)
+	return m.Decimal() + " " + m.currency.Code()
 }
@@ -1,7 +1,11 @@
 package valueobject
 
 import (
+	"errors"
+	"math"
 	"testing"
+
+	"bank/internal/domain"
 )
 
 func TestNewMoney(t *testing.T) {
@@ -10,7 +14,7 @@ func TestNewMoney(t *testing.T) {
 		amount := int64(10000)
 
 		// Act
-		money, err := NewMoney(amount)
+		money, err := NewMoney(amount, DefaultCurrency())
 
 		// Assert
 		if err != nil {
@@ -26,7 +30,7 @@ func TestNewMoney(t *testing.T) {
 		amount := int64(0)
 
 		// Act
-		money, err := NewMoney(amount)
+		money, err := NewMoney(amount, DefaultCurrency())
 
 		// Assert
 		if err != nil {
@@ -42,7 +46,7 @@ func TestNewMoney(t *testing.T) {
 		amount := int64(-1000)
 
 		// Act
-		_, err := NewMoney(amount)
+		_, err := NewMoney(amount, DefaultCurrency())
 
 		// Assert
 		if err == nil {
@@ -57,8 +61,8 @@ func TestNewMoney(t *testing.T) {
 func TestMoneySubtract(t *testing.T) {
 	t.Run("should subtract money correctly", func(t *testing.T) {
 		// Arrange
-		money1, _ := NewMoney(15000)
-		money2, _ := NewMoney(5000)
+		money1, _ := NewMoney(15000, DefaultCurrency())
+		money2, _ := NewMoney(5000, DefaultCurrency())
 
 		// Act
 		result, err := money1.Subtract(money2)
@@ -75,8 +79,8 @@ func TestMoneySubtract(t *testing.T) {
 
 	t.Run("should return error when subtracting more than available", func(t *testing.T) {
 		// Arrange
-		money1, _ := NewMoney(5000)
-		money2, _ := NewMoney(10000)
+		money1, _ := NewMoney(5000, DefaultCurrency())
+		money2, _ := NewMoney(10000, DefaultCurrency())
 
 		// Act
 		_, err := money1.Subtract(money2)
@@ -91,12 +95,82 @@ func TestMoneySubtract(t *testing.T) {
 	})
 }
 
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	t.Run("should refuse arithmetic between different currencies", func(t *testing.T) {
+		// Arrange
+		eur, _ := NewCurrency("EUR")
+		dollars, _ := NewMoney(15000, DefaultCurrency())
+		euros, _ := NewMoney(5000, eur)
+
+		// Act
+		_, addErr := dollars.Add(euros)
+		_, subtractErr := dollars.Subtract(euros)
+
+		// Assert
+		if !errors.Is(addErr, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch from Add, got %v", addErr)
+		}
+		if !errors.Is(subtractErr, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch from Subtract, got %v", subtractErr)
+		}
+	})
+
+	t.Run("should not compare different currencies", func(t *testing.T) {
+		// Arrange
+		eur, _ := NewCurrency("EUR")
+		dollars, _ := NewMoney(100, DefaultCurrency())
+		euros, _ := NewMoney(5000, eur)
+
+		// Act & Assert
+		if dollars.LessThanOrEqual(euros) {
+			t.Error("expected amounts in different currencies to be incomparable")
+		}
+	})
+}
+
+func TestMoneyAdd(t *testing.T) {
+	t.Run("should add money correctly", func(t *testing.T) {
+		// Arrange
+		money1, _ := NewMoney(15000, DefaultCurrency())
+		money2, _ := NewMoney(5000, DefaultCurrency())
+
+		// Act
+		result, err := money1.Add(money2)
+
+		// Assert
+		expected := int64(20000)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Amount() != expected {
+			t.Errorf("expected %d, got %d", expected, result.Amount())
+		}
+	})
+
+	t.Run("should return error when result overflows", func(t *testing.T) {
+		// Arrange
+		money1, _ := NewMoney(math.MaxInt64, DefaultCurrency())
+		money2, _ := NewMoney(1, DefaultCurrency())
+
+		// Act
+		_, err := money1.Add(money2)
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected overflow error, got nil")
+		}
+		if err.Error() != "money amount overflow" {
+			t.Errorf("expected 'money amount overflow', got %v", err)
+		}
+	})
+}
+
 func TestMoneyComparisons(t *testing.T) {
 	t.Run("should check if money is less than or equal", func(t *testing.T) {
 		// Arrange
-		money1, _ := NewMoney(10000)
-		money2, _ := NewMoney(10000)
-		money3, _ := NewMoney(15000)
+		money1, _ := NewMoney(10000, DefaultCurrency())
+		money2, _ := NewMoney(10000, DefaultCurrency())
+		money3, _ := NewMoney(15000, DefaultCurrency())
 
 		// Act & Assert
 		if !money1.LessThanOrEqual(money2) {
@@ -112,9 +186,9 @@ func TestMoneyComparisons(t *testing.T) {
 
 	t.Run("should check if money is equal", func(t *testing.T) {
 		// Arrange
-		money1, _ := NewMoney(10000)
-		money2, _ := NewMoney(10000)
-		money3, _ := NewMoney(15000)
+		money1, _ := NewMoney(10000, DefaultCurrency())
+		money2, _ := NewMoney(10000, DefaultCurrency())
+		money3, _ := NewMoney(15000, DefaultCurrency())
 
 		// Act & Assert
 		if money1.Amount() != money2.Amount() {
@@ -127,8 +201,8 @@ func TestMoneyComparisons(t *testing.T) {
 
 	t.Run("should check if money is zero", func(t *testing.T) {
 		// Arrange
-		money1, _ := NewMoney(0)
-		money2, _ := NewMoney(10000)
+		money1, _ := NewMoney(0, DefaultCurrency())
+		money2, _ := NewMoney(10000, DefaultCurrency())
 
 		// Act & Assert
 		if !money1.IsZero() {
@@ -140,18 +214,134 @@ func TestMoneyComparisons(t *testing.T) {
 	})
 }
 
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		amount   int64
+		currency string
+		wantErr  bool
+	}{
+		{name: "should parse two decimal places", input: "10.50 USD", amount: 1050, currency: "USD"},
+		{name: "should pad missing decimal places", input: "10.5 EUR", amount: 1050, currency: "EUR"},
+		{name: "should parse a whole amount", input: "7 USD", amount: 700, currency: "USD"},
+		{name: "should parse a zero-exponent currency", input: "1500 JPY", amount: 1500, currency: "JPY"},
+		{name: "should parse a three-exponent currency", input: "1.234 KWD", amount: 1234, currency: "KWD"},
+		{name: "should accept a lower-case currency code", input: "1.00 usd", amount: 100, currency: "USD"},
+		{name: "should reject too many decimal places", input: "10.505 USD", wantErr: true},
+		{name: "should reject decimals for a zero-exponent currency", input: "15.5 JPY", wantErr: true},
+		{name: "should reject a negative amount", input: "-1.00 USD", wantErr: true},
+		{name: "should reject a missing currency", input: "10.50", wantErr: true},
+		{name: "should reject an unsupported currency", input: "10.50 XYZ", wantErr: true},
+		{name: "should reject a non-numeric amount", input: "ten USD", wantErr: true},
+		{name: "should reject a trailing decimal point", input: "10. USD", wantErr: true},
+		{name: "should reject an amount that overflows", input: "99999999999999999999 USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			money, err := ParseMoney(tt.input)
+
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q, got %v", tt.input, money)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if money.Amount() != tt.amount {
+				t.Errorf("expected amount %d, got %d", tt.amount, money.Amount())
+			}
+			if money.Currency().Code() != tt.currency {
+				t.Errorf("expected currency %s, got %s", tt.currency, money.Currency().Code())
+			}
+		})
+	}
+}
+
 func TestMoneyString(t *testing.T) {
-	t.Run("should format money as string correctly", func(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+		expected string
+	}{
+		{name: "should format cents", amount: 5, currency: "USD", expected: "0.05 USD"},
+		{name: "should format zero", amount: 0, currency: "EUR", expected: "0.00 EUR"},
+		{name: "should format a zero-exponent currency", amount: 1500, currency: "JPY", expected: "1500 JPY"},
+		{name: "should format a three-exponent currency", amount: 1234, currency: "BHD", expected: "1.234 BHD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			currency, _ := NewCurrency(tt.currency)
+			money, _ := NewMoney(tt.amount, currency)
+
+			// Act
+			result := money.String()
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+			parsed, err := ParseMoney(result)
+			if err != nil || parsed != money {
+				t.Errorf("expected %s to parse back to %v, got %v (%v)", result, money, parsed, err)
+			}
+		})
+	}
+}
+
+func TestMoneyBasisPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		bps      int64
+		expected int64
+	}{
+		{name: "should take an exact share", amount: 10000, bps: 50, expected: 50},
+		{name: "should round half down to an even unit", amount: 100, bps: 50, expected: 0},
+		{name: "should round half up to an even unit", amount: 300, bps: 50, expected: 2},
+		{name: "should round above half up", amount: 130, bps: 50, expected: 1},
+		{name: "should take nothing at zero basis points", amount: 10000, bps: 0, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			money, _ := NewMoney(tt.amount, DefaultCurrency())
+
+			// Act
+			share, err := money.BasisPoints(tt.bps)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if share.Amount() != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, share.Amount())
+			}
+			if !share.Currency().Equals(money.Currency()) {
+				t.Errorf("expected currency %s, got %s", money.Currency(), share.Currency())
+			}
+		})
+	}
+
+	t.Run("should reject negative basis points", func(t *testing.T) {
 		// Arrange
-		money, _ := NewMoney(15000)
+		money, _ := NewMoney(100, DefaultCurrency())
 
 		// Act
-		result := money.String()
+		_, err := money.BasisPoints(-1)
 
 		// Assert
-		expected := "15000"
-		if result != expected {
-			t.Errorf("expected %s, got %s", expected, result)
+		if !errors.Is(err, domain.ErrNegativeAmount) {
+			t.Errorf("expected ErrNegativeAmount, got %v", err)
 		}
 	})
 }
@@ -1,7 +1,8 @@
 package valueobject
 
 import (
-	"errors"
+	"bank/internal/domain"
+
 	"github.com/google/uuid"
 )
 
@@ -12,7 +13,7 @@ type UserID struct {
 func NewUserID(idStr string) (UserID, error) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return UserID{}, errors.New("invalid user ID format")
+		return UserID{}, domain.ErrInvalidUserID
 	}
 
 	return UserID{value: id}, nil
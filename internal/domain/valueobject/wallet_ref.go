@@ -0,0 +1,37 @@
+package valueobject
+
+// WalletRef identifies a wallet either directly by its ID or as the default
+// wallet of a user, which is how clients predating multiple wallets per user
+// address it.
+type WalletRef struct {
+	id     UserID
+	byUser bool
+}
+
+func WalletByID(walletID UserID) WalletRef {
+	return WalletRef{id: walletID}
+}
+
+func DefaultWalletOf(userID UserID) WalletRef {
+	return WalletRef{id: userID, byUser: true}
+}
+
+// WalletID returns the referenced wallet ID, if the reference carries one.
+func (r WalletRef) WalletID() (UserID, bool) {
+	return r.id, !r.byUser
+}
+
+// UserID returns the user whose default wallet is referenced, if the reference
+// is by user.
+func (r WalletRef) UserID() (UserID, bool) {
+	return r.id, r.byUser
+}
+
+// String returns "wallet:<id>" or "user:<id>", so that two references are
+// equal exactly when their strings are.
+func (r WalletRef) String() string {
+	if r.byUser {
+		return "user:" + r.id.String()
+	}
+	return "wallet:" + r.id.String()
+}
@@ -0,0 +1,45 @@
+package valueobject
+
+import "testing"
+
+func TestWalletRef(t *testing.T) {
+	t.Run("should reference a wallet by ID", func(t *testing.T) {
+		// Arrange
+		id := NewUserIDRandom()
+
+		// Act
+		ref := WalletByID(id)
+
+		// Assert
+		walletID, ok := ref.WalletID()
+		if !ok || !walletID.Equals(id) {
+			t.Errorf("expected wallet ID %s, got %s (%t)", id, walletID, ok)
+		}
+		if _, ok := ref.UserID(); ok {
+			t.Error("expected no user ID")
+		}
+		if ref.String() != "wallet:"+id.String() {
+			t.Errorf("unexpected string %s", ref.String())
+		}
+	})
+
+	t.Run("should reference the default wallet of a user", func(t *testing.T) {
+		// Arrange
+		id := NewUserIDRandom()
+
+		// Act
+		ref := DefaultWalletOf(id)
+
+		// Assert
+		userID, ok := ref.UserID()
+		if !ok || !userID.Equals(id) {
+			t.Errorf("expected user ID %s, got %s (%t)", id, userID, ok)
+		}
+		if _, ok := ref.WalletID(); ok {
+			t.Error("expected no wallet ID")
+		}
+		if ref.String() == WalletByID(id).String() {
+			t.Error("expected references by user and by wallet to differ")
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("should load the embedded migrations in version order", func(t *testing.T) {
+		// Act
+		migrations, err := loadMigrations(migrationFiles)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(migrations) == 0 {
+			t.Fatal("expected embedded migrations, got none")
+		}
+		for i, migration := range migrations {
+			if migration.Version != i+1 {
+				t.Errorf("expected version %d at position %d, got %d", i+1, i, migration.Version)
+			}
+			if migration.up == "" || migration.down == "" {
+				t.Errorf("migration %d is missing a script", migration.Version)
+			}
+		}
+	})
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name: "should reject a migration without a down file",
+			files: fstest.MapFS{
+				"migrations/0001_init.up.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name: "should reject an unexpected file name",
+			files: fstest.MapFS{
+				"migrations/init.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+		{
+			name: "should reject conflicting names for one version",
+			files: fstest.MapFS{
+				"migrations/0001_init.up.sql":    {Data: []byte("SELECT 1;")},
+				"migrations/0001_other.down.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := loadMigrations(tt.files)
+
+			// Assert
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS users;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,92 @@
+-- Every wallet owns one ledger account; system accounts are the counterparties
+-- of money entering (CASH_IN) or leaving (CASH_OUT) the service.
+CREATE TABLE ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    kind VARCHAR(10) NOT NULL,
+    wallet_id UUID UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT ledger_accounts_kind_valid CHECK (kind IN ('WALLET', 'SYSTEM')),
+    CONSTRAINT ledger_accounts_wallet_required CHECK ((kind = 'WALLET') = (wallet_id IS NOT NULL)),
+
+    -- Foreign Key
+    FOREIGN KEY (wallet_id) REFERENCES wallets(id)
+);
+
+CREATE TABLE journal_entries (
+    id UUID PRIMARY KEY,
+    reference VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE postings (
+    id BIGSERIAL PRIMARY KEY,
+    journal_entry_id UUID NOT NULL,
+    account_id UUID NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount BIGINT NOT NULL,
+
+    -- Constraints
+    CONSTRAINT postings_direction_valid CHECK (direction IN ('DEBIT', 'CREDIT')),
+    CONSTRAINT postings_amount_positive CHECK (amount > 0),
+
+    -- Foreign Keys
+    FOREIGN KEY (journal_entry_id) REFERENCES journal_entries(id),
+    FOREIGN KEY (account_id) REFERENCES ledger_accounts(id)
+);
+
+CREATE INDEX idx_journal_entries_reference ON journal_entries(reference);
+CREATE INDEX idx_postings_journal_entry_id ON postings(journal_entry_id);
+CREATE INDEX idx_postings_account_id ON postings(account_id);
+
+-- Journal entries and postings are append-only
+CREATE OR REPLACE FUNCTION prevent_ledger_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger records are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER journal_entries_immutable
+    BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_ledger_mutation();
+
+CREATE TRIGGER postings_immutable
+    BEFORE UPDATE OR DELETE ON postings
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_ledger_mutation();
+
+-- The postings of a journal entry must sum to zero, checked at commit time
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced()
+RETURNS TRIGGER AS $$
+DECLARE
+    imbalance NUMERIC;
+BEGIN
+    SELECT COALESCE(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END), 0)
+    INTO imbalance
+    FROM postings
+    WHERE journal_entry_id = NEW.journal_entry_id;
+
+    IF imbalance <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced by %', NEW.journal_entry_id, imbalance;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE CONSTRAINT TRIGGER postings_balanced
+    AFTER INSERT ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+    EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- System ledger accounts
+INSERT INTO ledger_accounts (code, kind) VALUES
+    ('CASH_IN', 'SYSTEM'),
+    ('CASH_OUT', 'SYSTEM'),
+    ('FEES', 'SYSTEM'),
+    ('OPENING_BALANCE', 'SYSTEM');
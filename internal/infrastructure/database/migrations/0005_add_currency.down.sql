@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced()
+RETURNS TRIGGER AS $$
+DECLARE
+    imbalance NUMERIC;
+BEGIN
+    SELECT COALESCE(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END), 0)
+    INTO imbalance
+    FROM postings
+    WHERE journal_entry_id = NEW.journal_entry_id;
+
+    IF imbalance <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced by %', NEW.journal_entry_id, imbalance;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE postings DROP COLUMN currency;
+ALTER TABLE transactions DROP COLUMN currency;
+ALTER TABLE wallets DROP COLUMN currency;
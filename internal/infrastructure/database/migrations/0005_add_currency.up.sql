@@ -0,0 +1,35 @@
+-- Amounts are held in the minor unit of an ISO 4217 currency. Rows written
+-- before multi-currency support were all US dollars.
+ALTER TABLE wallets
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD CONSTRAINT wallets_currency_format CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE transactions
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD CONSTRAINT transactions_currency_format CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE postings
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD CONSTRAINT postings_currency_format CHECK (currency ~ '^[A-Z]{3}$');
+
+-- The postings of a journal entry must sum to zero in each currency
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced()
+RETURNS TRIGGER AS $$
+DECLARE
+    unbalanced RECORD;
+BEGIN
+    SELECT currency, SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END) AS imbalance
+    INTO unbalanced
+    FROM postings
+    WHERE journal_entry_id = NEW.journal_entry_id
+    GROUP BY currency
+    HAVING SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END) <> 0
+    LIMIT 1;
+
+    IF FOUND THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced by % %',
+            NEW.journal_entry_id, unbalanced.imbalance, unbalanced.currency;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
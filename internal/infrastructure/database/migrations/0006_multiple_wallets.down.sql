@@ -0,0 +1,10 @@
+-- Fails while any user still owns more than one wallet.
+DROP INDEX idx_wallets_user_id;
+DROP INDEX wallets_one_default_per_user;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_user_name_unique,
+    DROP COLUMN is_default,
+    DROP COLUMN name;
+
+ALTER TABLE wallets ADD CONSTRAINT wallets_user_id_key UNIQUE (user_id);
@@ -0,0 +1,16 @@
+-- A user may own several wallets, told apart by name. Exactly one of them is
+-- the default wallet that receives operations addressed by user ID; the
+-- wallets that existed before are the default wallets of their users.
+ALTER TABLE wallets DROP CONSTRAINT wallets_user_id_key;
+
+ALTER TABLE wallets
+    ADD COLUMN name VARCHAR(50) NOT NULL DEFAULT 'main',
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE wallets SET is_default = TRUE;
+
+ALTER TABLE wallets
+    ADD CONSTRAINT wallets_user_name_unique UNIQUE (user_id, name);
+
+CREATE UNIQUE INDEX wallets_one_default_per_user ON wallets(user_id) WHERE is_default;
+CREATE INDEX idx_wallets_user_id ON wallets(user_id);
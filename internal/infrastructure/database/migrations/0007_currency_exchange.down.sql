@@ -0,0 +1,9 @@
+-- Fails once an exchange has been posted to the ledger or recorded as a
+-- transaction.
+DELETE FROM ledger_accounts WHERE code IN ('FX_POSITION', 'FX_REVENUE') AND kind = 'SYSTEM';
+
+DROP TABLE exchange_quotes;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN'));
@@ -0,0 +1,39 @@
+-- Exchanges between a user's wallets are priced by quotes that lock a rate for
+-- a short time. The legs of an exchange are EXCHANGE_OUT/EXCHANGE_IN
+-- transactions linked through transfer_id, which holds the quote ID.
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN', 'EXCHANGE_OUT', 'EXCHANGE_IN'));
+
+CREATE TABLE exchange_quotes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    from_wallet_id UUID NOT NULL,
+    to_wallet_id UUID NOT NULL,
+    rate NUMERIC(24, 10) NOT NULL,
+    source_amount BIGINT NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    fee BIGINT NOT NULL,
+    target_amount BIGINT NOT NULL,
+    target_currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+
+    -- Constraints
+    CONSTRAINT exchange_quotes_rate_positive CHECK (rate > 0),
+    CONSTRAINT exchange_quotes_amounts_valid CHECK (source_amount > 0 AND fee >= 0 AND fee < source_amount AND target_amount > 0),
+    CONSTRAINT exchange_quotes_currencies_differ CHECK (source_currency <> target_currency),
+
+    -- Foreign Keys
+    FOREIGN KEY (from_wallet_id) REFERENCES wallets(id),
+    FOREIGN KEY (to_wallet_id) REFERENCES wallets(id)
+);
+
+CREATE INDEX idx_exchange_quotes_expires_at ON exchange_quotes(expires_at);
+
+-- System ledger accounts for the service's currency position and the spread
+-- earned on exchanges
+INSERT INTO ledger_accounts (code, kind) VALUES
+    ('FX_POSITION', 'SYSTEM'),
+    ('FX_REVENUE', 'SYSTEM');
@@ -0,0 +1,5 @@
+DROP TABLE holds;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_held_balance_valid,
+    DROP COLUMN held_balance;
@@ -0,0 +1,32 @@
+-- Holds reserve part of a wallet's balance until they are captured, released
+-- or expire. held_balance caches the total of a wallet's active holds; only
+-- balance - held_balance can be spent.
+ALTER TABLE wallets
+    ADD COLUMN held_balance BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT wallets_held_balance_valid CHECK (held_balance >= 0 AND held_balance <= balance);
+
+CREATE TABLE holds (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    captured_amount BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE',
+    transaction_id UUID,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT holds_amount_positive CHECK (amount > 0),
+    CONSTRAINT holds_captured_amount_valid CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    CONSTRAINT holds_status_valid CHECK (status IN ('ACTIVE', 'CAPTURED', 'RELEASED', 'EXPIRED')),
+    CONSTRAINT holds_capture_recorded CHECK ((status = 'CAPTURED') = (transaction_id IS NOT NULL)),
+
+    -- Foreign Keys
+    CONSTRAINT holds_wallet_fk FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT holds_transaction_fk FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_holds_wallet_id ON holds(wallet_id);
+CREATE INDEX idx_holds_active_expires_at ON holds(expires_at) WHERE status = 'ACTIVE';
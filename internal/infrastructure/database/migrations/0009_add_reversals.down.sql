@@ -0,0 +1,13 @@
+-- Fails once a reversal has been recorded as a transaction.
+
+DROP INDEX idx_transactions_reversal_of;
+
+ALTER TABLE transactions
+    DROP CONSTRAINT transactions_reversal_linked,
+    DROP CONSTRAINT transactions_reversal_of_fk,
+    DROP COLUMN reason,
+    DROP COLUMN reversal_of;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN', 'EXCHANGE_OUT', 'EXCHANGE_IN'));
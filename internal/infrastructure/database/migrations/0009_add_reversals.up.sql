@@ -0,0 +1,14 @@
+-- A REVERSAL returns all or part of a completed withdrawal to its wallet.
+-- reversal_of links it to the withdrawal, which can be reversed only once, and
+-- reason records why.
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN', 'EXCHANGE_OUT', 'EXCHANGE_IN', 'REVERSAL'));
+
+ALTER TABLE transactions
+    ADD COLUMN reversal_of UUID,
+    ADD COLUMN reason TEXT,
+    ADD CONSTRAINT transactions_reversal_of_fk FOREIGN KEY (reversal_of) REFERENCES transactions(id),
+    ADD CONSTRAINT transactions_reversal_linked CHECK ((transaction_type = 'REVERSAL') = (reversal_of IS NOT NULL AND reason IS NOT NULL));
+
+CREATE UNIQUE INDEX idx_transactions_reversal_of ON transactions(reversal_of) WHERE reversal_of IS NOT NULL;
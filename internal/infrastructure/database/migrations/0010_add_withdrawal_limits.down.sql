@@ -0,0 +1,6 @@
+DROP TABLE wallet_limits;
+DROP TABLE tier_limits;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_tier_valid,
+    DROP COLUMN tier;
@@ -0,0 +1,52 @@
+-- Withdrawal limits are configured per wallet tier and currency, and may be
+-- overridden per wallet. A NULL limit does not apply; in wallet_limits it
+-- keeps the tier's limit. Amounts are in minor units.
+ALTER TABLE wallets
+    ADD COLUMN tier VARCHAR(20) NOT NULL DEFAULT 'STANDARD',
+    ADD CONSTRAINT wallets_tier_valid CHECK (tier IN ('STANDARD', 'PREMIUM'));
+
+CREATE TABLE tier_limits (
+    tier VARCHAR(20) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    min_amount BIGINT,
+    per_transaction BIGINT,
+    daily BIGINT,
+    monthly BIGINT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tier, currency),
+
+    -- Constraints
+    CONSTRAINT tier_limits_tier_valid CHECK (tier IN ('STANDARD', 'PREMIUM')),
+    CONSTRAINT tier_limits_positive CHECK (
+        (min_amount IS NULL OR min_amount > 0) AND
+        (per_transaction IS NULL OR per_transaction > 0) AND
+        (daily IS NULL OR daily > 0) AND
+        (monthly IS NULL OR monthly > 0)
+    )
+);
+
+CREATE TABLE wallet_limits (
+    wallet_id UUID PRIMARY KEY,
+    min_amount BIGINT,
+    per_transaction BIGINT,
+    daily BIGINT,
+    monthly BIGINT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT wallet_limits_positive CHECK (
+        (min_amount IS NULL OR min_amount > 0) AND
+        (per_transaction IS NULL OR per_transaction > 0) AND
+        (daily IS NULL OR daily > 0) AND
+        (monthly IS NULL OR monthly > 0)
+    ),
+
+    -- Foreign Keys
+    CONSTRAINT wallet_limits_wallet_fk FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+-- Default limits for USD wallets.
+INSERT INTO tier_limits (tier, currency, min_amount, per_transaction, daily, monthly) VALUES
+    ('STANDARD', 'USD', NULL, 500000, 1000000, 5000000),
+    ('PREMIUM', 'USD', NULL, 2500000, 5000000, 25000000);
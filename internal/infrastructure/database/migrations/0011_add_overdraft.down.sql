@@ -0,0 +1,8 @@
+-- Fails while any wallet is overdrawn or holds part of its overdraft.
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_held_balance_valid,
+    DROP CONSTRAINT wallets_balance_within_overdraft,
+    DROP CONSTRAINT wallets_overdraft_limit_non_negative,
+    DROP COLUMN overdraft_limit,
+    ADD CONSTRAINT wallets_balance_non_negative CHECK (balance >= 0),
+    ADD CONSTRAINT wallets_held_balance_valid CHECK (held_balance >= 0 AND held_balance <= balance);
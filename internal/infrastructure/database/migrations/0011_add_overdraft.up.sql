@@ -0,0 +1,9 @@
+-- Wallets with an overdraft limit may spend that much beyond their balance,
+-- which then goes negative. Holds may reserve the overdraft as well.
+ALTER TABLE wallets
+    ADD COLUMN overdraft_limit BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT wallets_overdraft_limit_non_negative CHECK (overdraft_limit >= 0),
+    DROP CONSTRAINT wallets_balance_non_negative,
+    ADD CONSTRAINT wallets_balance_within_overdraft CHECK (balance >= -overdraft_limit),
+    DROP CONSTRAINT wallets_held_balance_valid,
+    ADD CONSTRAINT wallets_held_balance_valid CHECK (held_balance >= 0 AND held_balance <= balance + overdraft_limit);
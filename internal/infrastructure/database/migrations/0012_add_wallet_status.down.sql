@@ -0,0 +1,6 @@
+DROP TABLE wallet_status_changes;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_closed_empty,
+    DROP CONSTRAINT wallets_status_valid,
+    DROP COLUMN status;
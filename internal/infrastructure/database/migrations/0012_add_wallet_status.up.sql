@@ -0,0 +1,26 @@
+-- A wallet's status decides which money movements it accepts. Every change
+-- is recorded with the reason it was made.
+ALTER TABLE wallets
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    ADD CONSTRAINT wallets_status_valid CHECK (status IN ('ACTIVE', 'FROZEN', 'DEBIT_BLOCKED', 'CLOSED')),
+    -- Only an empty wallet can be closed.
+    ADD CONSTRAINT wallets_closed_empty CHECK (status <> 'CLOSED' OR (balance = 0 AND held_balance = 0));
+
+CREATE TABLE wallet_status_changes (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT wallet_status_changes_from_valid CHECK (from_status IN ('ACTIVE', 'FROZEN', 'DEBIT_BLOCKED', 'CLOSED')),
+    CONSTRAINT wallet_status_changes_to_valid CHECK (to_status IN ('ACTIVE', 'FROZEN', 'DEBIT_BLOCKED', 'CLOSED')),
+    CONSTRAINT wallet_status_changes_changed CHECK (from_status <> to_status),
+
+    -- Foreign Keys
+    CONSTRAINT wallet_status_changes_wallet_fk FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_wallet_status_changes_wallet_id ON wallet_status_changes(wallet_id, created_at);
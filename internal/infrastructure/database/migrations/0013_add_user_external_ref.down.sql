@@ -0,0 +1,4 @@
+DROP INDEX idx_users_external_ref;
+
+ALTER TABLE users
+    DROP COLUMN external_ref;
@@ -0,0 +1,7 @@
+-- Users may carry a reference to their record in another system, unique
+-- among users. Names stay nullable for users inserted before registration
+-- required them.
+ALTER TABLE users
+    ADD COLUMN external_ref VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_users_external_ref ON users(external_ref) WHERE external_ref IS NOT NULL;
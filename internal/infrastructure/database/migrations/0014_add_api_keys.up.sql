@@ -0,0 +1,23 @@
+-- API keys let server-to-server clients sign their requests. The signing key
+-- derived from the secret is stored instead of the secret, but it is enough to
+-- sign requests: the column is as sensitive as the secret. 0018 seals it.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    signing_key BYTEA NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    -- Constraints
+    CONSTRAINT api_keys_signing_key_size CHECK (octet_length(signing_key) = 32),
+    CONSTRAINT api_keys_scopes_valid CHECK (
+        cardinality(scopes) > 0 AND scopes <@ ARRAY['balance:read', 'withdraw', 'admin']::TEXT[]
+    ),
+
+    -- Foreign Keys
+    CONSTRAINT api_keys_user_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id, created_at);
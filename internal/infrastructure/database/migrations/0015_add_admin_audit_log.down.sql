@@ -0,0 +1,11 @@
+-- Fails once an adjustment has been posted to the ledger or recorded as a
+-- transaction.
+DROP TABLE admin_audit_log;
+
+DELETE FROM ledger_accounts WHERE code = 'ADJUSTMENTS' AND kind = 'SYSTEM';
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_adjustment_reason;
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN', 'EXCHANGE_OUT', 'EXCHANGE_IN', 'REVERSAL'));
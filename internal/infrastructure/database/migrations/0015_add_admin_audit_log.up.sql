@@ -0,0 +1,33 @@
+-- Back-office staff correct balances with manual adjustments, each carrying a
+-- reason code in transactions.reason and booked against the ADJUSTMENTS
+-- system account.
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_valid
+    CHECK (transaction_type IN ('WITHDRAWAL', 'DEPOSIT', 'TRANSFER_OUT', 'TRANSFER_IN', 'EXCHANGE_OUT', 'EXCHANGE_IN', 'REVERSAL',
+                                'ADJUSTMENT_CREDIT', 'ADJUSTMENT_DEBIT'));
+
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_adjustment_reason CHECK (transaction_type NOT LIKE 'ADJUSTMENT_%' OR reason IS NOT NULL);
+
+INSERT INTO ledger_accounts (code, kind) VALUES
+    ('ADJUSTMENTS', 'SYSTEM');
+
+-- Every change made through the admin API is recorded with the staff member
+-- who made it. The log is append-only.
+CREATE TABLE admin_audit_log (
+    id UUID PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(40) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(100) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT admin_audit_log_target_type_valid CHECK (target_type IN ('WALLET', 'TIER', 'API_KEY'))
+);
+
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at, id);
+CREATE INDEX idx_admin_audit_log_target_id ON admin_audit_log(target_id, created_at);
+CREATE INDEX idx_admin_audit_log_actor ON admin_audit_log(actor, created_at);
@@ -0,0 +1,7 @@
+-- Fails once a withdrawal request decision has been audited.
+ALTER TABLE admin_audit_log DROP CONSTRAINT admin_audit_log_target_type_valid;
+ALTER TABLE admin_audit_log ADD CONSTRAINT admin_audit_log_target_type_valid
+    CHECK (target_type IN ('WALLET', 'TIER', 'API_KEY'));
+
+DROP TABLE withdrawal_request_events;
+DROP TABLE withdrawal_requests;
@@ -0,0 +1,53 @@
+-- Withdrawals above the approval threshold wait as requests, their amount
+-- held on the wallet, until someone other than the requester approves or
+-- rejects them, or they expire.
+CREATE TABLE withdrawal_requests (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    requested_by VARCHAR(255) NOT NULL,
+    decided_by VARCHAR(255) NOT NULL DEFAULT '',
+    transaction_id UUID,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT withdrawal_requests_amount_positive CHECK (amount > 0),
+    CONSTRAINT withdrawal_requests_status_valid CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'EXPIRED')),
+    CONSTRAINT withdrawal_requests_approval_recorded CHECK ((status = 'APPROVED') = (transaction_id IS NOT NULL)),
+    -- Maker-checker: nobody approves their own request.
+    CONSTRAINT withdrawal_requests_checker_differs CHECK (status <> 'APPROVED' OR decided_by <> requested_by),
+
+    -- Foreign Keys
+    CONSTRAINT withdrawal_requests_wallet_fk FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT withdrawal_requests_transaction_fk FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+CREATE INDEX idx_withdrawal_requests_created_at ON withdrawal_requests(created_at, id);
+CREATE INDEX idx_withdrawal_requests_wallet_id ON withdrawal_requests(wallet_id, created_at);
+CREATE INDEX idx_withdrawal_requests_pending_expires_at ON withdrawal_requests(expires_at) WHERE status = 'PENDING';
+
+-- The history of each request, from the request to its decision or expiry.
+CREATE TABLE withdrawal_request_events (
+    id UUID PRIMARY KEY,
+    request_id UUID NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT withdrawal_request_events_action_valid CHECK (action IN ('REQUESTED', 'APPROVED', 'REJECTED', 'EXPIRED')),
+
+    -- Foreign Keys
+    CONSTRAINT withdrawal_request_events_request_fk FOREIGN KEY (request_id) REFERENCES withdrawal_requests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_withdrawal_request_events_request_id ON withdrawal_request_events(request_id, occurred_at);
+
+ALTER TABLE admin_audit_log DROP CONSTRAINT admin_audit_log_target_type_valid;
+ALTER TABLE admin_audit_log ADD CONSTRAINT admin_audit_log_target_type_valid
+    CHECK (target_type IN ('WALLET', 'TIER', 'API_KEY', 'WITHDRAWAL_REQUEST'));
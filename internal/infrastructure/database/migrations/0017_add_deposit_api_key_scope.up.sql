@@ -0,0 +1,5 @@
+-- API keys of payment processors may credit wallets with the deposit scope.
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_scopes_valid;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_scopes_valid CHECK (
+    cardinality(scopes) > 0 AND scopes <@ ARRAY['balance:read', 'withdraw', 'deposit', 'admin']::TEXT[]
+);
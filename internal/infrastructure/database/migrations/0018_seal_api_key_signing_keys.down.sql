@@ -0,0 +1,3 @@
+-- Fails while a key is sealed; sealed keys can only be opened by the service.
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_signing_key_size;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_signing_key_size CHECK (octet_length(signing_key) = 32);
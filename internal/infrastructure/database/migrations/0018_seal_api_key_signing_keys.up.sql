@@ -0,0 +1,6 @@
+-- Signing keys are enough to sign requests, so they are stored sealed with
+-- AES-256-GCM under API_KEY_ENCRYPTION_KEY: a 12 byte nonce, the 32 byte key
+-- and a 16 byte tag. Keys stored in clear before are sealed by the service
+-- when it starts.
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_signing_key_size;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_signing_key_size CHECK (octet_length(signing_key) IN (32, 60));
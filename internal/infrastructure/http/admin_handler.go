@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/service"
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// AdminHandler serves the back-office routes that are not configuration:
+// wallet search, transaction lookup, manual adjustments and the audit trail.
+type AdminHandler struct {
+	adminService      service.AdminService
+	adjustmentUseCase usecase.AdjustmentUseCase
+	validator         *validator.Validate
+}
+
+func NewAdminHandler(adminService service.AdminService, adjustmentUseCase usecase.AdjustmentUseCase) *AdminHandler {
+	return &AdminHandler{
+		adminService:      adminService,
+		adjustmentUseCase: adjustmentUseCase,
+		validator:         newValidator(),
+	}
+}
+
+type AdjustmentRequest struct {
+	Direction  string `json:"direction" validate:"required,oneof=CREDIT DEBIT"`
+	Amount     int64  `json:"amount" validate:"required,gt=0"`
+	Currency   string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	ReasonCode string `json:"reason_code" validate:"required,oneof=CORRECTION GOODWILL FEE_REFUND CHARGEBACK FRAUD_RECOVERY OTHER"`
+	Note       string `json:"note,omitempty" validate:"max=200"`
+}
+
+func (h *AdminHandler) HandleAdjustBalance(w http.ResponseWriter, r *http.Request) {
+	walletID := mux.Vars(r)["wallet_id"]
+
+	if err := h.validator.Var(walletID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	var req AdjustmentRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	direction, err := entity.ParseAdjustmentDirection(req.Direction)
+	if err != nil {
+		writeFieldProblem(w, r, "direction", "Unknown adjustment direction")
+		return
+	}
+
+	reason, err := entity.ParseAdjustmentReason(req.ReasonCode)
+	if err != nil {
+		writeFieldProblem(w, r, "reason_code", "Unknown reason code")
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adjustmentUseCase.Adjust(ctx, walletIDVO, direction, amountVO, reason, req.Note)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+// HandleSearchWallets lists the wallets of all users. status and tier accept
+// repeated parameters as well as comma separated values.
+func (h *AdminHandler) HandleSearchWallets(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := dto.WalletSearchQuery{
+		UserID:   values.Get("user_id"),
+		Statuses: splitQueryValues(values["status"]),
+		Tiers:    splitQueryValues(values["tier"]),
+		Currency: values.Get("currency"),
+		Name:     values.Get("name"),
+		Cursor:   values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = parseLimit(values); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adminService.SearchWallets(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *AdminHandler) HandleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	transactionID := mux.Vars(r)["transaction_id"]
+
+	if err := h.validator.Var(transactionID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "transaction_id", "Invalid transaction ID format")
+		return
+	}
+
+	transactionIDVO, err := valueobject.NewUserID(transactionID)
+	if err != nil {
+		writeFieldProblem(w, r, "transaction_id", "Invalid transaction ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adminService.GetTransaction(ctx, transactionIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// HandleListAuditEntries lists the audit trail, newest first. action accepts
+// repeated parameters as well as comma separated values.
+func (h *AdminHandler) HandleListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	query, err := parseAuditLogQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adminService.ListAuditEntries(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func parseAuditLogQuery(values url.Values) (dto.AuditLogQuery, error) {
+	query := dto.AuditLogQuery{
+		Actor:    strings.TrimSpace(values.Get("actor")),
+		Actions:  splitQueryValues(values["action"]),
+		TargetID: strings.TrimSpace(values.Get("target_id")),
+		Cursor:   values.Get("cursor"),
+	}
+
+	var err error
+	if query.From, err = parseOptionalTime(values, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseOptionalTime(values, "to"); err != nil {
+		return query, err
+	}
+	if query.Limit, err = parseLimit(values); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func parseLimit(values url.Values) (int, error) {
+	raw := values.Get("limit")
+	if raw == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		return 0, domain.NewValidationError("limit", "limit must be a positive integer")
+	}
+	return limit, nil
+}
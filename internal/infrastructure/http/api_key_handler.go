@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	validator     *validator.Validate
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		validator:     newValidator(),
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	UserID string   `json:"user_id" validate:"required,uuid"`
+	Name   string   `json:"name" validate:"required,max=50"`
+	Scopes []string `json:"scopes" validate:"required,dive,oneof=balance:read withdraw deposit admin"`
+}
+
+func (h *APIKeyHandler) HandleIssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	userIDVO, err := valueobject.NewUserID(req.UserID)
+	if err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.apiKeyService.IssueAPIKey(ctx, userIDVO, req.Name, req.Scopes)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// The secret must not linger in caches along the way.
+	w.Header().Set("Cache-Control", "no-store")
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+func (h *APIKeyHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID := mux.Vars(r)["key_id"]
+
+	keyIDVO, err := valueobject.NewUserID(keyID)
+	if err != nil {
+		writeFieldProblem(w, r, "key_id", "Invalid API key ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.apiKeyService.RevokeAPIKey(ctx, keyIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *APIKeyHandler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+
+	userIDVO, err := valueobject.NewUserID(userID)
+	if err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.apiKeyService.ListAPIKeys(ctx, userIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
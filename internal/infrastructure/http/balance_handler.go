@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"bank/internal/domain/service"
-	"bank/internal/domain/valueobject"
 
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
@@ -20,50 +19,44 @@ type BalanceHandler struct {
 func NewBalanceHandler(walletService service.BalanceService) *BalanceHandler {
 	return &BalanceHandler{
 		balanceService: walletService,
-		validator:      validator.New(),
+		validator:      newValidator(),
 	}
 }
 
 func (h *BalanceHandler) HandleGetBalance(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "missing_parameter",
-			Message: "user_id query parameter is required",
-		})
+	walletID := r.URL.Query().Get("wallet_id")
+	if userID == "" && walletID == "" {
+		writeProblem(w, r, http.StatusBadRequest, problemMissingParameter, "wallet_id or user_id query parameter is required")
+		return
+	}
+	if userID != "" && walletID != "" {
+		writeFieldProblem(w, r, "user_id", "user_id must not be combined with wallet_id")
 		return
 	}
 
-	if err := h.validator.Var(userID, "required,uuid"); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid user ID format",
-		})
+	if walletID != "" {
+		if err := h.validator.Var(walletID, "uuid"); err != nil {
+			writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+			return
+		}
+	} else if err := h.validator.Var(userID, "uuid"); err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
 		return
 	}
 
-	userIDVO, err := valueobject.NewUserID(userID)
+	walletRef, err := requestWalletRef(userID, walletID)
 	if err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid user ID format",
-		})
+		writeError(w, r, err)
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	response, err := h.balanceService.GetBalance(ctx, userIDVO)
+	response, err := h.balanceService.GetBalance(ctx, walletRef)
 	if err != nil {
-		render.Status(r, http.StatusInternalServerError)
-		render.JSON(w, r, ErrorResponse{
-			Error:   err.Error(),
-			Message: "An unexpected error occurred",
-		})
+		writeError(w, r, err)
 		return
 	}
 
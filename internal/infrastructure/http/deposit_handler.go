@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type DepositHandler struct {
+	depositUseCase usecase.DepositUseCase
+	validator      *validator.Validate
+}
+
+func NewDepositHandler(depositUseCase usecase.DepositUseCase) *DepositHandler {
+	return &DepositHandler{
+		depositUseCase: depositUseCase,
+		validator:      newValidator(),
+	}
+}
+
+type DepositRequest struct {
+	UserID   string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount   int64  `json:"amount" validate:"required,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+func (h *DepositHandler) HandleDeposit(w http.ResponseWriter, r *http.Request) {
+	var req DepositRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	walletRef, err := requestWalletRef(req.UserID, req.WalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.depositUseCase.Deposit(ctx, walletRef, amountVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
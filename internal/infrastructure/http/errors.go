@@ -0,0 +1,239 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"bank/internal/domain"
+)
+
+const (
+	// problemContentType is the media type of every error response.
+	problemContentType = "application/problem+json"
+
+	// problemTypeBase prefixes the problem type slugs below to form the
+	// type URI of a problem document.
+	problemTypeBase = "/problems/"
+)
+
+// Problem type slugs. Clients should branch on the type URI, not the title or
+// detail.
+const (
+	problemInvalidRequest            = "invalid-request"
+	problemMissingParameter          = "missing-parameter"
+	problemValidation                = "validation-error"
+	problemInvalidIdempotencyKey     = "invalid-idempotency-key"
+	problemUnsupportedMediaType      = "unsupported-media-type"
+	problemUnauthorized              = "unauthorized"
+	problemForbidden                 = "forbidden"
+	problemWalletNotFound            = "wallet-not-found"
+	problemTransactionNotFound       = "transaction-not-found"
+	problemUserNotFound              = "user-not-found"
+	problemWalletAlreadyExists       = "wallet-already-exists"
+	problemUserAlreadyExists         = "user-already-exists"
+	problemQuoteNotFound             = "quote-not-found"
+	problemQuoteExpired              = "quote-expired"
+	problemQuoteAlreadyUsed          = "quote-already-used"
+	problemHoldNotFound              = "hold-not-found"
+	problemWithdrawalRequestNotFound = "withdrawal-request-not-found"
+	problemAPIKeyNotFound            = "api-key-not-found"
+	problemAPIKeyRevoked             = "api-key-revoked"
+	problemHoldExpired               = "hold-expired"
+	problemWithdrawalRequestExpired  = "withdrawal-request-expired"
+	problemSelfApproval              = "self-approval"
+	problemAlreadyReversed           = "transaction-already-reversed"
+	problemNotReversible             = "transaction-not-reversible"
+	problemRateUnavailable           = "rate-unavailable"
+	problemWalletOwnerMismatch       = "wallet-owner-mismatch"
+	problemInsufficientFunds         = "insufficient-funds"
+	problemLimitExceeded             = "limit-exceeded"
+	problemApprovalRequired          = "approval-required"
+	problemBalanceOverflow           = "balance-overflow"
+	problemWalletFrozen              = "wallet-frozen"
+	problemWalletDebitBlocked        = "wallet-debit-blocked"
+	problemWalletClosed              = "wallet-closed"
+	problemWalletNotEmpty            = "wallet-not-empty"
+	problemCurrencyMismatch          = "currency-mismatch"
+	problemInvalidStatusTransition   = "invalid-status-transition"
+	problemIdempotencyKeyReused      = "idempotency-key-reused"
+	problemRateLimited               = "rate-limited"
+	problemRequestTimeout            = "request-timeout"
+	problemInternal                  = "internal-error"
+)
+
+// problemTitles holds the fixed, human readable summary of each problem type.
+var problemTitles = map[string]string{
+	problemInvalidRequest:            "Malformed request body",
+	problemMissingParameter:          "Missing required parameter",
+	problemValidation:                "Request validation failed",
+	problemInvalidIdempotencyKey:     "Invalid Idempotency-Key",
+	problemUnsupportedMediaType:      "Unsupported media type",
+	problemUnauthorized:              "Authentication required",
+	problemForbidden:                 "Access denied",
+	problemWalletNotFound:            "Wallet not found",
+	problemTransactionNotFound:       "Transaction not found",
+	problemUserNotFound:              "User not found",
+	problemWalletAlreadyExists:       "Wallet already exists",
+	problemUserAlreadyExists:         "User already exists",
+	problemQuoteNotFound:             "Exchange quote not found",
+	problemQuoteExpired:              "Exchange quote expired",
+	problemQuoteAlreadyUsed:          "Exchange quote already used",
+	problemHoldNotFound:              "Hold not found",
+	problemWithdrawalRequestNotFound: "Withdrawal request not found",
+	problemAPIKeyNotFound:            "API key not found",
+	problemAPIKeyRevoked:             "API key already revoked",
+	problemHoldExpired:               "Hold expired",
+	problemWithdrawalRequestExpired:  "Withdrawal request expired",
+	problemSelfApproval:              "Self-approval not allowed",
+	problemAlreadyReversed:           "Transaction already reversed",
+	problemNotReversible:             "Transaction cannot be reversed",
+	problemRateUnavailable:           "Exchange rate unavailable",
+	problemWalletOwnerMismatch:       "Wallets belong to different users",
+	problemInsufficientFunds:         "Insufficient funds",
+	problemLimitExceeded:             "Withdrawal limit exceeded",
+	problemApprovalRequired:          "Approval required",
+	problemBalanceOverflow:           "Balance overflow",
+	problemWalletFrozen:              "Wallet is frozen",
+	problemWalletDebitBlocked:        "Wallet is blocked for debits",
+	problemWalletClosed:              "Wallet is closed",
+	problemWalletNotEmpty:            "Wallet is not empty",
+	problemCurrencyMismatch:          "Currency mismatch",
+	problemInvalidStatusTransition:   "Invalid status transition",
+	problemIdempotencyKeyReused:      "Idempotency-Key reused",
+	problemRateLimited:               "Too many requests",
+	problemRequestTimeout:            "Request timeout",
+	problemInternal:                  "Internal server error",
+}
+
+// errorMapping translates a domain error into a problem document. An empty
+// detail means the error's own message is safe to show to clients.
+type errorMapping struct {
+	target      error
+	status      int
+	problemType string
+	detail      string
+}
+
+// errorMappings is the single table every handler uses to turn use case
+// errors into responses. The first entry matching with errors.Is wins.
+var errorMappings = []errorMapping{
+	{domain.ErrUnauthenticated, http.StatusUnauthorized, problemUnauthorized, "A valid bearer token is required"},
+	{domain.ErrForbidden, http.StatusForbidden, problemForbidden, "The caller may not act on this resource"},
+	{domain.ErrWalletNotFound, http.StatusNotFound, problemWalletNotFound, "No wallet exists for the requested user"},
+	{domain.ErrTransactionNotFound, http.StatusNotFound, problemTransactionNotFound, "No transaction exists with the requested ID"},
+	{domain.ErrUserNotFound, http.StatusNotFound, problemUserNotFound, "No user exists with the requested ID"},
+	{domain.ErrQuoteNotFound, http.StatusNotFound, problemQuoteNotFound, "No exchange quote exists with the requested ID"},
+	{domain.ErrHoldNotFound, http.StatusNotFound, problemHoldNotFound, "No hold exists with the requested ID"},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, problemAPIKeyNotFound, "No API key exists with the requested ID"},
+	{domain.ErrWithdrawalRequestNotFound, http.StatusNotFound, problemWithdrawalRequestNotFound, "No withdrawal request exists with the requested ID"},
+	{domain.ErrSelfApproval, http.StatusForbidden, problemSelfApproval, "A withdrawal must be approved by someone other than its requester"},
+	{domain.ErrWalletAlreadyExists, http.StatusConflict, problemWalletAlreadyExists, "The user already has a wallet with this name"},
+	{domain.ErrUserAlreadyExists, http.StatusConflict, problemUserAlreadyExists, "Another user already has this external reference"},
+	{domain.ErrQuoteAlreadyUsed, http.StatusConflict, problemQuoteAlreadyUsed, "The exchange quote has already been redeemed"},
+	{domain.ErrTransactionAlreadyReversed, http.StatusConflict, problemAlreadyReversed, "The transaction has already been reversed"},
+	{domain.ErrAPIKeyRevoked, http.StatusConflict, problemAPIKeyRevoked, "The API key has already been revoked"},
+	{domain.ErrInvalidUserID, http.StatusBadRequest, problemValidation, "Invalid user ID format"},
+	{domain.ErrNegativeAmount, http.StatusBadRequest, problemValidation, "Invalid amount"},
+	{domain.ErrUnsupportedCurrency, http.StatusBadRequest, problemValidation, "Unsupported currency"},
+	{domain.ErrSameWallet, http.StatusBadRequest, problemValidation, ""},
+	{domain.ErrInsufficientFunds, http.StatusUnprocessableEntity, problemInsufficientFunds, "The wallet balance does not cover the requested amount"},
+	{domain.ErrAmountOverflow, http.StatusUnprocessableEntity, problemBalanceOverflow, "Operation would exceed the maximum wallet balance"},
+	{domain.ErrWalletFrozen, http.StatusUnprocessableEntity, problemWalletFrozen, "The wallet is frozen and cannot move money"},
+	{domain.ErrWalletDebitBlocked, http.StatusUnprocessableEntity, problemWalletDebitBlocked, "The wallet can receive money but cannot pay it out"},
+	{domain.ErrWalletClosed, http.StatusUnprocessableEntity, problemWalletClosed, "The wallet is closed and cannot move money"},
+	{domain.ErrWalletNotEmpty, http.StatusUnprocessableEntity, problemWalletNotEmpty, "Only a wallet with a zero balance and no holds can be closed"},
+	{domain.ErrCurrencyMismatch, http.StatusUnprocessableEntity, problemCurrencyMismatch, "The amount is not in the wallet's currency"},
+	{domain.ErrQuoteExpired, http.StatusUnprocessableEntity, problemQuoteExpired, "The exchange quote has expired; request a new quote"},
+	{domain.ErrRateUnavailable, http.StatusUnprocessableEntity, problemRateUnavailable, "No exchange rate is available between the wallets' currencies"},
+	{domain.ErrWalletOwnerMismatch, http.StatusUnprocessableEntity, problemWalletOwnerMismatch, "Exchanges are only possible between wallets of the same user"},
+	{domain.ErrHoldExpired, http.StatusUnprocessableEntity, problemHoldExpired, "The hold has expired and can no longer be captured"},
+	{domain.ErrApprovalRequired, http.StatusUnprocessableEntity, problemApprovalRequired, "Amounts above the approval threshold must be withdrawn through /withdraw, which waits for approval"},
+	{domain.ErrWithdrawalRequestExpired, http.StatusUnprocessableEntity, problemWithdrawalRequestExpired, "The withdrawal request has expired and can no longer be approved"},
+	{domain.ErrTransactionNotReversible, http.StatusUnprocessableEntity, problemNotReversible, "Only completed withdrawals can be reversed"},
+	{domain.ErrInvalidStatusTransition, http.StatusConflict, problemInvalidStatusTransition, ""},
+	{domain.ErrInvalidWalletStatusTransition, http.StatusConflict, problemInvalidStatusTransition, ""},
+	{domain.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, problemIdempotencyKeyReused, "Idempotency-Key was already used for a different request"},
+}
+
+// newProblem builds the problem document for r. The title comes from the
+// problem type so that it is identical for every occurrence.
+func newProblem(r *http.Request, status int, problemType, detail string) Problem {
+	title, ok := problemTitles[problemType]
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	return Problem{
+		Type:      problemTypeBase + problemType,
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: requestIDFromContext(r.Context()),
+	}
+}
+
+// writeProblem renders a problem document. render.JSON is not used because it
+// forces the application/json content type.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, problemType, detail string) {
+	renderProblem(w, newProblem(r, status, problemType, detail))
+}
+
+func renderProblem(w http.ResponseWriter, problem Problem) {
+	body, err := json.Marshal(problem)
+	if err != nil {
+		log.Printf("❌ Failed to encode problem response: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	w.Write(body) //nolint:errcheck
+}
+
+// writeError renders err using errorMappings. Validation errors are reported
+// with their own message and exceeded limits with the limit that was broken;
+// unknown errors become a generic 500 and are logged.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		problem := newProblem(r, http.StatusBadRequest, problemValidation, validationErr.Message)
+		if validationErr.Field != "" {
+			problem.Errors = []FieldError{{Field: validationErr.Field, Message: validationErr.Message}}
+		}
+		renderProblem(w, problem)
+		return
+	}
+
+	var limitErr *domain.LimitExceededError
+	if errors.As(err, &limitErr) {
+		problem := newProblem(r, http.StatusUnprocessableEntity, problemLimitExceeded, "The withdrawal is not allowed by the wallet's "+limitErr.Limit+" limit")
+		problem.Limit = &LimitViolation{
+			Name:      limitErr.Limit,
+			Threshold: limitErr.Threshold,
+			Remaining: limitErr.Remaining,
+		}
+		renderProblem(w, problem)
+		return
+	}
+
+	for _, mapping := range errorMappings {
+		if !errors.Is(err, mapping.target) {
+			continue
+		}
+
+		detail := mapping.detail
+		if detail == "" {
+			detail = err.Error()
+		}
+
+		writeProblem(w, r, mapping.status, mapping.problemType, detail)
+		return
+	}
+
+	log.Printf("❌ Unhandled error for %s %s: %v", r.Method, r.URL.Path, err)
+	writeProblem(w, r, http.StatusInternalServerError, problemInternal, "An unexpected error occurred")
+}
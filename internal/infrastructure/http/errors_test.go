@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bank/internal/domain"
+)
+
+// decodeProblem checks that the response is a problem document and decodes it.
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	if contentType := recorder.Header().Get("Content-Type"); contentType != problemContentType {
+		t.Errorf("expected content type %s, got %q", problemContentType, contentType)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("expected a JSON problem document, got %q: %v", recorder.Body.String(), err)
+	}
+	if problem.Status != recorder.Code {
+		t.Errorf("expected the status %d in the body, got %d", recorder.Code, problem.Status)
+	}
+	return problem
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantTitle  string
+	}{
+		{name: "should map a missing token to 401", err: domain.ErrUnauthenticated, wantStatus: http.StatusUnauthorized, wantType: "/problems/unauthorized", wantTitle: "Authentication required"},
+		{name: "should map a foreign wallet to 403", err: domain.ErrForbidden, wantStatus: http.StatusForbidden, wantType: "/problems/forbidden", wantTitle: "Access denied"},
+		{name: "should map a missing wallet to 404", err: domain.ErrWalletNotFound, wantStatus: http.StatusNotFound, wantType: "/problems/wallet-not-found", wantTitle: "Wallet not found"},
+		{name: "should map insufficient funds to 422", err: domain.ErrInsufficientFunds, wantStatus: http.StatusUnprocessableEntity, wantType: "/problems/insufficient-funds", wantTitle: "Insufficient funds"},
+		{name: "should map a wrapped error like the error it wraps", err: fmt.Errorf("withdraw: %w", domain.ErrInsufficientFunds), wantStatus: http.StatusUnprocessableEntity, wantType: "/problems/insufficient-funds", wantTitle: "Insufficient funds"},
+		{name: "should map a hold above the approval threshold to 422", err: domain.ErrApprovalRequired, wantStatus: http.StatusUnprocessableEntity, wantType: "/problems/approval-required", wantTitle: "Approval required"},
+		{name: "should map a reused idempotency key to 422", err: domain.ErrIdempotencyKeyReused, wantStatus: http.StatusUnprocessableEntity, wantType: "/problems/idempotency-key-reused", wantTitle: "Idempotency-Key reused"},
+		{name: "should map an invalid transition to 409", err: domain.ErrInvalidStatusTransition, wantStatus: http.StatusConflict, wantType: "/problems/invalid-status-transition", wantTitle: "Invalid status transition"},
+		{name: "should map an unknown error to 500", err: errors.New("connection reset"), wantStatus: http.StatusInternalServerError, wantType: "/problems/internal-error", wantTitle: "Internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/withdraw", nil)
+			request = request.WithContext(context.WithValue(request.Context(), requestIDKey{}, "req-1"))
+
+			// Act
+			writeError(recorder, request, tt.err)
+
+			// Assert
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, recorder.Code)
+			}
+			problem := decodeProblem(t, recorder)
+			if problem.Type != tt.wantType || problem.Title != tt.wantTitle {
+				t.Errorf("expected %s %q, got %s %q", tt.wantType, tt.wantTitle, problem.Type, problem.Title)
+			}
+			if problem.Instance != "/withdraw" || problem.RequestID != "req-1" {
+				t.Errorf("expected the path and request ID, got %q and %q", problem.Instance, problem.RequestID)
+			}
+			if problem.Detail == "" {
+				t.Error("expected a detail")
+			}
+		})
+	}
+
+	t.Run("should not reveal the message of an unknown error", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// Act
+		writeError(recorder, httptest.NewRequest("GET", "/balance", nil), errors.New("password authentication failed for user bank"))
+
+		// Assert
+		if problem := decodeProblem(t, recorder); problem.Detail != "An unexpected error occurred" {
+			t.Errorf("expected a generic detail, got %q", problem.Detail)
+		}
+	})
+
+	t.Run("should report the field of a validation error", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+
+		// Act
+		writeError(recorder, httptest.NewRequest("POST", "/withdraw", nil), domain.NewValidationError("amount", "amount must be positive"))
+
+		// Assert
+		problem := decodeProblem(t, recorder)
+		if recorder.Code != http.StatusBadRequest || problem.Type != "/problems/validation-error" {
+			t.Errorf("expected a 400 validation-error, got %d %s", recorder.Code, problem.Type)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "amount" {
+			t.Errorf("expected the amount field, got %+v", problem.Errors)
+		}
+	})
+
+	t.Run("should describe the limit that was exceeded", func(t *testing.T) {
+		// Arrange
+		recorder := httptest.NewRecorder()
+		err := fmt.Errorf("withdraw: %w", &domain.LimitExceededError{Limit: "daily", Threshold: 100000, Remaining: 2500})
+
+		// Act
+		writeError(recorder, httptest.NewRequest("POST", "/withdraw", nil), err)
+
+		// Assert
+		problem := decodeProblem(t, recorder)
+		if recorder.Code != http.StatusUnprocessableEntity || problem.Type != "/problems/limit-exceeded" {
+			t.Errorf("expected a 422 limit-exceeded, got %d %s", recorder.Code, problem.Type)
+		}
+		if problem.Limit == nil || problem.Limit.Name != "daily" || problem.Limit.Remaining != 2500 {
+			t.Errorf("expected the daily limit with 2500 remaining, got %+v", problem.Limit)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExchangeHandler struct {
+	exchangeUseCase usecase.ExchangeUseCase
+	validator       *validator.Validate
+}
+
+func NewExchangeHandler(exchangeUseCase usecase.ExchangeUseCase) *ExchangeHandler {
+	return &ExchangeHandler{
+		exchangeUseCase: exchangeUseCase,
+		validator:       newValidator(),
+	}
+}
+
+type ExchangeQuoteRequest struct {
+	FromWalletID string `json:"from_wallet_id" validate:"required,uuid"`
+	ToWalletID   string `json:"to_wallet_id" validate:"required,uuid,nefield=FromWalletID"`
+	Amount       int64  `json:"amount" validate:"required,gt=0"`
+	Currency     string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type ExchangeRequest struct {
+	QuoteID string `json:"quote_id" validate:"required,uuid"`
+}
+
+func (h *ExchangeHandler) HandleQuote(w http.ResponseWriter, r *http.Request) {
+	var req ExchangeQuoteRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	fromWalletIDVO, err := valueobject.NewUserID(req.FromWalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "from_wallet_id", "Invalid source wallet ID format")
+		return
+	}
+
+	toWalletIDVO, err := valueobject.NewUserID(req.ToWalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "to_wallet_id", "Invalid target wallet ID format")
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.exchangeUseCase.Quote(ctx, fromWalletIDVO, toWalletIDVO, amountVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+func (h *ExchangeHandler) HandleExchange(w http.ResponseWriter, r *http.Request) {
+	var req ExchangeRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	quoteIDVO, err := valueobject.NewUserID(req.QuoteID)
+	if err != nil {
+		writeFieldProblem(w, r, "quote_id", "Invalid quote ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.exchangeUseCase.Exchange(ctx, quoteIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type HoldHandler struct {
+	holdUseCase usecase.HoldUseCase
+	validator   *validator.Validate
+}
+
+func NewHoldHandler(holdUseCase usecase.HoldUseCase) *HoldHandler {
+	return &HoldHandler{
+		holdUseCase: holdUseCase,
+		validator:   newValidator(),
+	}
+}
+
+type HoldRequest struct {
+	UserID           string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID         string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount           int64  `json:"amount" validate:"required,gt=0"`
+	Currency         string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds,omitempty" validate:"omitempty,gt=0"`
+}
+
+type CaptureHoldRequest struct {
+	Amount   int64  `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+func (h *HoldHandler) HandlePlaceHold(w http.ResponseWriter, r *http.Request) {
+	var req HoldRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	walletRef, err := requestWalletRef(req.UserID, req.WalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.holdUseCase.PlaceHold(ctx, walletRef, amountVO, time.Duration(req.ExpiresInSeconds)*time.Second)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/holds/"+response.HoldID)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+func (h *HoldHandler) HandleGetHold(w http.ResponseWriter, r *http.Request) {
+	holdIDVO, ok := h.pathHoldID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.holdUseCase.GetHold(ctx, holdIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// HandleCaptureHold accepts an empty body, which captures the whole hold.
+func (h *HoldHandler) HandleCaptureHold(w http.ResponseWriter, r *http.Request) {
+	holdIDVO, ok := h.pathHoldID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CaptureHoldRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	var amountVO *valueobject.Money
+	if req.Amount != 0 {
+		currencyVO, err := requestCurrency(req.Currency)
+		if err != nil {
+			writeFieldProblem(w, r, "currency", "Unsupported currency")
+			return
+		}
+
+		amount, err := valueobject.NewMoney(req.Amount, currencyVO)
+		if err != nil {
+			writeFieldProblem(w, r, "amount", "Invalid amount")
+			return
+		}
+		amountVO = &amount
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.holdUseCase.CaptureHold(ctx, holdIDVO, amountVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *HoldHandler) HandleReleaseHold(w http.ResponseWriter, r *http.Request) {
+	holdIDVO, ok := h.pathHoldID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.holdUseCase.ReleaseHold(ctx, holdIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *HoldHandler) pathHoldID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	holdID := mux.Vars(r)["hold_id"]
+
+	if err := h.validator.Var(holdID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "hold_id", "Invalid hold ID format")
+		return valueobject.UserID{}, false
+	}
+
+	holdIDVO, err := valueobject.NewUserID(holdID)
+	if err != nil {
+		writeFieldProblem(w, r, "hold_id", "Invalid hold ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return holdIDVO, true
+}
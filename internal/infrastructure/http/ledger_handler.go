@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type LedgerHandler struct {
+	ledgerService service.LedgerService
+	validator     *validator.Validate
+}
+
+func NewLedgerHandler(ledgerService service.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerService: ledgerService,
+		validator:     newValidator(),
+	}
+}
+
+func (h *LedgerHandler) HandleVerifyWallet(w http.ResponseWriter, r *http.Request) {
+	walletID := mux.Vars(r)["wallet_id"]
+
+	if err := h.validator.Var(walletID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	var response *dto.LedgerVerificationResponse
+	err = withPathWallet(walletIDVO, func(ref valueobject.WalletRef) error {
+		var err error
+		response, err = h.ledgerService.VerifyWalletBalance(ctx, ref)
+		return err
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *LedgerHandler) HandleTrialBalance(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	response, err := h.ledgerService.GetTrialBalance(ctx)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type LimitHandler struct {
+	limitService service.LimitService
+	validator    *validator.Validate
+}
+
+func NewLimitHandler(limitService service.LimitService) *LimitHandler {
+	return &LimitHandler{
+		limitService: limitService,
+		validator:    newValidator(),
+	}
+}
+
+// WithdrawalLimits holds limits in minor units; an omitted limit does not
+// apply, or keeps the tier's limit when it is a wallet override.
+type WithdrawalLimits struct {
+	MinAmount      *int64 `json:"min_amount,omitempty" validate:"omitempty,gt=0"`
+	PerTransaction *int64 `json:"per_transaction,omitempty" validate:"omitempty,gt=0"`
+	Daily          *int64 `json:"daily,omitempty" validate:"omitempty,gt=0"`
+	Monthly        *int64 `json:"monthly,omitempty" validate:"omitempty,gt=0"`
+}
+
+type SetTierLimitsRequest struct {
+	Currency string           `json:"currency" validate:"required,len=3,alpha"`
+	Limits   WithdrawalLimits `json:"limits"`
+}
+
+type SetWalletLimitsRequest struct {
+	Tier      string            `json:"tier,omitempty" validate:"omitempty,oneof=STANDARD PREMIUM"`
+	Overrides *WithdrawalLimits `json:"overrides,omitempty"`
+}
+
+func (h *LimitHandler) HandleListTierLimits(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.limitService.ListTierLimits(ctx)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *LimitHandler) HandleSetTierLimits(w http.ResponseWriter, r *http.Request) {
+	tier, err := entity.ParseWalletTier(mux.Vars(r)["tier"])
+	if err != nil {
+		writeFieldProblem(w, r, "tier", "Unknown wallet tier")
+		return
+	}
+
+	var req SetTierLimitsRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	currencyVO, err := valueobject.NewCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.limitService.SetTierLimits(ctx, tier, currencyVO, req.Limits.toEntity())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *LimitHandler) HandleGetWalletLimits(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.limitService.GetWalletLimits(ctx, walletIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *LimitHandler) HandleSetWalletLimits(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetWalletLimitsRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	var tier *entity.WalletTier
+	if req.Tier != "" {
+		parsed, err := entity.ParseWalletTier(req.Tier)
+		if err != nil {
+			writeFieldProblem(w, r, "tier", "Unknown wallet tier")
+			return
+		}
+		tier = &parsed
+	}
+
+	var overrides *entity.WithdrawalLimits
+	if req.Overrides != nil {
+		converted := req.Overrides.toEntity()
+		overrides = &converted
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.limitService.SetWalletLimits(ctx, walletIDVO, tier, overrides)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *LimitHandler) pathWalletID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	walletID := mux.Vars(r)["wallet_id"]
+
+	if err := h.validator.Var(walletID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return valueobject.UserID{}, false
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return walletIDVO, true
+}
+
+func (l WithdrawalLimits) toEntity() entity.WithdrawalLimits {
+	return entity.WithdrawalLimits{
+		MinAmount:      l.MinAmount,
+		PerTransaction: l.PerTransaction,
+		Daily:          l.Daily,
+		Monthly:        l.Monthly,
+	}
+}
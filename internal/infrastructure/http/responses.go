@@ -1,8 +1,31 @@
 package http
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+// Problem is an RFC 7807 problem details document. Every error response is
+// rendered as one with the application/problem+json media type.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Limit is set on limit-exceeded problems.
+	Limit *LimitViolation `json:"limit,omitempty"`
+}
+
+// LimitViolation describes the withdrawal limit an amount exceeded, in minor
+// units. Remaining is the largest amount the limit still allows.
+type LimitViolation struct {
+	Name      string `json:"name"`
+	Threshold int64  `json:"threshold"`
+	Remaining int64  `json:"remaining"`
+}
+
+// FieldError describes a single request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type HealthResponse struct {
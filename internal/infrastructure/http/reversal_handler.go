@@ -0,0 +1,87 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type ReversalHandler struct {
+	reversalUseCase usecase.ReversalUseCase
+	validator       *validator.Validate
+}
+
+func NewReversalHandler(reversalUseCase usecase.ReversalUseCase) *ReversalHandler {
+	return &ReversalHandler{
+		reversalUseCase: reversalUseCase,
+		validator:       newValidator(),
+	}
+}
+
+type ReverseTransactionRequest struct {
+	Amount   int64  `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+func (h *ReversalHandler) HandleReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	transactionID := mux.Vars(r)["transaction_id"]
+
+	if err := h.validator.Var(transactionID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "transaction_id", "Invalid transaction ID format")
+		return
+	}
+
+	transactionIDVO, err := valueobject.NewUserID(transactionID)
+	if err != nil {
+		writeFieldProblem(w, r, "transaction_id", "Invalid transaction ID format")
+		return
+	}
+
+	var req ReverseTransactionRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	var amountVO *valueobject.Money
+	if req.Amount != 0 {
+		currencyVO, err := requestCurrency(req.Currency)
+		if err != nil {
+			writeFieldProblem(w, r, "currency", "Unsupported currency")
+			return
+		}
+
+		amount, err := valueobject.NewMoney(req.Amount, currencyVO)
+		if err != nil {
+			writeFieldProblem(w, r, "amount", "Invalid amount")
+			return
+		}
+		amountVO = &amount
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.reversalUseCase.Reverse(ctx, transactionIDVO, amountVO, req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
@@ -1,30 +1,131 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"bank/internal/application/auth"
+	"bank/internal/application/idempotency"
+	"bank/internal/domain"
 	"bank/internal/domain/service"
 	"bank/internal/domain/usecase"
+	"bank/internal/infrastructure/ratelimit"
+	"bank/internal/infrastructure/signing"
 	"github.com/go-chi/render"
 	"github.com/gorilla/mux"
 )
 
+// Authenticator turns a bearer token into the principal it was issued to.
+type Authenticator interface {
+	Authenticate(token string) (auth.Principal, error)
+}
+
+// RequestVerifier checks the signature of a request signed with an API key
+// and returns the key's principal.
+type RequestVerifier interface {
+	VerifyRequest(ctx context.Context, r *http.Request, body []byte) (auth.Principal, error)
+}
+
+// RateLimiter decides whether a request on route may proceed. client
+// identifies the caller and wallet the wallet the request addresses, or is
+// empty; wallets are limited per caller, since nobody has checked yet that
+// the caller may use the wallet. ok is false when no limit applies.
+type RateLimiter interface {
+	WalletLimited(route string) bool
+	Allow(ctx context.Context, route, client, wallet string, now time.Time) (decision ratelimit.Decision, ok bool, err error)
+}
+
+// maxBufferedBodySize bounds the body read before the handler runs, to check
+// a request signature or find the wallet a request addresses.
+const maxBufferedBodySize = 1 << 20
+
+// requestTimeout bounds how long a request may run before it is answered with
+// a request-timeout problem.
+const requestTimeout = 60 * time.Second
+
+// apiKeyRoutes lists the calls API keys may make without the admin scope,
+// keyed by method and route template, with the scope each requires. Keys are
+// refused everywhere else.
+var apiKeyRoutes = map[string]string{
+	"GET /balance":   auth.ScopeBalanceRead,
+	"POST /withdraw": auth.ScopeWithdraw,
+	"POST /deposit":  auth.ScopeDeposit,
+}
+
 type Server struct {
 	router          *mux.Router
+	authenticator   Authenticator
+	requestVerifier RequestVerifier
+	rateLimiter     RateLimiter
+	requestTimeout  time.Duration
 	withdrawHandler *WithdrawHandler
+	depositHandler  *DepositHandler
+	transferHandler *TransferHandler
 	balanceHandler  *BalanceHandler
+	historyHandler  *TransactionHistoryHandler
+	ledgerHandler   *LedgerHandler
+	walletHandler   *WalletHandler
+	exchangeHandler *ExchangeHandler
+	holdHandler     *HoldHandler
+	reversalHandler *ReversalHandler
+	limitHandler    *LimitHandler
+	userHandler     *UserHandler
+	apiKeyHandler   *APIKeyHandler
+	adminHandler    *AdminHandler
+	approvalHandler *WithdrawalRequestHandler
 }
 
 func NewServer(
 	withdrawUseCase usecase.WithdrawUseCase,
+	depositUseCase usecase.DepositUseCase,
+	transferUseCase usecase.TransferUseCase,
 	balanceService service.BalanceService,
+	historyService service.TransactionHistoryService,
+	ledgerService service.LedgerService,
+	walletService service.WalletService,
+	exchangeUseCase usecase.ExchangeUseCase,
+	holdUseCase usecase.HoldUseCase,
+	reversalUseCase usecase.ReversalUseCase,
+	limitService service.LimitService,
+	userService service.UserService,
+	apiKeyService service.APIKeyService,
+	adminService service.AdminService,
+	adjustmentUseCase usecase.AdjustmentUseCase,
+	withdrawalApprovalUseCase usecase.WithdrawalApprovalUseCase,
+	authenticator Authenticator,
+	requestVerifier RequestVerifier,
+	rateLimiter RateLimiter,
 ) *Server {
 	server := &Server{
 		router:          mux.NewRouter(),
+		authenticator:   authenticator,
+		requestVerifier: requestVerifier,
+		rateLimiter:     rateLimiter,
+		requestTimeout:  requestTimeout,
 		withdrawHandler: NewWithdrawHandler(withdrawUseCase),
+		depositHandler:  NewDepositHandler(depositUseCase),
+		transferHandler: NewTransferHandler(transferUseCase),
 		balanceHandler:  NewBalanceHandler(balanceService),
+		historyHandler:  NewTransactionHistoryHandler(historyService),
+		ledgerHandler:   NewLedgerHandler(ledgerService),
+		walletHandler:   NewWalletHandler(walletService),
+		exchangeHandler: NewExchangeHandler(exchangeUseCase),
+		holdHandler:     NewHoldHandler(holdUseCase),
+		reversalHandler: NewReversalHandler(reversalUseCase),
+		limitHandler:    NewLimitHandler(limitService),
+		userHandler:     NewUserHandler(userService),
+		apiKeyHandler:   NewAPIKeyHandler(apiKeyService),
+		adminHandler:    NewAdminHandler(adminService, adjustmentUseCase),
+		approvalHandler: NewWithdrawalRequestHandler(adminService, withdrawalApprovalUseCase),
 	}
 
 	server.setupRoutes()
@@ -32,17 +133,68 @@ func NewServer(
 }
 
 func (s *Server) setupRoutes() {
-	// Apply middleware
+	// Apply middleware. The request ID comes first so that every error
+	// response, including recovered panics, can carry it.
+	s.router.Use(s.requestIDMiddleware)
 	s.router.Use(s.loggingMiddleware)
 	s.router.Use(s.recoveryMiddleware)
-	s.router.Use(s.requestIDMiddleware)
+	s.router.Use(s.authMiddleware)
+	s.router.Use(s.rateLimitMiddleware)
+	s.router.Use(s.idempotencyKeyMiddleware)
 	s.router.Use(s.timeoutMiddleware)
 	s.router.Use(s.contentTypeMiddleware)
 
 	// Health check endpoint
 	s.router.HandleFunc("/health", s.healthHandler).Methods("GET")
 	s.router.HandleFunc("/withdraw", s.withdrawHandler.HandleWithdraw).Methods("POST")
+	s.router.HandleFunc("/deposit", s.requireRoleOrScope(s.depositHandler.HandleDeposit, auth.ScopeOperator, auth.ScopeDeposit)).Methods("POST")
+	s.router.HandleFunc("/transfers", s.transferHandler.HandleTransfer).Methods("POST")
+	s.router.HandleFunc("/exchange/quotes", s.exchangeHandler.HandleQuote).Methods("POST")
+	s.router.HandleFunc("/exchange", s.exchangeHandler.HandleExchange).Methods("POST")
+	s.router.HandleFunc("/holds", s.holdHandler.HandlePlaceHold).Methods("POST")
+	s.router.HandleFunc("/holds/{hold_id}", s.holdHandler.HandleGetHold).Methods("GET")
+	s.router.HandleFunc("/holds/{hold_id}/capture", s.holdHandler.HandleCaptureHold).Methods("POST")
+	s.router.HandleFunc("/holds/{hold_id}/release", s.holdHandler.HandleReleaseHold).Methods("POST")
+	s.router.HandleFunc("/transactions/{transaction_id}/reverse", s.requireRole(s.reversalHandler.HandleReverseTransaction, auth.ScopeOperator)).Methods("POST")
 	s.router.HandleFunc("/balance", s.balanceHandler.HandleGetBalance).Methods("GET")
+	s.router.HandleFunc("/users", s.requireRole(s.userHandler.HandleCreateUser, auth.ScopeOperator)).Methods("POST")
+	s.router.HandleFunc("/users/{user_id}", s.userHandler.HandleGetUser).Methods("GET")
+	s.router.HandleFunc("/users/{user_id}/wallets", s.walletHandler.HandleListWallets).Methods("GET")
+	s.router.HandleFunc("/users/{user_id}/wallets", s.walletHandler.HandleCreateWallet).Methods("POST")
+	s.router.HandleFunc("/wallets/{wallet_id}", s.walletHandler.HandleGetWallet).Methods("GET")
+	s.router.HandleFunc("/wallets/{wallet_id}/transactions", s.historyHandler.HandleListTransactions).Methods("GET")
+	s.router.HandleFunc("/wallets/{wallet_id}/ledger/verify", s.ledgerHandler.HandleVerifyWallet).Methods("GET")
+	s.router.HandleFunc("/ledger/trial-balance", s.requireRole(s.ledgerHandler.HandleTrialBalance, auth.ScopeOperator)).Methods("GET")
+
+	s.setupAdminRoutes(s.router.PathPrefix("/admin").Subrouter())
+}
+
+// setupAdminRoutes registers the back-office routes. Each requires a role,
+// which includes the roles ranked below it: viewers may look things up,
+// operators may also adjust balances and freeze wallets, and supervisors may
+// also change limits and read the audit trail. Operators decide withdrawal
+// requests, though never their own. Wallet statuses check the
+// role needed for the target status in the service. API keys are credentials
+// and stay with administrators.
+func (s *Server) setupAdminRoutes(admin *mux.Router) {
+	admin.HandleFunc("/wallets", s.requireRole(s.adminHandler.HandleSearchWallets, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/wallets/{wallet_id}/adjustments", s.requireRole(s.adminHandler.HandleAdjustBalance, auth.ScopeOperator)).Methods("POST")
+	admin.HandleFunc("/wallets/{wallet_id}/status", s.requireRole(s.walletHandler.HandleChangeStatus, auth.ScopeOperator)).Methods("POST")
+	admin.HandleFunc("/wallets/{wallet_id}/status-history", s.requireRole(s.walletHandler.HandleStatusHistory, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/wallets/{wallet_id}/limits", s.requireRole(s.limitHandler.HandleGetWalletLimits, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/wallets/{wallet_id}/limits", s.requireRole(s.limitHandler.HandleSetWalletLimits, auth.ScopeSupervisor)).Methods("PUT")
+	admin.HandleFunc("/wallets/{wallet_id}/overdraft", s.requireRole(s.walletHandler.HandleSetOverdraftLimit, auth.ScopeSupervisor)).Methods("PUT")
+	admin.HandleFunc("/transactions/{transaction_id}", s.requireRole(s.adminHandler.HandleGetTransaction, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/limits/tiers", s.requireRole(s.limitHandler.HandleListTierLimits, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/limits/tiers/{tier}", s.requireRole(s.limitHandler.HandleSetTierLimits, auth.ScopeSupervisor)).Methods("PUT")
+	admin.HandleFunc("/withdrawal-requests", s.requireRole(s.approvalHandler.HandleListRequests, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/withdrawal-requests/{request_id}", s.requireRole(s.approvalHandler.HandleGetRequest, auth.ScopeViewer)).Methods("GET")
+	admin.HandleFunc("/withdrawal-requests/{request_id}/approve", s.requireRole(s.approvalHandler.HandleApprove, auth.ScopeOperator)).Methods("POST")
+	admin.HandleFunc("/withdrawal-requests/{request_id}/reject", s.requireRole(s.approvalHandler.HandleReject, auth.ScopeOperator)).Methods("POST")
+	admin.HandleFunc("/audit", s.requireRole(s.adminHandler.HandleListAuditEntries, auth.ScopeSupervisor)).Methods("GET")
+	admin.HandleFunc("/api-keys", s.requireScope(s.apiKeyHandler.HandleIssueAPIKey, auth.ScopeAdmin)).Methods("POST")
+	admin.HandleFunc("/api-keys/{key_id}/revoke", s.requireScope(s.apiKeyHandler.HandleRevokeAPIKey, auth.ScopeAdmin)).Methods("POST")
+	admin.HandleFunc("/users/{user_id}/api-keys", s.requireScope(s.apiKeyHandler.HandleListAPIKeys, auth.ScopeAdmin)).Methods("GET")
 }
 
 // GetRouter returns the gorilla mux router
@@ -64,7 +216,7 @@ func (s *Server) recoveryMiddleware(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				log.Printf("Panic: %v", err)
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, problemInternal, "An unexpected error occurred")
 			}
 		}()
 		next.ServeHTTP(w, r)
@@ -78,12 +230,308 @@ func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
 			requestID = generateRequestID()
 		}
 		w.Header().Set("X-Request-ID", requestID)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID)))
+	})
+}
+
+type requestIDKey struct{}
+
+// requestIDFromContext returns the ID assigned by requestIDMiddleware.
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// authMiddleware authenticates every request but the health check and
+// attaches the caller to the request context, where the use cases check what
+// it may act on. Requests carrying an API key are checked by their signature,
+// all others by their bearer token. Without an authenticator, bearer
+// authentication is disabled and unsigned requests run as auth.Anonymous.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(signing.HeaderKeyID) != "" && s.requestVerifier != nil && r.URL.Path != "/health" {
+			s.serveSignedRequest(w, r, next)
+			return
+		}
+
+		if s.authenticator == nil {
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), auth.Anonymous())))
+			return
+		}
+
+		if r.URL.Path == "/health" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			writeUnauthorized(w, r, "A bearer token is required")
+			return
+		}
+
+		principal, err := s.authenticator.Authenticate(strings.TrimSpace(token))
+		if err != nil {
+			log.Printf("🚫 Rejected bearer token for %s %s: %v", r.Method, r.URL.Path, err)
+			writeUnauthorized(w, r, "The bearer token is invalid or has expired")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// serveSignedRequest verifies the API key signature of r and lets it through
+// if the key's scopes allow the route.
+func (s *Server) serveSignedRequest(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	body, err := bufferBody(w, r)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Request body could not be read")
+		return
+	}
+
+	principal, err := s.requestVerifier.VerifyRequest(r.Context(), r, body)
+	if errors.Is(err, signing.ErrInvalidSignature) {
+		log.Printf("🚫 Rejected signed request for %s %s: %v", r.Method, r.URL.Path, err)
+		writeProblem(w, r, http.StatusUnauthorized, problemUnauthorized, "The request signature is invalid, expired or was already used")
+		return
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if !principal.HasScope(auth.ScopeAdmin) {
+		scope, allowed := apiKeyRoutes[routeName(r)]
+		if !allowed || !principal.HasScope(scope) {
+			log.Printf("🚫 %s %s refused for API key %s: scopes %v", r.Method, r.URL.Path, principal.KeyID, principal.Scopes)
+			writeError(w, r, domain.ErrForbidden)
+			return
+		}
+	}
+
+	next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+}
+
+// bufferBody reads the body of r, up to maxBufferedBodySize, and puts it back
+// for the handler.
+func bufferBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBufferedBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// routeName names the matched route by method and path template, such as
+// "POST /holds/{hold_id}/capture".
+func routeName(r *http.Request) string {
+	route := ""
+	if current := mux.CurrentRoute(r); current != nil {
+		route, _ = current.GetPathTemplate()
+	}
+	return r.Method + " " + route
+}
+
+// rateLimitMiddleware throttles each caller, and each caller on each wallet
+// it addresses, per route. Limited responses describe the bucket closest to
+// running out in RateLimit-* headers; refused requests get a 429 with
+// Retry-After. A failing store lets requests through rather than take the
+// service down with it.
+func (s *Server) rateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.rateLimiter == nil || r.URL.Path == "/health" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := routeName(r)
+		wallet := ""
+		if s.rateLimiter.WalletLimited(route) {
+			var err error
+			if wallet, err = rateLimitWallet(w, r); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Request body could not be read")
+				return
+			}
+		}
+
+		client := rateLimitClient(r)
+		decision, limited, err := s.rateLimiter.Allow(r.Context(), route, client, wallet, time.Now())
+		if err != nil {
+			log.Printf("⚠️ Rate limit check failed for %s, letting the request through: %v", route, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !limited {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit.Requests))
+		header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		header.Set("RateLimit-Reset", ceilSeconds(decision.ResetAfter))
+		header.Set("RateLimit-Policy", strconv.Itoa(decision.Limit.Requests)+";w="+ceilSeconds(decision.Limit.Period))
+
+		if !decision.Allowed {
+			header.Set("Retry-After", ceilSeconds(decision.RetryAfter))
+			log.Printf("🚦 %s throttled for %s (wallet %q): limit %s", route, client, wallet, decision.Limit)
+			writeProblem(w, r, http.StatusTooManyRequests, problemRateLimited, "Too many requests; retry after the number of seconds in Retry-After")
+			return
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
 
+// rateLimitClient identifies the caller by API key, by token subject or, when
+// authentication is disabled, by remote address.
+func rateLimitClient(r *http.Request) string {
+	principal, _ := auth.FromContext(r.Context())
+	switch {
+	case principal.KeyID != "":
+		return "key:" + principal.KeyID
+	case principal.Subject != "" && !principal.IsAnonymous():
+		return "subject:" + principal.Subject
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "address:" + host
+}
+
+// rateLimitWallet names the wallet a request addresses: the wallet_id path
+// variable, or the wallet_id, from_wallet_id, user_id or from_user_id of the
+// query or JSON body, in that order. A user stands for their default wallet
+// and gets a bucket of its own. A body that is not JSON names no wallet; the
+// handler reports it.
+func rateLimitWallet(w http.ResponseWriter, r *http.Request) (string, error) {
+	if walletID := mux.Vars(r)["wallet_id"]; walletID != "" {
+		return "wallet:" + strings.ToLower(walletID), nil
+	}
+
+	var fields struct {
+		WalletID     string `json:"wallet_id"`
+		FromWalletID string `json:"from_wallet_id"`
+		UserID       string `json:"user_id"`
+		FromUserID   string `json:"from_user_id"`
+	}
+
+	query := r.URL.Query()
+	fields.WalletID = query.Get("wallet_id")
+	fields.UserID = query.Get("user_id")
+
+	if r.Body != nil && (r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH") {
+		body, err := bufferBody(w, r)
+		if err != nil {
+			return "", err
+		}
+		_ = json.Unmarshal(body, &fields)
+	}
+
+	switch {
+	case fields.WalletID != "":
+		return "wallet:" + strings.ToLower(fields.WalletID), nil
+	case fields.FromWalletID != "":
+		return "wallet:" + strings.ToLower(fields.FromWalletID), nil
+	case fields.UserID != "":
+		return "user:" + strings.ToLower(fields.UserID), nil
+	case fields.FromUserID != "":
+		return "user:" + strings.ToLower(fields.FromUserID), nil
+	}
+	return "", nil
+}
+
+// ceilSeconds renders d in whole seconds, rounded up, as rate limit headers
+// expect.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+// writeUnauthorized renders a 401 problem with the challenge RFC 6750 asks
+// for.
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="wallet"`)
+	writeProblem(w, r, http.StatusUnauthorized, problemUnauthorized, detail)
+}
+
+// requireScope only lets callers holding one of scopes through to handler.
+func (s *Server) requireScope(handler http.HandlerFunc, scopes ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.RequireScope(r.Context(), scopes...); err != nil {
+			principal, _ := auth.FromContext(r.Context())
+			log.Printf("🚫 %s %s refused for %q: requires one of %v", r.Method, r.URL.Path, principal.Subject, scopes)
+			writeError(w, r, err)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// requireRole only lets callers holding role, or a role ranked above it,
+// through to handler.
+func (s *Server) requireRole(handler http.HandlerFunc, role string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.RequireRole(r.Context(), role); err != nil {
+			principal, _ := auth.FromContext(r.Context())
+			log.Printf("🚫 %s %s refused for %q: requires role %s", r.Method, r.URL.Path, principal.Subject, role)
+			writeError(w, r, err)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// requireRoleOrScope only lets callers holding role, a role ranked above it,
+// or one of scopes through to handler.
+func (s *Server) requireRoleOrScope(handler http.HandlerFunc, role string, scopes ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.RequireRoleOrScope(r.Context(), role, scopes...); err != nil {
+			principal, _ := auth.FromContext(r.Context())
+			log.Printf("🚫 %s %s refused for %q: requires role %s or one of %v", r.Method, r.URL.Path, principal.Subject, role, scopes)
+			writeError(w, r, err)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// idempotencyKeyMiddleware makes the Idempotency-Key header of mutating
+// requests available to the use cases through the request context.
+func (s *Server) idempotencyKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotency.HeaderName)
+		if key == "" || r.Method == "GET" || r.Method == "HEAD" || r.Method == "OPTIONS" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > idempotency.MaxKeyLength {
+			writeProblem(w, r, http.StatusBadRequest, problemInvalidIdempotencyKey, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(idempotency.WithKey(r.Context(), key)))
+	})
+}
+
+// timeoutMiddleware wraps http.TimeoutHandler so that its timeout body is a
+// problem document. The handler is built per request because the body carries
+// the request ID. The content type set here only survives on timeout; a
+// completed response replaces it with the handler's own headers.
 func (s *Server) timeoutMiddleware(next http.Handler) http.Handler {
-	return http.TimeoutHandler(next, 60*time.Second, "Request timeout")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal(newProblem(r, http.StatusServiceUnavailable, problemRequestTimeout, "The request took too long to complete"))
+		if err != nil {
+			body = []byte("Request timeout")
+		}
+
+		w.Header().Set("Content-Type", problemContentType)
+		http.TimeoutHandler(next, s.requestTimeout, string(body)).ServeHTTP(w, r)
+	})
 }
 
 func (s *Server) contentTypeMiddleware(next http.Handler) http.Handler {
@@ -91,7 +539,7 @@ func (s *Server) contentTypeMiddleware(next http.Handler) http.Handler {
 		if r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" {
 			contentType := r.Header.Get("Content-Type")
 			if contentType != "application/json" {
-				http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+				writeProblem(w, r, http.StatusUnsupportedMediaType, problemUnsupportedMediaType, "Content-Type must be application/json")
 				return
 			}
 		}
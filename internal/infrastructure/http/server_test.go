@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	t.Run("should answer a panicking handler with an internal-error problem", func(t *testing.T) {
+		// Arrange
+		s := &Server{}
+		panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("nil wallet")
+		})
+		handler := s.requestIDMiddleware(s.recoveryMiddleware(panicking))
+		request := httptest.NewRequest("POST", "/withdraw", nil)
+		request.Header.Set("X-Request-ID", "req-1")
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, request)
+
+		// Assert
+		if recorder.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", recorder.Code)
+		}
+		problem := decodeProblem(t, recorder)
+		if problem.Type != "/problems/internal-error" || problem.Title != "Internal server error" {
+			t.Errorf("expected an internal-error problem, got %s %q", problem.Type, problem.Title)
+		}
+		if problem.Detail != "An unexpected error occurred" || problem.RequestID != "req-1" {
+			t.Errorf("expected a generic detail and the request ID, got %q and %q", problem.Detail, problem.RequestID)
+		}
+	})
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Run("should answer a slow handler with a request-timeout problem", func(t *testing.T) {
+		// Arrange
+		s := &Server{requestTimeout: 10 * time.Millisecond}
+		slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+		handler := s.timeoutMiddleware(slow)
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/balance", nil))
+
+		// Assert
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d", recorder.Code)
+		}
+		problem := decodeProblem(t, recorder)
+		if problem.Type != "/problems/request-timeout" || problem.Title != "Request timeout" {
+			t.Errorf("expected a request-timeout problem, got %s %q", problem.Type, problem.Title)
+		}
+	})
+
+	t.Run("should keep the response of a handler that finishes in time", func(t *testing.T) {
+		// Arrange
+		s := &Server{requestTimeout: time.Second}
+		fast := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"balance":100}`)) //nolint:errcheck
+		})
+		handler := s.timeoutMiddleware(fast)
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/balance", nil))
+
+		// Assert
+		if recorder.Code != http.StatusOK || recorder.Body.String() != `{"balance":100}` {
+			t.Errorf("expected the handler's response, got %d %q", recorder.Code, recorder.Body.String())
+		}
+		if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("expected the handler's content type, got %q", contentType)
+		}
+	})
+}
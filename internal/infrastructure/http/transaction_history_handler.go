@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain"
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type TransactionHistoryHandler struct {
+	historyService service.TransactionHistoryService
+	validator      *validator.Validate
+}
+
+func NewTransactionHistoryHandler(historyService service.TransactionHistoryService) *TransactionHistoryHandler {
+	return &TransactionHistoryHandler{
+		historyService: historyService,
+		validator:      newValidator(),
+	}
+}
+
+func (h *TransactionHistoryHandler) HandleListTransactions(w http.ResponseWriter, r *http.Request) {
+	walletID := mux.Vars(r)["wallet_id"]
+
+	if err := h.validator.Var(walletID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return
+	}
+
+	query, err := parseTransactionHistoryQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	var response *dto.TransactionHistoryResponse
+	err = withPathWallet(walletIDVO, func(ref valueobject.WalletRef) error {
+		var err error
+		response, err = h.historyService.ListTransactions(ctx, ref, query)
+		return err
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// parseTransactionHistoryQuery reads the listing filters. type and status
+// accept repeated parameters as well as comma separated values.
+func parseTransactionHistoryQuery(values url.Values) (dto.TransactionHistoryQuery, error) {
+	query := dto.TransactionHistoryQuery{
+		Types:    splitQueryValues(values["type"]),
+		Statuses: splitQueryValues(values["status"]),
+		Cursor:   values.Get("cursor"),
+	}
+
+	var err error
+	if query.MinAmount, err = parseOptionalInt(values, "min_amount"); err != nil {
+		return query, err
+	}
+	if query.MaxAmount, err = parseOptionalInt(values, "max_amount"); err != nil {
+		return query, err
+	}
+	if query.From, err = parseOptionalTime(values, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseOptionalTime(values, "to"); err != nil {
+		return query, err
+	}
+
+	if query.Limit, err = parseLimit(values); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, strings.ToUpper(part))
+			}
+		}
+	}
+	return result
+}
+
+func parseOptionalInt(values url.Values, key string) (*int64, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || parsed < 0 {
+		return nil, domain.NewValidationError(key, key+" must be a non-negative integer")
+	}
+	return &parsed, nil
+}
+
+func parseOptionalTime(values url.Values, key string) (*time.Time, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, domain.NewValidationError(key, key+" must be an RFC 3339 timestamp")
+	}
+	return &parsed, nil
+}
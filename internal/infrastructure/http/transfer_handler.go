@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type TransferHandler struct {
+	transferUseCase usecase.TransferUseCase
+	validator       *validator.Validate
+}
+
+func NewTransferHandler(transferUseCase usecase.TransferUseCase) *TransferHandler {
+	return &TransferHandler{
+		transferUseCase: transferUseCase,
+		validator:       newValidator(),
+	}
+}
+
+type TransferRequest struct {
+	FromUserID   string `json:"from_user_id,omitempty" validate:"required_without=FromWalletID,excluded_with=FromWalletID,omitempty,uuid"`
+	FromWalletID string `json:"from_wallet_id,omitempty" validate:"omitempty,uuid"`
+	ToUserID     string `json:"to_user_id,omitempty" validate:"required_without=ToWalletID,excluded_with=ToWalletID,omitempty,uuid,nefield=FromUserID"`
+	ToWalletID   string `json:"to_wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount       int64  `json:"amount" validate:"required,gt=0"`
+	Currency     string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+func (h *TransferHandler) HandleTransfer(w http.ResponseWriter, r *http.Request) {
+	var req TransferRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	fromRef, err := requestWalletRef(req.FromUserID, req.FromWalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "from_wallet_id", "Invalid sender wallet ID format")
+		return
+	}
+
+	toRef, err := requestWalletRef(req.ToUserID, req.ToWalletID)
+	if err != nil {
+		writeFieldProblem(w, r, "to_wallet_id", "Invalid recipient wallet ID format")
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.transferUseCase.Transfer(ctx, fromRef, toRef, amountVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
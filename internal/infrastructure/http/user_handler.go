@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type UserHandler struct {
+	userService service.UserService
+	validator   *validator.Validate
+}
+
+func NewUserHandler(userService service.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		validator:   newValidator(),
+	}
+}
+
+type CreateUserRequest struct {
+	Name        string `json:"name" validate:"required,max=50"`
+	ExternalRef string `json:"external_ref,omitempty" validate:"omitempty,max=64"`
+	Currency    string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+func (h *UserHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.userService.RegisterUser(ctx, req.Name, req.ExternalRef, currencyVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/users/"+response.UserID)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+func (h *UserHandler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+	userIDVO, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.userService.GetUser(ctx, userIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *UserHandler) pathUserID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	userID := mux.Vars(r)["user_id"]
+
+	if err := h.validator.Var(userID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return valueobject.UserID{}, false
+	}
+
+	userIDVO, err := valueobject.NewUserID(userID)
+	if err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return userIDVO, true
+}
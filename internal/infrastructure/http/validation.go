@@ -0,0 +1,103 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator returns a validator that reports fields by their JSON name, so
+// field errors refer to what the client actually sent.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// writeValidationProblem renders the result of validator.Struct as a
+// validation problem with one entry per failing field.
+func writeValidationProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := newProblem(r, http.StatusBadRequest, problemValidation, "One or more fields are invalid")
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, fieldErr := range validationErrs {
+			problem.Errors = append(problem.Errors, FieldError{
+				Field:   fieldErr.Field(),
+				Message: fieldErrorMessage(fieldErr),
+			})
+		}
+	}
+
+	renderProblem(w, problem)
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "uuid":
+		return "must be a valid UUID"
+	case "gt":
+		return "must be greater than " + fieldErr.Param()
+	case "gte":
+		return "must be at least " + fieldErr.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "nefield":
+		return "must not equal " + jsonFieldName(fieldErr.Param())
+	case "required_without":
+		return "is required when " + jsonFieldName(fieldErr.Param()) + " is not given"
+	case "excluded_with":
+		return "must not be combined with " + jsonFieldName(fieldErr.Param())
+	case "len":
+		return "must be " + fieldErr.Param() + " characters long"
+	case "alpha":
+		return "must contain only letters"
+	default:
+		return "failed the " + fieldErr.Tag() + " rule"
+	}
+}
+
+// jsonFieldName turns the Go field name in a cross-field rule parameter, such
+// as FromWalletID, into the JSON name the client sent, from_wallet_id.
+func jsonFieldName(goName string) string {
+	var b strings.Builder
+	runes := []rune(goName)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// writeFieldProblem renders a validation problem for a single field that was
+// checked outside validator.Struct, such as a path or query parameter.
+func writeFieldProblem(w http.ResponseWriter, r *http.Request, field, message string) {
+	problem := newProblem(r, http.StatusBadRequest, problemValidation, message)
+	problem.Errors = []FieldError{{Field: field, Message: message}}
+	renderProblem(w, problem)
+}
+
+// requestCurrency resolves the optional currency of a request body, falling
+// back to the default currency when the client left it out.
+func requestCurrency(code string) (valueobject.Currency, error) {
+	if code == "" {
+		return valueobject.DefaultCurrency(), nil
+	}
+	return valueobject.NewCurrency(code)
+}
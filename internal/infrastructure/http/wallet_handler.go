@@ -0,0 +1,283 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/service"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+type WalletHandler struct {
+	walletService service.WalletService
+	validator     *validator.Validate
+}
+
+func NewWalletHandler(walletService service.WalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+		validator:     newValidator(),
+	}
+}
+
+type CreateWalletRequest struct {
+	Name     string `json:"name" validate:"required,max=50"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type SetOverdraftLimitRequest struct {
+	OverdraftLimit int64  `json:"overdraft_limit" validate:"gte=0"`
+	Currency       string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
+}
+
+type ChangeWalletStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=ACTIVE FROZEN DEBIT_BLOCKED CLOSED"`
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+func (h *WalletHandler) HandleCreateWallet(w http.ResponseWriter, r *http.Request) {
+	userIDVO, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateWalletRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.CreateWallet(ctx, userIDVO, req.Name, currencyVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", "/wallets/"+response.WalletID)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) HandleListWallets(w http.ResponseWriter, r *http.Request) {
+	userIDVO, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.ListWallets(ctx, userIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) HandleGetWallet(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.GetWallet(ctx, walletIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) HandleSetOverdraftLimit(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetOverdraftLimitRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	currencyVO, err := requestCurrency(req.Currency)
+	if err != nil {
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	limitVO, err := valueobject.NewMoney(req.OverdraftLimit, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "overdraft_limit", "Overdraft limit cannot be negative")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.SetOverdraftLimit(ctx, walletIDVO, limitVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) HandleChangeStatus(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ChangeWalletStatusRequest
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	status, err := entity.ParseWalletStatus(req.Status)
+	if err != nil {
+		writeFieldProblem(w, r, "status", "Unknown wallet status")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.ChangeStatus(ctx, walletIDVO, status, req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) HandleStatusHistory(w http.ResponseWriter, r *http.Request) {
+	walletIDVO, ok := h.pathWalletID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.walletService.ListStatusHistory(ctx, walletIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WalletHandler) pathWalletID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	walletID := mux.Vars(r)["wallet_id"]
+
+	if err := h.validator.Var(walletID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return valueobject.UserID{}, false
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return walletIDVO, true
+}
+
+func (h *WalletHandler) pathUserID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	userID := mux.Vars(r)["user_id"]
+
+	if err := h.validator.Var(userID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return valueobject.UserID{}, false
+	}
+
+	userIDVO, err := valueobject.NewUserID(userID)
+	if err != nil {
+		writeFieldProblem(w, r, "user_id", "Invalid user ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return userIDVO, true
+}
+
+// requestWalletRef resolves the wallet a request body addresses: by wallet ID,
+// or for clients predating multiple wallets, by the user ID of the default
+// wallet. The validator has already checked that exactly one is set.
+func requestWalletRef(userID, walletID string) (valueobject.WalletRef, error) {
+	if walletID != "" {
+		id, err := valueobject.NewUserID(walletID)
+		if err != nil {
+			return valueobject.WalletRef{}, err
+		}
+		return valueobject.WalletByID(id), nil
+	}
+
+	id, err := valueobject.NewUserID(userID)
+	if err != nil {
+		return valueobject.WalletRef{}, err
+	}
+	return valueobject.DefaultWalletOf(id), nil
+}
+
+// withPathWallet calls fn with the wallet named by a {wallet_id} path segment.
+// These routes took a user ID before wallets had IDs of their own, so an ID
+// that matches no wallet is retried as the default wallet of that user.
+func withPathWallet(id valueobject.UserID, fn func(ref valueobject.WalletRef) error) error {
+	err := fn(valueobject.WalletByID(id))
+	if errors.Is(err, domain.ErrWalletNotFound) {
+		err = fn(valueobject.DefaultWalletOf(id))
+	}
+	return err
+}
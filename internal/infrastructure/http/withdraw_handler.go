@@ -20,96 +20,62 @@ type WithdrawHandler struct {
 func NewWithdrawHandler(withdrawUseCase usecase.WithdrawUseCase) *WithdrawHandler {
 	return &WithdrawHandler{
 		withdrawUseCase: withdrawUseCase,
-		validator:       validator.New(),
+		validator:       newValidator(),
 	}
 }
 
 type WithdrawRequest struct {
-	UserID string `json:"user_id" validate:"required,uuid"`
-	Amount int64  `json:"amount" validate:"required,gt=0"`
+	UserID   string `json:"user_id,omitempty" validate:"required_without=WalletID,excluded_with=WalletID,omitempty,uuid"`
+	WalletID string `json:"wallet_id,omitempty" validate:"omitempty,uuid"`
+	Amount   int64  `json:"amount" validate:"required,gt=0"`
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,alpha"`
 }
 
 func (h *WithdrawHandler) HandleWithdraw(w http.ResponseWriter, r *http.Request) {
 	var req WithdrawRequest
 
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid JSON format",
-		})
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
 		return
 	}
 
 	if err := h.validator.Struct(&req); err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
+		writeValidationProblem(w, r, err)
 		return
 	}
 
-	userIDVO, err := valueobject.NewUserID(req.UserID)
+	walletRef, err := requestWalletRef(req.UserID, req.WalletID)
 	if err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid user ID format",
-		})
+		writeFieldProblem(w, r, "wallet_id", "Invalid wallet ID format")
 		return
 	}
 
-	amountVO, err := valueobject.NewMoney(req.Amount)
+	currencyVO, err := requestCurrency(req.Currency)
 	if err != nil {
-		render.Status(r, http.StatusBadRequest)
-		render.JSON(w, r, ErrorResponse{
-			Error:   "validation_error",
-			Message: "Invalid amount",
-		})
+		writeFieldProblem(w, r, "currency", "Unsupported currency")
+		return
+	}
+
+	amountVO, err := valueobject.NewMoney(req.Amount, currencyVO)
+	if err != nil {
+		writeFieldProblem(w, r, "amount", "Invalid amount")
 		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	response, err := h.withdrawUseCase.Withdraw(ctx, userIDVO, amountVO)
+	response, err := h.withdrawUseCase.Withdraw(ctx, walletRef, amountVO)
 	if err != nil {
-		switch {
-		case err.Error() == "invalid user ID format":
-			fallthrough
-		case err.Error() == "user ID is required":
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, ErrorResponse{
-				Error:   "validation_error",
-				Message: err.Error(),
-			})
-			return
-
-		case err.Error() == "wallet not found":
-			render.Status(r, http.StatusNotFound)
-			render.JSON(w, r, ErrorResponse{
-				Error:   "wallet_not_found",
-				Message: "No wallet found for this user",
-			})
-			return
-
-		case err.Error() == "insufficient funds":
-			render.Status(r, http.StatusBadRequest)
-			render.JSON(w, r, ErrorResponse{
-				Error:   "insufficient_funds",
-				Message: "Insufficient funds for withdrawal",
-			})
-			return
-
-		default:
-			render.Status(r, http.StatusInternalServerError)
-			render.JSON(w, r, ErrorResponse{
-				Error:   "internal_error",
-				Message: "An unexpected error occurred",
-			})
-			return
-		}
+		writeError(w, r, err)
+		return
+	}
+
+	// A withdrawal waiting for approval has been accepted, not executed.
+	if response.WithdrawalRequestID != "" {
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, response)
+		return
 	}
 
 	render.Status(r, http.StatusOK)
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"bank/internal/application/dto"
+	"bank/internal/domain/service"
+	"bank/internal/domain/usecase"
+	"bank/internal/domain/valueobject"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// WithdrawalRequestHandler serves the back-office routes that list and decide
+// the withdrawals waiting for approval.
+type WithdrawalRequestHandler struct {
+	adminService    service.AdminService
+	approvalUseCase usecase.WithdrawalApprovalUseCase
+	validator       *validator.Validate
+}
+
+func NewWithdrawalRequestHandler(adminService service.AdminService, approvalUseCase usecase.WithdrawalApprovalUseCase) *WithdrawalRequestHandler {
+	return &WithdrawalRequestHandler{
+		adminService:    adminService,
+		approvalUseCase: approvalUseCase,
+		validator:       newValidator(),
+	}
+}
+
+type WithdrawalDecisionRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=255"`
+}
+
+// HandleListRequests lists the withdrawal requests, newest first. status
+// accepts repeated parameters as well as comma separated values.
+func (h *WithdrawalRequestHandler) HandleListRequests(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	query := dto.WithdrawalRequestQuery{
+		WalletID:    values.Get("wallet_id"),
+		Statuses:    splitQueryValues(values["status"]),
+		RequestedBy: strings.TrimSpace(values.Get("requested_by")),
+		Cursor:      values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = parseLimit(values); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adminService.ListWithdrawalRequests(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WithdrawalRequestHandler) HandleGetRequest(w http.ResponseWriter, r *http.Request) {
+	requestIDVO, ok := h.pathRequestID(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.adminService.GetWithdrawalRequest(ctx, requestIDVO)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WithdrawalRequestHandler) HandleApprove(w http.ResponseWriter, r *http.Request) {
+	requestIDVO, req, ok := h.decisionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.approvalUseCase.Approve(ctx, requestIDVO, req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+func (h *WithdrawalRequestHandler) HandleReject(w http.ResponseWriter, r *http.Request) {
+	requestIDVO, req, ok := h.decisionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	response, err := h.approvalUseCase.Reject(ctx, requestIDVO, req.Reason)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, response)
+}
+
+// decisionRequest reads the request ID from the path and the decision from the
+// body, writing the problem response itself when either is invalid.
+func (h *WithdrawalRequestHandler) decisionRequest(w http.ResponseWriter, r *http.Request) (valueobject.UserID, WithdrawalDecisionRequest, bool) {
+	var req WithdrawalDecisionRequest
+
+	requestIDVO, ok := h.pathRequestID(w, r)
+	if !ok {
+		return requestIDVO, req, false
+	}
+
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, problemInvalidRequest, "Invalid JSON format")
+		return requestIDVO, req, false
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		writeValidationProblem(w, r, err)
+		return requestIDVO, req, false
+	}
+
+	return requestIDVO, req, true
+}
+
+func (h *WithdrawalRequestHandler) pathRequestID(w http.ResponseWriter, r *http.Request) (valueobject.UserID, bool) {
+	requestID := mux.Vars(r)["request_id"]
+
+	if err := h.validator.Var(requestID, "required,uuid"); err != nil {
+		writeFieldProblem(w, r, "request_id", "Invalid withdrawal request ID format")
+		return valueobject.UserID{}, false
+	}
+
+	requestIDVO, err := valueobject.NewUserID(requestID)
+	if err != nil {
+		writeFieldProblem(w, r, "request_id", "Invalid withdrawal request ID format")
+		return valueobject.UserID{}, false
+	}
+
+	return requestIDVO, true
+}
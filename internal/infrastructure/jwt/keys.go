@@ -0,0 +1,157 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// KeySet holds the keys tokens may be signed with: HMAC secrets for HS256 and
+// RSA public keys for RS256. Keys are looked up by the token's "kid" header;
+// a token without one is checked against the only key of its algorithm.
+type KeySet struct {
+	secrets    map[string][]byte         // by key ID
+	publicKeys map[string]*rsa.PublicKey // by key ID
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{
+		secrets:    make(map[string][]byte),
+		publicKeys: make(map[string]*rsa.PublicKey),
+	}
+}
+
+// AddSecret adds an HS256 secret. Secrets shorter than 32 bytes, the size of
+// the SHA-256 output, are refused.
+func (k *KeySet) AddSecret(kid string, secret []byte) error {
+	if len(secret) < 32 {
+		return errors.New("HS256 secret must be at least 32 bytes")
+	}
+	k.secrets[kid] = secret
+	return nil
+}
+
+// AddPublicKey adds an RS256 public key. Keys shorter than 2048 bits are
+// refused.
+func (k *KeySet) AddPublicKey(kid string, key *rsa.PublicKey) error {
+	if key.N.BitLen() < 2048 {
+		return errors.New("RS256 public key must be at least 2048 bits")
+	}
+	k.publicKeys[kid] = key
+	return nil
+}
+
+// Empty reports whether the set holds no key at all.
+func (k *KeySet) Empty() bool {
+	return len(k.secrets) == 0 && len(k.publicKeys) == 0
+}
+
+// LoadPublicKeyFile adds the PEM encoded RSA public key at path, either a
+// PKIX "PUBLIC KEY" or a PKCS #1 "RSA PUBLIC KEY" block, under key ID kid.
+func (k *KeySet) LoadPublicKeyFile(kid, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read public key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("public key file %s: no PEM block found", path)
+	}
+
+	var key *rsa.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("public key file %s: %w", path, err)
+		}
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("public key file %s: not an RSA key", path)
+		}
+		key = rsaKey
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("public key file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("public key file %s: unexpected PEM block %q", path, block.Type)
+	}
+
+	if err := k.AddPublicKey(kid, key); err != nil {
+		return fmt.Errorf("public key file %s: %w", path, err)
+	}
+	return nil
+}
+
+// jwks is the JSON Web Key Set file format (RFC 7517). Only RSA signing keys
+// are read; other keys are skipped.
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// LoadJWKSFile adds the RSA keys of the JSON Web Key Set at path.
+func (k *KeySet) LoadJWKSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read JWKS file: %w", err)
+	}
+
+	if err := k.loadJWKS(data); err != nil {
+		return fmt.Errorf("JWKS file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (k *KeySet) loadJWKS(data []byte) error {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return err
+	}
+
+	loaded := 0
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") || (jwk.Alg != "" && jwk.Alg != algRS256) {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return fmt.Errorf("key %q: invalid modulus: %w", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return fmt.Errorf("key %q: invalid exponent: %w", jwk.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return fmt.Errorf("key %q: unsupported exponent", jwk.Kid)
+		}
+
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if err := k.AddPublicKey(jwk.Kid, key); err != nil {
+			return fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		loaded++
+	}
+
+	if loaded == 0 {
+		return errors.New("no RSA signing keys found")
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Package jwt verifies the JSON Web Tokens callers authenticate with. Only
+// compact, signed tokens using HS256 or RS256 are accepted.
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"bank/internal/application/auth"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+// ErrInvalidToken is returned for every token that is not accepted; the
+// wrapped message says why.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Options are the claim checks a Verifier makes besides the signature.
+type Options struct {
+	// Issuer, if set, must equal the "iss" claim.
+	Issuer string
+	// Audience, if set, must be one of the "aud" claim's values.
+	Audience string
+	// Leeway absorbs clock skew when checking "exp" and "nbf".
+	Leeway time.Duration
+}
+
+// Verifier checks tokens against a key set and turns them into principals.
+type Verifier struct {
+	keys    *KeySet
+	options Options
+	now     func() time.Time
+}
+
+func NewVerifier(keys *KeySet, options Options) *Verifier {
+	return &Verifier{
+		keys:    keys,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+	Scope     string   `json:"scope"`
+}
+
+// audience is the "aud" claim, which may be a single string or an array.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, candidate := range a {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate verifies token and returns the principal it names: the "sub"
+// claim with the space separated scopes of the "scope" claim. Tokens must
+// carry an expiry.
+func (v *Verifier) Authenticate(token string) (auth.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return auth.Principal{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: signature is not base64url", ErrInvalidToken)
+	}
+
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], signature); err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	if err := v.checkClaims(c); err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return auth.Principal{
+		Subject: c.Subject,
+		Scopes:  strings.Fields(c.Scope),
+	}, nil
+}
+
+// verifySignature checks signature over signed with the key the header names.
+// The algorithm decides which kind of key is used, so that an RSA public key
+// can never be taken for an HMAC secret.
+func (v *Verifier) verifySignature(h header, signed string, signature []byte) error {
+	switch h.Alg {
+	case algHS256:
+		secret, err := lookup(v.keys.secrets, h.Kid)
+		if err != nil {
+			return err
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	case algRS256:
+		key, err := lookup(v.keys.publicKeys, h.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q", h.Alg)
+	}
+}
+
+// lookup returns the key with ID kid, or the only key when the token names
+// none.
+func lookup[K any](keys map[string]K, kid string) (K, error) {
+	var zero K
+	if kid == "" {
+		if len(keys) != 1 {
+			return zero, errors.New("token has no key ID")
+		}
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return zero, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return key, nil
+}
+
+func (v *Verifier) checkClaims(c claims) error {
+	now := v.now()
+
+	if c.Subject == "" {
+		return errors.New("missing sub claim")
+	}
+	if c.ExpiresAt == nil {
+		return errors.New("missing exp claim")
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(v.options.Leeway)) {
+		return errors.New("token has expired")
+	}
+	if c.NotBefore != nil && now.Add(v.options.Leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if v.options.Issuer != "" && c.Issuer != v.options.Issuer {
+		return errors.New("unexpected issuer")
+	}
+	if v.options.Audience != "" && !c.Audience.contains(v.options.Audience) {
+		return errors.New("unexpected audience")
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("not base64url")
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,240 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error encoding segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret []byte, header, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, header, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("unexpected error signing token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "550e8400-e29b-41d4-a716-446655440000",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "operator reports",
+	}
+}
+
+func hs256Verifier(t *testing.T, options Options) *Verifier {
+	t.Helper()
+	keys := NewKeySet()
+	if err := keys.AddSecret("", testSecret); err != nil {
+		t.Fatalf("unexpected error adding secret: %v", err)
+	}
+	return NewVerifier(keys, options)
+}
+
+func TestVerifierHS256(t *testing.T) {
+	t.Run("should accept a valid token", func(t *testing.T) {
+		// Arrange
+		verifier := hs256Verifier(t, Options{})
+		token := signHS256(t, testSecret, map[string]any{"alg": "HS256", "typ": "JWT"}, validClaims())
+
+		// Act
+		principal, err := verifier.Authenticate(token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Subject != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Errorf("unexpected subject %q", principal.Subject)
+		}
+		if !principal.HasScope("operator") || !principal.HasScope("reports") {
+			t.Errorf("expected both scopes, got %v", principal.Scopes)
+		}
+	})
+
+	t.Run("should reject invalid tokens", func(t *testing.T) {
+		verifier := hs256Verifier(t, Options{Issuer: "bank-auth", Audience: "wallet-api"})
+		header := map[string]any{"alg": "HS256"}
+		with := func(changes map[string]any) map[string]any {
+			claims := validClaims()
+			claims["iss"] = "bank-auth"
+			claims["aud"] = "wallet-api"
+			for name, value := range changes {
+				if value == nil {
+					delete(claims, name)
+					continue
+				}
+				claims[name] = value
+			}
+			return claims
+		}
+		valid := signHS256(t, testSecret, header, with(nil))
+		parts := strings.Split(valid, ".")
+
+		tests := []struct {
+			name  string
+			token string
+		}{
+			{"expired", signHS256(t, testSecret, header, with(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()}))},
+			{"without expiry", signHS256(t, testSecret, header, with(map[string]any{"exp": nil}))},
+			{"not valid yet", signHS256(t, testSecret, header, with(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()}))},
+			{"without subject", signHS256(t, testSecret, header, with(map[string]any{"sub": nil}))},
+			{"wrong issuer", signHS256(t, testSecret, header, with(map[string]any{"iss": "someone-else"}))},
+			{"wrong audience", signHS256(t, testSecret, header, with(map[string]any{"aud": []string{"other-api"}}))},
+			{"wrong secret", signHS256(t, []byte("ffffffffffffffffffffffffffffffff"), header, with(nil))},
+			{"tampered claims", parts[0] + "." + encodeSegment(t, with(map[string]any{"scope": "admin"})) + "." + parts[2]},
+			{"alg none", encodeSegment(t, map[string]any{"alg": "none"}) + "." + parts[1] + "."},
+			{"unsupported alg", signHS256(t, testSecret, map[string]any{"alg": "HS512"}, with(nil))},
+			{"malformed", "not-a-token"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Act
+				_, err := verifier.Authenticate(tt.token)
+
+				// Assert
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("expected ErrInvalidToken, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("should accept an audience listed among several", func(t *testing.T) {
+		// Arrange
+		verifier := hs256Verifier(t, Options{Audience: "wallet-api"})
+		claims := validClaims()
+		claims["aud"] = []string{"reports-api", "wallet-api"}
+		token := signHS256(t, testSecret, map[string]any{"alg": "HS256"}, claims)
+
+		// Act
+		_, err := verifier.Authenticate(token)
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should refuse a short secret", func(t *testing.T) {
+		// Act
+		err := NewKeySet().AddSecret("", []byte("too-short"))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestVerifierRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	jwksData, _ := json.Marshal(map[string]any{
+		"keys": []map[string]any{
+			{"kty": "EC", "kid": "ignored"},
+			{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	})
+
+	t.Run("should accept a token signed with a key from the JWKS", func(t *testing.T) {
+		// Arrange
+		keys := NewKeySet()
+		if err := keys.loadJWKS(jwksData); err != nil {
+			t.Fatalf("unexpected error loading JWKS: %v", err)
+		}
+		token := signRS256(t, key, map[string]any{"alg": "RS256", "kid": "key-1"}, validClaims())
+
+		// Act
+		principal, err := NewVerifier(keys, Options{}).Authenticate(token)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Subject != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Errorf("unexpected subject %q", principal.Subject)
+		}
+	})
+
+	t.Run("should reject a token naming an unknown key", func(t *testing.T) {
+		// Arrange
+		keys := NewKeySet()
+		_ = keys.loadJWKS(jwksData)
+		token := signRS256(t, key, map[string]any{"alg": "RS256", "kid": "key-2"}, validClaims())
+
+		// Act
+		_, err := NewVerifier(keys, Options{}).Authenticate(token)
+
+		// Assert
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("should not take the public key for an HMAC secret", func(t *testing.T) {
+		// Arrange
+		keys := NewKeySet()
+		_ = keys.AddPublicKey("", &key.PublicKey)
+		token := signHS256(t, key.N.Bytes(), map[string]any{"alg": "HS256"}, validClaims())
+
+		// Act
+		_, err := NewVerifier(keys, Options{}).Authenticate(token)
+
+		// Assert
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("should reject a JWKS without RSA keys", func(t *testing.T) {
+		// Act
+		err := NewKeySet().loadJWKS([]byte(`{"keys": [{"kty": "EC", "kid": "ec-1"}]}`))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
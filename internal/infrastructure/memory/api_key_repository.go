@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type APIKeyRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewAPIKeyRepository(store *Store) *APIKeyRepository {
+	return &APIKeyRepository{
+		store: store,
+	}
+}
+
+// CreateAPIKey checks the owning user like the foreign key on the api_keys
+// table.
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	r.store.mu.RLock()
+	user := lookupUser(r.store, r.tx, key.UserID())
+	r.store.mu.RUnlock()
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	r.save(key)
+	return nil
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	key := r.lookup(keyID.String())
+	if key == nil {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return copyAPIKey(key), nil
+}
+
+// GetAPIKeyForUpdate locks the key for the rest of the unit of work and
+// returns a copy of it, so that changes only take effect through
+// UpdateAPIKey.
+func (r *APIKeyRepository) GetAPIKeyForUpdate(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "api-key:"+keyID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.GetAPIKey(ctx, keyID)
+}
+
+func (r *APIKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	r.store.mu.RLock()
+	existing := r.lookup(key.ID().String())
+	r.store.mu.RUnlock()
+	if existing == nil {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	r.save(key)
+	return nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, userID valueobject.UserID) ([]*entity.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var keys []*entity.APIKey
+	collect := func(keyID string) {
+		if seen[keyID] {
+			return
+		}
+		seen[keyID] = true
+		if key := r.lookup(keyID); key.UserID().Equals(userID) {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+	for keyID := range r.store.apiKeys {
+		collect(keyID)
+	}
+	if r.tx != nil {
+		for keyID := range r.tx.apiKeys {
+			collect(keyID)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt().Before(keys[j].CreatedAt())
+	})
+	return keys, nil
+}
+
+// lookup returns the key as seen by this repository, or nil. The caller must
+// hold r.store.mu.
+func (r *APIKeyRepository) lookup(keyID string) *entity.APIKey {
+	if r.tx != nil {
+		if key, ok := r.tx.apiKeys[keyID]; ok {
+			return key
+		}
+	}
+	return r.store.apiKeys[keyID]
+}
+
+func (r *APIKeyRepository) save(key *entity.APIKey) {
+	stored := copyAPIKey(key)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.apiKeys[stored.ID().String()] = stored
+	})
+}
+
+func copyAPIKey(key *entity.APIKey) *entity.APIKey {
+	var revokedAt *time.Time
+	if key.RevokedAt() != nil {
+		at := *key.RevokedAt()
+		revokedAt = &at
+	}
+
+	return entity.ReconstructAPIKey(
+		key.ID(),
+		key.UserID(),
+		key.Name(),
+		append([]byte(nil), key.SigningKey()...),
+		append([]string(nil), key.Scopes()...),
+		key.CreatedAt(),
+		revokedAt,
+	)
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func newAPIKey(t *testing.T, userID valueobject.UserID, createdAt time.Time) *entity.APIKey {
+	t.Helper()
+	key, err := entity.NewAPIKey(userID, "backend", []string{entity.APIKeyScopeBalanceRead}, bytes.Repeat([]byte{3}, entity.APIKeySigningKeySize), createdAt)
+	if err != nil {
+		t.Fatalf("unexpected error creating API key: %v", err)
+	}
+	return key
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	t.Run("should find a created key", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewAPIKeyRepository(store)
+		key := newAPIKey(t, addUser(store), time.Now())
+
+		// Act
+		err := repo.CreateAPIKey(context.Background(), key)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		found, err := repo.GetAPIKey(context.Background(), key.ID())
+		if err != nil || !bytes.Equal(found.SigningKey(), key.SigningKey()) || !found.HasScope(entity.APIKeyScopeBalanceRead) {
+			t.Errorf("expected to find the key, got %v (%v)", found, err)
+		}
+	})
+
+	t.Run("should reject a key of an unknown user", func(t *testing.T) {
+		// Arrange
+		repo := NewAPIKeyRepository(NewStore())
+
+		// Act
+		err := repo.CreateAPIKey(context.Background(), newAPIKey(t, valueobject.NewUserIDRandom(), time.Now()))
+
+		// Assert
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should report a missing key", func(t *testing.T) {
+		// Act
+		_, err := NewAPIKeyRepository(NewStore()).GetAPIKey(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrAPIKeyNotFound) {
+			t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should only publish a revocation on commit", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewAPIKeyRepository(store)
+		key := newAPIKey(t, addUser(store), time.Now())
+		_ = repo.CreateAPIKey(context.Background(), key)
+
+		// Act
+		err := NewUnitOfWork(store).RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			locked, err := repos.APIKeys.GetAPIKeyForUpdate(ctx, key.ID())
+			if err != nil {
+				return err
+			}
+			if err := locked.Revoke(time.Now()); err != nil {
+				return err
+			}
+			if err := repos.APIKeys.UpdateAPIKey(ctx, locked); err != nil {
+				return err
+			}
+
+			committed, _ := repo.GetAPIKey(ctx, key.ID())
+			if committed.IsRevoked() {
+				t.Error("expected the revocation to stay invisible before commit")
+			}
+			return nil
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		found, _ := repo.GetAPIKey(context.Background(), key.ID())
+		if !found.IsRevoked() {
+			t.Error("expected the key to be revoked")
+		}
+	})
+
+	t.Run("should list a user's keys oldest first", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewAPIKeyRepository(store)
+		userID := addUser(store)
+		now := time.Now()
+		newer := newAPIKey(t, userID, now)
+		older := newAPIKey(t, userID, now.Add(-time.Hour))
+		_ = repo.CreateAPIKey(context.Background(), newer)
+		_ = repo.CreateAPIKey(context.Background(), older)
+		_ = repo.CreateAPIKey(context.Background(), newAPIKey(t, addUser(store), now))
+
+		// Act
+		keys, err := repo.ListAPIKeys(context.Background(), userID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(keys) != 2 || !keys[0].ID().Equals(older.ID()) || !keys[1].ID().Equals(newer.ID()) {
+			t.Errorf("expected the older key then the newer one, got %d keys", len(keys))
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+)
+
+type AuditRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewAuditRepository(store *Store) *AuditRepository {
+	return &AuditRepository{
+		store: store,
+	}
+}
+
+func (r *AuditRepository) InsertAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	stored := copyAuditEntry(entry)
+	r.store.write(r.tx, func(p *pending) {
+		p.auditEntries = append(p.auditEntries, stored)
+	})
+	return nil
+}
+
+// ListAuditEntries returns the matching entries newest first, starting after
+// the filter's cursor.
+func (r *AuditRepository) ListAuditEntries(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEntry, error) {
+	r.store.mu.RLock()
+	candidates := slices.Clone(r.store.auditEntries)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		candidates = append(candidates, r.tx.auditEntries...)
+	}
+
+	var entries []*entity.AuditEntry
+	for _, entry := range candidates {
+		if matchesAuditFilter(entry, filter) {
+			entries = append(entries, entry)
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b *entity.AuditEntry) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		switch {
+		case a.ID().String() > b.ID().String():
+			return -1
+		case a.ID().String() < b.ID().String():
+			return 1
+		}
+		return 0
+	})
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+
+	result := make([]*entity.AuditEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = copyAuditEntry(entry)
+	}
+	return result, nil
+}
+
+func matchesAuditFilter(entry *entity.AuditEntry, filter repository.AuditFilter) bool {
+	if filter.Actor != "" && entry.Actor() != filter.Actor {
+		return false
+	}
+	if len(filter.Actions) > 0 && !slices.Contains(filter.Actions, entry.Action()) {
+		return false
+	}
+	if filter.TargetID != "" && entry.TargetID() != filter.TargetID {
+		return false
+	}
+
+	createdAt := entry.CreatedAt()
+	if filter.From != nil && createdAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !createdAt.Before(*filter.To) {
+		return false
+	}
+
+	if filter.After != nil {
+		// Keep only rows where (created_at, id) < (cursor.CreatedAt, cursor.ID).
+		if c := createdAt.Compare(filter.After.CreatedAt); c > 0 || (c == 0 && entry.ID().String() >= filter.After.ID.String()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyAuditEntry(entry *entity.AuditEntry) *entity.AuditEntry {
+	return entity.ReconstructAuditEntry(
+		entry.ID(),
+		entry.Actor(),
+		entry.Action(),
+		entry.TargetType(),
+		entry.TargetID(),
+		entry.Reason(),
+		maps.Clone(entry.Details()),
+		entry.CreatedAt(),
+	)
+}
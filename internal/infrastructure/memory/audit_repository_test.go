@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+)
+
+func newAuditEntry(t *testing.T, actor string, action entity.AuditAction, createdAt time.Time) *entity.AuditEntry {
+	t.Helper()
+	entry, err := entity.NewAuditEntry(actor, action, entity.AuditTargetWallet, "wallet-1", "", map[string]string{"status": "FROZEN"}, createdAt)
+	if err != nil {
+		t.Fatalf("unexpected error creating audit entry: %v", err)
+	}
+	return entry
+}
+
+func TestAuditRepository(t *testing.T) {
+	t.Run("should list matching entries newest first and page with the cursor", func(t *testing.T) {
+		// Arrange
+		repo := NewAuditRepository(NewStore())
+		now := time.Now()
+		oldest := newAuditEntry(t, "ops-1", entity.AuditActionWalletStatusChanged, now.Add(-2*time.Minute))
+		middle := newAuditEntry(t, "ops-1", entity.AuditActionWalletStatusChanged, now.Add(-time.Minute))
+		other := newAuditEntry(t, "ops-2", entity.AuditActionWalletStatusChanged, now.Add(-30*time.Second))
+		newest := newAuditEntry(t, "ops-1", entity.AuditActionBalanceAdjusted, now)
+		for _, entry := range []*entity.AuditEntry{oldest, middle, other, newest} {
+			_ = repo.InsertAuditEntry(context.Background(), entry)
+		}
+
+		// Act
+		firstPage, err := repo.ListAuditEntries(context.Background(), repository.AuditFilter{
+			Actor:   "ops-1",
+			Actions: []entity.AuditAction{entity.AuditActionWalletStatusChanged},
+			Limit:   1,
+		})
+		secondPage, _ := repo.ListAuditEntries(context.Background(), repository.AuditFilter{
+			Actor:   "ops-1",
+			Actions: []entity.AuditAction{entity.AuditActionWalletStatusChanged},
+			After:   &repository.AuditCursor{CreatedAt: middle.CreatedAt(), ID: middle.ID()},
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(firstPage) != 1 || !firstPage[0].ID().Equals(middle.ID()) {
+			t.Errorf("expected the newest matching entry first, got %d entries", len(firstPage))
+		}
+		if len(secondPage) != 1 || !secondPage[0].ID().Equals(oldest.ID()) {
+			t.Errorf("expected the oldest entry after the cursor, got %d entries", len(secondPage))
+		}
+	})
+
+	t.Run("should discard entries of a rolled back unit of work", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		failure := errors.New("rolled back")
+
+		// Act
+		err := NewUnitOfWork(store).RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			_ = repos.Audit.InsertAuditEntry(ctx, newAuditEntry(t, "ops-1", entity.AuditActionBalanceAdjusted, time.Now()))
+			return failure
+		})
+
+		// Assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected the unit of work to fail, got %v", err)
+		}
+		entries, _ := NewAuditRepository(store).ListAuditEntries(context.Background(), repository.AuditFilter{})
+		if len(entries) != 0 {
+			t.Errorf("expected no entries, got %d", len(entries))
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type ExchangeQuoteRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewExchangeQuoteRepository(store *Store) *ExchangeQuoteRepository {
+	return &ExchangeQuoteRepository{
+		store: store,
+	}
+}
+
+func (r *ExchangeQuoteRepository) InsertQuote(ctx context.Context, quote *entity.ExchangeQuote) error {
+	stored := withUsedAt(quote, nil)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.quotes = append(p.quotes, stored)
+		if usedAt := quote.UsedAt(); usedAt != nil {
+			p.quotesUsed[quote.ID().String()] = *usedAt
+		}
+	})
+	return nil
+}
+
+// GetQuoteForUpdate returns a copy of the stored quote, so that redeeming it
+// changes nothing until MarkQuoteUsed is called and the unit of work commits.
+func (r *ExchangeQuoteRepository) GetQuoteForUpdate(ctx context.Context, quoteID valueobject.UserID) (*entity.ExchangeQuote, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "quote:"+quoteID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	key := quoteID.String()
+
+	r.store.mu.RLock()
+	quote, ok := r.store.quotes[key]
+	usedAt, used := r.store.quotesUsed[key]
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		for _, pendingQuote := range r.tx.quotes {
+			if pendingQuote.ID().Equals(quoteID) {
+				quote, ok = pendingQuote, true
+			}
+		}
+		if pendingUsedAt, pendingUsed := r.tx.quotesUsed[key]; pendingUsed {
+			usedAt, used = pendingUsedAt, true
+		}
+	}
+
+	if !ok {
+		return nil, domain.ErrQuoteNotFound
+	}
+	if used {
+		return withUsedAt(quote, &usedAt), nil
+	}
+	return withUsedAt(quote, nil), nil
+}
+
+func (r *ExchangeQuoteRepository) MarkQuoteUsed(ctx context.Context, quote *entity.ExchangeQuote) error {
+	usedAt := quote.UsedAt()
+	if usedAt == nil {
+		return nil
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.quotesUsed[quote.ID().String()] = *usedAt
+	})
+	return nil
+}
+
+// withUsedAt copies quote with the given redemption time.
+func withUsedAt(quote *entity.ExchangeQuote, usedAt *time.Time) *entity.ExchangeQuote {
+	return entity.ReconstructExchangeQuote(
+		quote.ID(),
+		quote.UserID(),
+		quote.FromWalletID(),
+		quote.ToWalletID(),
+		quote.Rate(),
+		quote.SourceAmount(),
+		quote.Fee(),
+		quote.TargetAmount(),
+		quote.CreatedAt(),
+		quote.ExpiresAt(),
+		usedAt,
+	)
+}
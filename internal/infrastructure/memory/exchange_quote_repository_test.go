@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestExchangeQuoteRepository(t *testing.T) {
+	newQuote := func(t *testing.T, store *Store) *entity.ExchangeQuote {
+		t.Helper()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		from := store.AddWallet(valueobject.NewUserIDRandom(), balance)
+		eur, _ := valueobject.NewCurrency("EUR")
+		to, _ := entity.NewWallet(from.UserID(), "euros", eur)
+		rate, _ := valueobject.NewExchangeRate(from.Currency(), eur, "0.92")
+		amount, _ := valueobject.NewMoney(1000, from.Currency())
+		quote, err := entity.NewExchangeQuote(from, to, amount, rate, 50, time.Now(), time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error creating quote: %v", err)
+		}
+		return quote
+	}
+
+	t.Run("should keep a redemption only when the unit of work commits", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		quote := newQuote(t, store)
+		repo := NewExchangeQuoteRepository(store)
+		_ = repo.InsertQuote(context.Background(), quote)
+		unitOfWork := NewUnitOfWork(store)
+		redeem := func(ctx context.Context, repos repository.Repositories) (*entity.ExchangeQuote, error) {
+			stored, err := repos.Quotes.GetQuoteForUpdate(ctx, quote.ID())
+			if err != nil {
+				return nil, err
+			}
+			if err := stored.Redeem(time.Now()); err != nil {
+				return nil, err
+			}
+			return stored, repos.Quotes.MarkQuoteUsed(ctx, stored)
+		}
+
+		// Act
+		rollbackErr := errors.New("rollback")
+		rolledBack := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if _, err := redeem(ctx, repos); err != nil {
+				return err
+			}
+			return rollbackErr
+		})
+		afterRollback, _ := repo.GetQuoteForUpdate(context.Background(), quote.ID())
+		committed := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			_, err := redeem(ctx, repos)
+			return err
+		})
+		again := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			_, err := redeem(ctx, repos)
+			return err
+		})
+
+		// Assert
+		if !errors.Is(rolledBack, rollbackErr) {
+			t.Fatalf("expected the rollback error, got %v", rolledBack)
+		}
+		if afterRollback == nil || afterRollback.UsedAt() != nil {
+			t.Error("expected the quote to stay unused after a rollback")
+		}
+		if committed != nil {
+			t.Fatalf("expected the redemption to commit, got %v", committed)
+		}
+		if !errors.Is(again, domain.ErrQuoteAlreadyUsed) {
+			t.Errorf("expected ErrQuoteAlreadyUsed, got %v", again)
+		}
+	})
+
+	t.Run("should report an unknown quote", func(t *testing.T) {
+		// Arrange
+		repo := NewExchangeQuoteRepository(NewStore())
+
+		// Act
+		_, err := repo.GetQuoteForUpdate(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrQuoteNotFound) {
+			t.Errorf("expected ErrQuoteNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type HoldRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewHoldRepository(store *Store) *HoldRepository {
+	return &HoldRepository{
+		store: store,
+	}
+}
+
+func (r *HoldRepository) InsertHold(ctx context.Context, hold *entity.Hold) error {
+	r.save(hold)
+	return nil
+}
+
+func (r *HoldRepository) GetHold(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	hold := r.lookup(holdID.String())
+	if hold == nil {
+		return nil, domain.ErrHoldNotFound
+	}
+	return copyHold(hold), nil
+}
+
+// GetHoldForUpdate locks the hold for the rest of the unit of work and
+// returns a copy of it, so that changes only take effect through UpdateHold.
+func (r *HoldRepository) GetHoldForUpdate(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "hold:"+holdID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.GetHold(ctx, holdID)
+}
+
+func (r *HoldRepository) UpdateHold(ctx context.Context, hold *entity.Hold) error {
+	r.store.mu.RLock()
+	existing := r.lookup(hold.ID().String())
+	r.store.mu.RUnlock()
+	if existing == nil {
+		return domain.ErrHoldNotFound
+	}
+
+	r.save(hold)
+	return nil
+}
+
+func (r *HoldRepository) ListExpiredHolds(ctx context.Context, now time.Time, limit int) ([]*entity.Hold, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var expired []*entity.Hold
+	for holdID := range r.store.holds {
+		hold := r.lookup(holdID)
+		if hold.Status() == entity.HoldStatusActive && hold.IsExpired(now) {
+			expired = append(expired, copyHold(hold))
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt().Before(expired[j].ExpiresAt())
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+// lookup returns the hold as seen by this repository, or nil. The caller
+// must hold r.store.mu.
+func (r *HoldRepository) lookup(holdID string) *entity.Hold {
+	if r.tx != nil {
+		if hold, ok := r.tx.holds[holdID]; ok {
+			return hold
+		}
+	}
+	return r.store.holds[holdID]
+}
+
+func (r *HoldRepository) save(hold *entity.Hold) {
+	stored := copyHold(hold)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.holds[stored.ID().String()] = stored
+	})
+}
+
+func copyHold(hold *entity.Hold) *entity.Hold {
+	return entity.ReconstructHold(
+		hold.ID(),
+		hold.WalletID(),
+		hold.Amount(),
+		hold.CapturedAmount(),
+		hold.Status(),
+		hold.TransactionID(),
+		hold.ExpiresAt(),
+		hold.CreatedAt(),
+		hold.UpdatedAt(),
+	)
+}
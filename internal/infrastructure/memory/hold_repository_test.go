@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestHoldRepository(t *testing.T) {
+	newHold := func(t *testing.T, store *Store, ttl time.Duration) *entity.Hold {
+		t.Helper()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		wallet := store.AddWallet(valueobject.NewUserIDRandom(), balance)
+		amount, _ := valueobject.NewMoney(1000, wallet.Currency())
+		hold, err := entity.NewHold(wallet.ID(), amount, time.Now(), ttl)
+		if err != nil {
+			t.Fatalf("unexpected error creating hold: %v", err)
+		}
+		return hold
+	}
+
+	t.Run("should keep an update only when the unit of work commits", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		hold := newHold(t, store, time.Hour)
+		repo := NewHoldRepository(store)
+		_ = repo.InsertHold(context.Background(), hold)
+		unitOfWork := NewUnitOfWork(store)
+		release := func(ctx context.Context, repos repository.Repositories) error {
+			stored, err := repos.Holds.GetHoldForUpdate(ctx, hold.ID())
+			if err != nil {
+				return err
+			}
+			if err := stored.Release(time.Now()); err != nil {
+				return err
+			}
+			return repos.Holds.UpdateHold(ctx, stored)
+		}
+
+		// Act
+		rollbackErr := errors.New("rollback")
+		rolledBack := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := release(ctx, repos); err != nil {
+				return err
+			}
+			return rollbackErr
+		})
+		afterRollback, _ := repo.GetHold(context.Background(), hold.ID())
+		committed := unitOfWork.RunInTx(context.Background(), release)
+		afterCommit, _ := repo.GetHold(context.Background(), hold.ID())
+
+		// Assert
+		if !errors.Is(rolledBack, rollbackErr) {
+			t.Fatalf("expected the rollback error, got %v", rolledBack)
+		}
+		if afterRollback.Status() != entity.HoldStatusActive {
+			t.Errorf("expected the hold to stay active after a rollback, got %s", afterRollback.Status())
+		}
+		if committed != nil {
+			t.Fatalf("expected the release to commit, got %v", committed)
+		}
+		if afterCommit.Status() != entity.HoldStatusReleased {
+			t.Errorf("expected the hold to be released, got %s", afterCommit.Status())
+		}
+	})
+
+	t.Run("should list only active holds past their expiry", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewHoldRepository(store)
+		expired := newHold(t, store, time.Millisecond)
+		active := newHold(t, store, time.Hour)
+		released := newHold(t, store, time.Millisecond)
+		_ = released.Release(time.Now())
+		for _, hold := range []*entity.Hold{expired, active, released} {
+			_ = repo.InsertHold(context.Background(), hold)
+		}
+
+		// Act
+		holds, err := repo.ListExpiredHolds(context.Background(), time.Now().Add(time.Second), 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(holds) != 1 || !holds[0].ID().Equals(expired.ID()) {
+			t.Errorf("expected only the expired active hold, got %d holds", len(holds))
+		}
+	})
+
+	t.Run("should report a missing hold", func(t *testing.T) {
+		// Arrange
+		repo := NewHoldRepository(NewStore())
+
+		// Act
+		_, err := repo.GetHold(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrHoldNotFound) {
+			t.Errorf("expected ErrHoldNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bank/internal/domain/entity"
+)
+
+type IdempotencyRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewIdempotencyRepository(store *Store) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		store: store,
+	}
+}
+
+// GetIdempotencyRecordForUpdate locks the key itself rather than a record,
+// since the first request for a key has no record to lock yet.
+func (r *IdempotencyRepository) GetIdempotencyRecordForUpdate(ctx context.Context, key string) (*entity.IdempotencyRecord, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "idempotency:"+key); err != nil {
+			return nil, err
+		}
+
+		if record, ok := r.tx.idempotency[key]; ok {
+			return record, nil
+		}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.idempotency[key], nil
+}
+
+func (r *IdempotencyRepository) SaveIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	r.store.write(r.tx, func(p *pending) {
+		p.idempotency[record.Key()] = record
+	})
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int64
+	for key, record := range r.store.idempotency {
+		if !record.ExpiresAt().After(now) {
+			delete(r.store.idempotency, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
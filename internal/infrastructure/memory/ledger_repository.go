@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type LedgerRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewLedgerRepository(store *Store) *LedgerRepository {
+	return &LedgerRepository{
+		store: store,
+	}
+}
+
+func (r *LedgerRepository) GetWalletAccount(ctx context.Context, walletID valueobject.UserID) (*entity.LedgerAccount, error) {
+	return r.findAccount(func(account *entity.LedgerAccount) bool {
+		return account.WalletID() != nil && account.WalletID().Equals(walletID)
+	}), nil
+}
+
+func (r *LedgerRepository) GetSystemAccount(ctx context.Context, code string) (*entity.LedgerAccount, error) {
+	account := r.findAccount(func(account *entity.LedgerAccount) bool {
+		return account.Kind() == entity.LedgerAccountKindSystem && account.Code() == code
+	})
+	if account == nil {
+		return nil, fmt.Errorf("system ledger account %s not found", code)
+	}
+	return account, nil
+}
+
+// CreateLedgerAccount enforces the uniqueness of account codes, like the
+// constraint on the ledger_accounts table.
+func (r *LedgerRepository) CreateLedgerAccount(ctx context.Context, account *entity.LedgerAccount) error {
+	existing := r.findAccount(func(other *entity.LedgerAccount) bool {
+		return other.Code() == account.Code()
+	})
+	if existing != nil {
+		return fmt.Errorf("ledger account %s already exists", account.Code())
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.ledgerAccounts = append(p.ledgerAccounts, account)
+	})
+	return nil
+}
+
+func (r *LedgerRepository) InsertJournalEntry(ctx context.Context, entry *entity.JournalEntry) error {
+	r.store.write(r.tx, func(p *pending) {
+		p.journalEntries = append(p.journalEntries, entry)
+	})
+	return nil
+}
+
+func (r *LedgerRepository) GetAccountTotals(ctx context.Context, accountID valueobject.UserID) (int64, int64, error) {
+	var debits, credits int64
+	for _, entry := range r.entries() {
+		for _, posting := range entry.Postings() {
+			if !posting.AccountID().Equals(accountID) {
+				continue
+			}
+			if posting.Direction() == entity.PostingDirectionDebit {
+				debits += posting.Amount().Amount()
+			} else {
+				credits += posting.Amount().Amount()
+			}
+		}
+	}
+	return debits, credits, nil
+}
+
+func (r *LedgerRepository) GetLedgerTotals(ctx context.Context) ([]repository.LedgerTotals, error) {
+	byCurrency := make(map[string]*repository.LedgerTotals)
+	for _, entry := range r.entries() {
+		for _, posting := range entry.Postings() {
+			currency := posting.Amount().Currency().Code()
+			total, ok := byCurrency[currency]
+			if !ok {
+				total = &repository.LedgerTotals{Currency: currency}
+				byCurrency[currency] = total
+			}
+			if posting.Direction() == entity.PostingDirectionDebit {
+				total.Debits += posting.Amount().Amount()
+			} else {
+				total.Credits += posting.Amount().Amount()
+			}
+		}
+	}
+
+	totals := make([]repository.LedgerTotals, 0, len(byCurrency))
+	for _, total := range byCurrency {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Currency < totals[j].Currency
+	})
+	return totals, nil
+}
+
+// findAccount returns the first account matching match, looking at the
+// accounts created by this unit of work before the committed ones.
+func (r *LedgerRepository) findAccount(match func(account *entity.LedgerAccount) bool) *entity.LedgerAccount {
+	if r.tx != nil {
+		for _, account := range r.tx.ledgerAccounts {
+			if match(account) {
+				return account
+			}
+		}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, account := range r.store.ledgerAccounts {
+		if match(account) {
+			return account
+		}
+	}
+	return nil
+}
+
+// entries returns the committed journal entries followed by those written by
+// this unit of work.
+func (r *LedgerRepository) entries() []*entity.JournalEntry {
+	r.store.mu.RLock()
+	entries := make([]*entity.JournalEntry, len(r.store.journalEntries))
+	copy(entries, r.store.journalEntries)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		entries = append(entries, r.tx.journalEntries...)
+	}
+	return entries
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type LimitRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewLimitRepository(store *Store) *LimitRepository {
+	return &LimitRepository{
+		store: store,
+	}
+}
+
+func (r *LimitRepository) GetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency) (entity.WithdrawalLimits, error) {
+	key := tierLimitsKey{tier: tier, currency: currency.Code()}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		if limits, ok := r.tx.tierLimits[key]; ok {
+			return copyLimits(limits), nil
+		}
+	}
+	return copyLimits(r.store.tierLimits[key]), nil
+}
+
+func (r *LimitRepository) SetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency, limits entity.WithdrawalLimits) error {
+	stored := copyLimits(limits)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.tierLimits[tierLimitsKey{tier: tier, currency: currency.Code()}] = stored
+	})
+	return nil
+}
+
+func (r *LimitRepository) ListTierLimits(ctx context.Context) (map[entity.WalletTier]map[string]entity.WithdrawalLimits, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	result := make(map[entity.WalletTier]map[string]entity.WithdrawalLimits)
+	add := func(key tierLimitsKey, limits entity.WithdrawalLimits) {
+		if result[key.tier] == nil {
+			result[key.tier] = make(map[string]entity.WithdrawalLimits)
+		}
+		result[key.tier][key.currency] = copyLimits(limits)
+	}
+
+	for key, limits := range r.store.tierLimits {
+		add(key, limits)
+	}
+	if r.tx != nil {
+		for key, limits := range r.tx.tierLimits {
+			add(key, limits)
+		}
+	}
+	return result, nil
+}
+
+func (r *LimitRepository) GetWalletLimits(ctx context.Context, walletID valueobject.UserID) (entity.WithdrawalLimits, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		if limits, ok := r.tx.walletLimits[walletID.String()]; ok {
+			return copyLimits(limits), nil
+		}
+	}
+	return copyLimits(r.store.walletLimits[walletID.String()]), nil
+}
+
+func (r *LimitRepository) SetWalletLimits(ctx context.Context, walletID valueobject.UserID, limits entity.WithdrawalLimits) error {
+	stored := copyLimits(limits)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.walletLimits[walletID.String()] = stored
+	})
+	return nil
+}
+
+// copyLimits copies the limit values so that the stored limits do not share
+// memory with the caller's.
+func copyLimits(limits entity.WithdrawalLimits) entity.WithdrawalLimits {
+	copyLimit := func(value *int64) *int64 {
+		if value == nil {
+			return nil
+		}
+		copied := *value
+		return &copied
+	}
+
+	return entity.WithdrawalLimits{
+		MinAmount:      copyLimit(limits.MinAmount),
+		PerTransaction: copyLimit(limits.PerTransaction),
+		Daily:          copyLimit(limits.Daily),
+		Monthly:        copyLimit(limits.Monthly),
+	}
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestLimitRepository(t *testing.T) {
+	limit := func(value int64) *int64 { return &value }
+
+	t.Run("should seed the default USD tier limits", func(t *testing.T) {
+		// Arrange
+		repo := NewLimitRepository(NewStore())
+
+		// Act
+		limits, err := repo.GetTierLimits(context.Background(), entity.WalletTierStandard, valueobject.DefaultCurrency())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if limits.Daily == nil || *limits.Daily != 1000000 {
+			t.Errorf("expected a daily limit of 1000000, got %v", limits.Daily)
+		}
+	})
+
+	t.Run("should keep wallet overrides only when the unit of work commits", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		walletID := valueobject.NewUserIDRandom()
+		unitOfWork := NewUnitOfWork(store)
+		overrides := entity.WithdrawalLimits{Daily: limit(5000)}
+
+		// Act
+		rollbackErr := errors.New("rollback")
+		rolledBack := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := repos.Limits.SetWalletLimits(ctx, walletID, overrides); err != nil {
+				return err
+			}
+			return rollbackErr
+		})
+		afterRollback, _ := NewLimitRepository(store).GetWalletLimits(context.Background(), walletID)
+
+		committed := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			return repos.Limits.SetWalletLimits(ctx, walletID, overrides)
+		})
+		afterCommit, _ := NewLimitRepository(store).GetWalletLimits(context.Background(), walletID)
+
+		// Assert
+		if !errors.Is(rolledBack, rollbackErr) {
+			t.Fatalf("expected the rollback error, got %v", rolledBack)
+		}
+		if !afterRollback.IsZero() {
+			t.Error("expected no overrides after rollback")
+		}
+		if committed != nil {
+			t.Fatalf("expected commit to succeed, got %v", committed)
+		}
+		if afterCommit.Daily == nil || *afterCommit.Daily != 5000 {
+			t.Errorf("expected a daily override of 5000, got %v", afterCommit.Daily)
+		}
+	})
+
+	t.Run("should remove overrides set to empty limits", func(t *testing.T) {
+		// Arrange
+		repo := NewLimitRepository(NewStore())
+		walletID := valueobject.NewUserIDRandom()
+		_ = repo.SetWalletLimits(context.Background(), walletID, entity.WithdrawalLimits{Monthly: limit(5000)})
+
+		// Act
+		_ = repo.SetWalletLimits(context.Background(), walletID, entity.WithdrawalLimits{})
+		limits, err := repo.GetWalletLimits(context.Background(), walletID)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !limits.IsZero() {
+			t.Error("expected the overrides to be removed")
+		}
+	})
+
+	t.Run("should not share limit values with the caller", func(t *testing.T) {
+		// Arrange
+		repo := NewLimitRepository(NewStore())
+		walletID := valueobject.NewUserIDRandom()
+		daily := int64(5000)
+		_ = repo.SetWalletLimits(context.Background(), walletID, entity.WithdrawalLimits{Daily: &daily})
+
+		// Act
+		daily = 1
+		limits, _ := repo.GetWalletLimits(context.Background(), walletID)
+
+		// Assert
+		if *limits.Daily != 5000 {
+			t.Errorf("expected the stored limit to stay 5000, got %d", *limits.Daily)
+		}
+	})
+}
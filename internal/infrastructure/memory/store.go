@@ -0,0 +1,332 @@
+// Package memory implements the repository interfaces on top of process
+// memory. It is meant for tests and for running the service locally without
+// PostgreSQL; nothing survives a restart.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// walletRow is the stored form of a wallet.
+type walletRow struct {
+	id        valueobject.UserID
+	userID    valueobject.UserID
+	name      string
+	isDefault bool
+	tier      entity.WalletTier
+	status    entity.WalletStatus
+	balance   int64
+	held      int64
+	overdraft int64
+	currency  valueobject.Currency
+}
+
+func newWalletRow(wallet *entity.Wallet) *walletRow {
+	return &walletRow{
+		id:        wallet.ID(),
+		userID:    wallet.UserID(),
+		name:      wallet.Name(),
+		isDefault: wallet.IsDefault(),
+		tier:      wallet.Tier(),
+		status:    wallet.Status(),
+		balance:   wallet.Balance().Amount(),
+		held:      wallet.HeldBalance().Amount(),
+		overdraft: wallet.OverdraftLimit().Amount(),
+		currency:  wallet.Currency(),
+	}
+}
+
+// Store holds the committed state shared by every repository and unit of work
+// created from it.
+type Store struct {
+	mu sync.RWMutex
+
+	users          map[string]*entity.User   // by user ID
+	userRefs       map[string]string         // external reference to user ID
+	apiKeys        map[string]*entity.APIKey // by key ID
+	wallets        map[string]*walletRow     // by wallet ID
+	defaultWallets map[string]string         // user ID to default wallet ID
+	transactions   []*entity.Transaction
+	idempotency    map[string]*entity.IdempotencyRecord
+	ledgerAccounts map[string]*entity.LedgerAccount // by account ID
+	journalEntries []*entity.JournalEntry
+	quotes         map[string]*entity.ExchangeQuote // by quote ID, as inserted
+	quotesUsed     map[string]time.Time             // quote ID to redemption time
+	holds          map[string]*entity.Hold          // by hold ID
+	tierLimits     map[tierLimitsKey]entity.WithdrawalLimits
+	walletLimits   map[string]entity.WithdrawalLimits // by wallet ID
+	statusChanges  []*entity.WalletStatusChange
+	auditEntries   []*entity.AuditEntry
+
+	withdrawalRequests map[string]*entity.WithdrawalRequest // by request ID
+	withdrawalEvents   []*entity.WithdrawalRequestEvent
+
+	locks *lockTable
+}
+
+// tierLimitsKey identifies the limits of a wallet tier in one currency.
+type tierLimitsKey struct {
+	tier     entity.WalletTier
+	currency string
+}
+
+// NewStore returns an empty store with the system ledger accounts and default
+// tier limits created, mirroring the rows seeded by the database schema.
+func NewStore() *Store {
+	s := &Store{
+		users:          make(map[string]*entity.User),
+		userRefs:       make(map[string]string),
+		apiKeys:        make(map[string]*entity.APIKey),
+		wallets:        make(map[string]*walletRow),
+		defaultWallets: make(map[string]string),
+		idempotency:    make(map[string]*entity.IdempotencyRecord),
+		ledgerAccounts: make(map[string]*entity.LedgerAccount),
+		quotes:         make(map[string]*entity.ExchangeQuote),
+		quotesUsed:     make(map[string]time.Time),
+		holds:          make(map[string]*entity.Hold),
+		tierLimits:     make(map[tierLimitsKey]entity.WithdrawalLimits),
+		walletLimits:   make(map[string]entity.WithdrawalLimits),
+
+		withdrawalRequests: make(map[string]*entity.WithdrawalRequest),
+
+		locks: newLockTable(),
+	}
+
+	for _, code := range []string{
+		entity.SystemAccountCashIn,
+		entity.SystemAccountCashOut,
+		entity.SystemAccountFees,
+		entity.SystemAccountOpeningBalance,
+		entity.SystemAccountFXPosition,
+		entity.SystemAccountFXRevenue,
+		entity.SystemAccountAdjustments,
+	} {
+		account := entity.ReconstructLedgerAccount(valueobject.NewUserIDRandom(), code, entity.LedgerAccountKindSystem, nil, time.Now().UTC())
+		s.ledgerAccounts[account.ID().String()] = account
+	}
+
+	s.tierLimits[tierLimitsKey{entity.WalletTierStandard, "USD"}] = entity.WithdrawalLimits{
+		PerTransaction: limit(500000),
+		Daily:          limit(1000000),
+		Monthly:        limit(5000000),
+	}
+	s.tierLimits[tierLimitsKey{entity.WalletTierPremium, "USD"}] = entity.WithdrawalLimits{
+		PerTransaction: limit(2500000),
+		Daily:          limit(5000000),
+		Monthly:        limit(25000000),
+	}
+
+	return s
+}
+
+func limit(value int64) *int64 {
+	return &value
+}
+
+// AddUser stores a user. Together with AddWallet it stands in for the rows
+// that are inserted directly into the users and wallets tables in a database
+// setup.
+func (s *Store) AddUser(user *entity.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.insertUser(user)
+}
+
+// AddWallet creates the default wallet of userID holding balance. Unlike
+// WalletRepository.CreateWallet, it does not check that the user exists.
+func (s *Store) AddWallet(userID valueobject.UserID, balance valueobject.Money) *entity.Wallet {
+	wallet := entity.NewWalletWithBalance(userID, balance)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.insertWallet(newWalletRow(wallet))
+
+	return wallet
+}
+
+// insertUser adds a user and its external reference index entry. The caller
+// must hold s.mu.
+func (s *Store) insertUser(user *entity.User) {
+	s.users[user.ID().String()] = user
+	if ref := user.ExternalRef(); ref != "" {
+		s.userRefs[ref] = user.ID().String()
+	}
+}
+
+// insertWallet adds a wallet row and its default wallet index entry. The
+// caller must hold s.mu.
+func (s *Store) insertWallet(row *walletRow) {
+	s.wallets[row.id.String()] = row
+	if row.isDefault {
+		s.defaultWallets[row.userID.String()] = row.id.String()
+	}
+}
+
+// pending buffers the writes of one unit of work until it commits, so other
+// callers only ever observe committed state.
+type pending struct {
+	users          []*entity.User
+	apiKeys        map[string]*entity.APIKey // by key ID, created or updated
+	wallets        []*walletRow
+	balances       map[string]int64               // wallet ID to new balance
+	heldBalances   map[string]int64               // wallet ID to new held balance
+	tiers          map[string]entity.WalletTier   // wallet ID to new tier
+	overdrafts     map[string]int64               // wallet ID to new overdraft limit
+	statuses       map[string]entity.WalletStatus // wallet ID to new status
+	statusChanges  []*entity.WalletStatusChange
+	auditEntries   []*entity.AuditEntry
+	transactions   []*entity.Transaction
+	idempotency    map[string]*entity.IdempotencyRecord
+	ledgerAccounts []*entity.LedgerAccount
+	journalEntries []*entity.JournalEntry
+	quotes         []*entity.ExchangeQuote
+	quotesUsed     map[string]time.Time
+	holds          map[string]*entity.Hold // by hold ID, inserted or updated
+	tierLimits     map[tierLimitsKey]entity.WithdrawalLimits
+	walletLimits   map[string]entity.WithdrawalLimits
+
+	withdrawalRequests map[string]*entity.WithdrawalRequest // by request ID, inserted or updated
+	withdrawalEvents   []*entity.WithdrawalRequestEvent
+
+	heldLocks []string
+}
+
+func newPending() *pending {
+	return &pending{
+		apiKeys:      make(map[string]*entity.APIKey),
+		balances:     make(map[string]int64),
+		heldBalances: make(map[string]int64),
+		tiers:        make(map[string]entity.WalletTier),
+		overdrafts:   make(map[string]int64),
+		statuses:     make(map[string]entity.WalletStatus),
+		idempotency:  make(map[string]*entity.IdempotencyRecord),
+		quotesUsed:   make(map[string]time.Time),
+		holds:        make(map[string]*entity.Hold),
+		tierLimits:   make(map[tierLimitsKey]entity.WithdrawalLimits),
+		walletLimits: make(map[string]entity.WithdrawalLimits),
+
+		withdrawalRequests: make(map[string]*entity.WithdrawalRequest),
+	}
+}
+
+// apply publishes the buffered writes. The caller must hold s.mu.
+func (s *Store) apply(p *pending) {
+	for _, user := range p.users {
+		s.insertUser(user)
+	}
+	for _, row := range p.wallets {
+		s.insertWallet(row)
+	}
+	for keyID, key := range p.apiKeys {
+		s.apiKeys[keyID] = key
+	}
+	for walletID, balance := range p.balances {
+		s.wallets[walletID].balance = balance
+	}
+	for walletID, held := range p.heldBalances {
+		s.wallets[walletID].held = held
+	}
+	for walletID, tier := range p.tiers {
+		s.wallets[walletID].tier = tier
+	}
+	for walletID, overdraft := range p.overdrafts {
+		s.wallets[walletID].overdraft = overdraft
+	}
+	for walletID, status := range p.statuses {
+		s.wallets[walletID].status = status
+	}
+	s.statusChanges = append(s.statusChanges, p.statusChanges...)
+	s.auditEntries = append(s.auditEntries, p.auditEntries...)
+	s.transactions = append(s.transactions, p.transactions...)
+	for key, record := range p.idempotency {
+		s.idempotency[key] = record
+	}
+	for _, account := range p.ledgerAccounts {
+		s.ledgerAccounts[account.ID().String()] = account
+	}
+	s.journalEntries = append(s.journalEntries, p.journalEntries...)
+	for _, quote := range p.quotes {
+		s.quotes[quote.ID().String()] = quote
+	}
+	for quoteID, usedAt := range p.quotesUsed {
+		s.quotesUsed[quoteID] = usedAt
+	}
+	for holdID, hold := range p.holds {
+		s.holds[holdID] = hold
+	}
+	for key, limits := range p.tierLimits {
+		s.tierLimits[key] = limits
+	}
+	for walletID, limits := range p.walletLimits {
+		if limits.IsZero() {
+			delete(s.walletLimits, walletID)
+			continue
+		}
+		s.walletLimits[walletID] = limits
+	}
+	for requestID, request := range p.withdrawalRequests {
+		s.withdrawalRequests[requestID] = request
+	}
+	s.withdrawalEvents = append(s.withdrawalEvents, p.withdrawalEvents...)
+}
+
+// lockTable emulates row locks. A lock is owned by a unit of work until it
+// commits or rolls back; other units of work asking for it wait, like a
+// SELECT ... FOR UPDATE on a locked row.
+type lockTable struct {
+	mu      sync.Mutex
+	owners  map[string]*pending
+	release map[string]chan struct{}
+}
+
+func newLockTable() *lockTable {
+	return &lockTable{
+		owners:  make(map[string]*pending),
+		release: make(map[string]chan struct{}),
+	}
+}
+
+func (l *lockTable) acquire(ctx context.Context, owner *pending, key string) error {
+	for {
+		l.mu.Lock()
+		current, held := l.owners[key]
+		if !held {
+			l.owners[key] = owner
+			l.release[key] = make(chan struct{})
+			owner.heldLocks = append(owner.heldLocks, key)
+			l.mu.Unlock()
+			return nil
+		}
+		if current == owner {
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.release[key]
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *lockTable) releaseAll(owner *pending) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range owner.heldLocks {
+		close(l.release[key])
+		delete(l.release, key)
+		delete(l.owners, key)
+	}
+	owner.heldLocks = nil
+}
@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type TransactionRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewTransactionRepository(store *Store) *TransactionRepository {
+	return &TransactionRepository{
+		store: store,
+	}
+}
+
+// InsertTransaction refuses a second reversal of the same transaction, like
+// the unique index on transactions.reversal_of.
+func (r *TransactionRepository) InsertTransaction(ctx context.Context, transaction *entity.Transaction) error {
+	if reversalOf := transaction.ReversalOf(); reversalOf != nil {
+		existing, err := r.GetReversal(ctx, *reversalOf)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return domain.ErrTransactionAlreadyReversed
+		}
+	}
+
+	// Store a copy so later changes to the caller's entity are not persisted.
+	stored := *transaction
+
+	r.store.write(r.tx, func(p *pending) {
+		p.transactions = append(p.transactions, &stored)
+	})
+	return nil
+}
+
+func (r *TransactionRepository) GetTransaction(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	transaction := r.find(func(transaction *entity.Transaction) bool {
+		return transaction.ID().Equals(transactionID)
+	})
+	if transaction == nil {
+		return nil, domain.ErrTransactionNotFound
+	}
+	return transaction, nil
+}
+
+// GetTransactionForUpdate locks the transaction for the rest of the unit of
+// work, waiting while another unit of work holds it.
+func (r *TransactionRepository) GetTransactionForUpdate(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "transaction:"+transactionID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.GetTransaction(ctx, transactionID)
+}
+
+func (r *TransactionRepository) GetReversal(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	return r.find(func(transaction *entity.Transaction) bool {
+		reversalOf := transaction.ReversalOf()
+		return reversalOf != nil && reversalOf.Equals(transactionID)
+	}), nil
+}
+
+func (r *TransactionRepository) SumWithdrawals(ctx context.Context, walletID valueobject.UserID, from, to time.Time) (int64, error) {
+	r.store.mu.RLock()
+	candidates := slices.Clone(r.store.transactions)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		candidates = append(candidates, r.tx.transactions...)
+	}
+
+	var total int64
+	for _, transaction := range candidates {
+		createdAt := transaction.CreatedAt()
+		if transaction.WalletID().Equals(walletID) &&
+			(transaction.Type() == entity.TransactionTypeWithdrawal || transaction.Type() == entity.TransactionTypeTransferOut) &&
+			transaction.Status() == entity.TransactionStatusCompleted &&
+			!createdAt.Before(from) && createdAt.Before(to) {
+			total += transaction.Amount().Amount()
+		}
+	}
+	return total, nil
+}
+
+// find returns a copy of the first transaction, committed or written by this
+// unit of work, that matches, or nil.
+func (r *TransactionRepository) find(matches func(*entity.Transaction) bool) *entity.Transaction {
+	r.store.mu.RLock()
+	candidates := slices.Clone(r.store.transactions)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		candidates = append(candidates, r.tx.transactions...)
+	}
+
+	for _, transaction := range candidates {
+		if matches(transaction) {
+			copied := *transaction
+			return &copied
+		}
+	}
+	return nil
+}
+
+// ListTransactions returns a wallet's transactions newest first, applying the
+// filter and starting after the filter's cursor.
+func (r *TransactionRepository) ListTransactions(ctx context.Context, walletID valueobject.UserID, filter repository.TransactionFilter) ([]*entity.Transaction, error) {
+	r.store.mu.RLock()
+	candidates := slices.Clone(r.store.transactions)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		candidates = append(candidates, r.tx.transactions...)
+	}
+
+	var transactions []*entity.Transaction
+	for _, transaction := range candidates {
+		if transaction.WalletID().Equals(walletID) && matchesFilter(transaction, filter) {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	slices.SortFunc(transactions, func(a, b *entity.Transaction) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		switch {
+		case a.ID().String() > b.ID().String():
+			return -1
+		case a.ID().String() < b.ID().String():
+			return 1
+		}
+		return 0
+	})
+
+	if filter.Limit > 0 && len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+	}
+
+	result := make([]*entity.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		copied := *transaction
+		result[i] = &copied
+	}
+	return result, nil
+}
+
+func matchesFilter(transaction *entity.Transaction, filter repository.TransactionFilter) bool {
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, transaction.Type()) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, transaction.Status()) {
+		return false
+	}
+
+	amount := transaction.Amount().Amount()
+	if filter.MinAmount != nil && amount < *filter.MinAmount {
+		return false
+	}
+	if filter.MaxAmount != nil && amount > *filter.MaxAmount {
+		return false
+	}
+
+	createdAt := transaction.CreatedAt()
+	if filter.From != nil && createdAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !createdAt.Before(*filter.To) {
+		return false
+	}
+
+	if filter.After != nil {
+		// Keep only rows where (created_at, id) < (cursor.CreatedAt, cursor.ID).
+		if c := createdAt.Compare(filter.After.CreatedAt); c > 0 || (c == 0 && transaction.ID().String() >= filter.After.ID.String()) {
+			return false
+		}
+	}
+
+	return true
+}
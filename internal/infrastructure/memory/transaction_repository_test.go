@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+func TestTransactionRepositoryReversals(t *testing.T) {
+	newWithdrawal := func(t *testing.T, repo *TransactionRepository) *entity.Transaction {
+		t.Helper()
+		amount, _ := valueobject.NewMoney(5000, valueobject.DefaultCurrency())
+		withdrawal := entity.NewTransaction(valueobject.NewUserIDRandom(), entity.TransactionTypeWithdrawal, amount)
+		_ = withdrawal.Complete()
+		if err := repo.InsertTransaction(context.Background(), withdrawal); err != nil {
+			t.Fatalf("unexpected error saving withdrawal: %v", err)
+		}
+		return withdrawal
+	}
+
+	t.Run("should find the reversal of a withdrawal", func(t *testing.T) {
+		// Arrange
+		repo := NewTransactionRepository(NewStore())
+		withdrawal := newWithdrawal(t, repo)
+		reversal, _ := entity.NewReversalTransaction(withdrawal, withdrawal.Amount(), "refund")
+		_ = repo.InsertTransaction(context.Background(), reversal)
+
+		// Act
+		found, err := repo.GetReversal(context.Background(), withdrawal.ID())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if found == nil || !found.ID().Equals(reversal.ID()) {
+			t.Error("expected to find the reversal")
+		}
+	})
+
+	t.Run("should refuse a second reversal", func(t *testing.T) {
+		// Arrange
+		repo := NewTransactionRepository(NewStore())
+		withdrawal := newWithdrawal(t, repo)
+		first, _ := entity.NewReversalTransaction(withdrawal, withdrawal.Amount(), "refund")
+		second, _ := entity.NewReversalTransaction(withdrawal, withdrawal.Amount(), "refund again")
+		_ = repo.InsertTransaction(context.Background(), first)
+
+		// Act
+		err := repo.InsertTransaction(context.Background(), second)
+
+		// Assert
+		if !errors.Is(err, domain.ErrTransactionAlreadyReversed) {
+			t.Errorf("expected ErrTransactionAlreadyReversed, got %v", err)
+		}
+	})
+
+	t.Run("should report a missing transaction", func(t *testing.T) {
+		// Arrange
+		repo := NewTransactionRepository(NewStore())
+
+		// Act
+		_, err := repo.GetTransactionForUpdate(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrTransactionNotFound) {
+			t.Errorf("expected ErrTransactionNotFound, got %v", err)
+		}
+	})
+}
+
+func TestTransactionRepositorySumWithdrawals(t *testing.T) {
+	t.Run("should only sum the wallet's completed withdrawals and outgoing transfers in the window", func(t *testing.T) {
+		// Arrange
+		repo := NewTransactionRepository(NewStore())
+		walletID := valueobject.NewUserIDRandom()
+		amount, _ := valueobject.NewMoney(1000, valueobject.DefaultCurrency())
+
+		for _, transaction := range []*entity.Transaction{
+			entity.NewTransaction(walletID, entity.TransactionTypeWithdrawal, amount),
+			entity.NewTransaction(walletID, entity.TransactionTypeWithdrawal, amount),
+			entity.NewTransferTransaction(walletID, entity.TransactionTypeTransferOut, amount, valueobject.NewUserIDRandom()),
+			entity.NewTransferTransaction(walletID, entity.TransactionTypeTransferIn, amount, valueobject.NewUserIDRandom()),
+			entity.NewTransaction(walletID, entity.TransactionTypeDeposit, amount),
+			entity.NewTransaction(valueobject.NewUserIDRandom(), entity.TransactionTypeWithdrawal, amount),
+		} {
+			_ = transaction.Complete()
+			_ = repo.InsertTransaction(context.Background(), transaction)
+		}
+		failed := entity.NewTransaction(walletID, entity.TransactionTypeWithdrawal, amount)
+		_ = failed.Fail("insufficient funds")
+		_ = repo.InsertTransaction(context.Background(), failed)
+
+		from := time.Now().Add(-time.Hour)
+		to := time.Now().Add(time.Hour)
+
+		// Act
+		total, err := repo.SumWithdrawals(context.Background(), walletID, from, to)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total != 3000 {
+			t.Errorf("expected 3000, got %d", total)
+		}
+	})
+
+	t.Run("should exclude withdrawals outside the window", func(t *testing.T) {
+		// Arrange
+		repo := NewTransactionRepository(NewStore())
+		walletID := valueobject.NewUserIDRandom()
+		amount, _ := valueobject.NewMoney(1000, valueobject.DefaultCurrency())
+		withdrawal := entity.NewTransaction(walletID, entity.TransactionTypeWithdrawal, amount)
+		_ = withdrawal.Complete()
+		_ = repo.InsertTransaction(context.Background(), withdrawal)
+
+		// Act
+		total, err := repo.SumWithdrawals(context.Background(), walletID, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total != 0 {
+			t.Errorf("expected 0, got %d", total)
+		}
+	})
+}
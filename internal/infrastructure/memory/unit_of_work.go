@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+
+	"bank/internal/domain/repository"
+)
+
+// UnitOfWork is the in-memory implementation of repository.UnitOfWork. Writes
+// made through the repositories it hands out are buffered and published at
+// once when fn succeeds; row locks taken along the way are held until then.
+type UnitOfWork struct {
+	store *Store
+}
+
+func NewUnitOfWork(store *Store) *UnitOfWork {
+	return &UnitOfWork{
+		store: store,
+	}
+}
+
+func (u *UnitOfWork) RunInTx(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) error {
+	tx := newPending()
+	defer u.store.locks.releaseAll(tx)
+
+	if err := fn(ctx, repository.Repositories{
+		Users:        &UserRepository{store: u.store, tx: tx},
+		APIKeys:      &APIKeyRepository{store: u.store, tx: tx},
+		Wallets:      &WalletRepository{store: u.store, tx: tx},
+		Transactions: &TransactionRepository{store: u.store, tx: tx},
+		Idempotency:  &IdempotencyRepository{store: u.store, tx: tx},
+		Ledger:       &LedgerRepository{store: u.store, tx: tx},
+		Quotes:       &ExchangeQuoteRepository{store: u.store, tx: tx},
+		Holds:        &HoldRepository{store: u.store, tx: tx},
+		Limits:       &LimitRepository{store: u.store, tx: tx},
+		Audit:        &AuditRepository{store: u.store, tx: tx},
+
+		WithdrawalRequests: &WithdrawalRequestRepository{store: u.store, tx: tx},
+	}); err != nil {
+		return err
+	}
+
+	// Honour cancellation the way a database commit would.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	u.store.mu.Lock()
+	u.store.apply(tx)
+	u.store.mu.Unlock()
+
+	return nil
+}
+
+// write runs fn against tx, or publishes its writes immediately when the
+// repository is used outside a unit of work.
+func (s *Store) write(tx *pending, fn func(p *pending)) {
+	if tx != nil {
+		fn(tx)
+		return
+	}
+
+	p := newPending()
+	fn(p)
+
+	s.mu.Lock()
+	s.apply(p)
+	s.mu.Unlock()
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestUnitOfWorkRunInTx(t *testing.T) {
+	t.Run("should publish writes when fn succeeds", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := valueobject.NewUserIDRandom()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		wallet := store.AddWallet(userID, balance)
+		uow := NewUnitOfWork(store)
+
+		// Act
+		err := uow.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			return repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), 7000)
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		stored, _ := NewWalletRepository(store).GetWallet(context.Background(), valueobject.DefaultWalletOf(userID))
+		if stored.Balance().Amount() != 7000 {
+			t.Errorf("expected balance 7000, got %d", stored.Balance().Amount())
+		}
+	})
+
+	t.Run("should discard writes when fn fails", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := valueobject.NewUserIDRandom()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		wallet := store.AddWallet(userID, balance)
+		uow := NewUnitOfWork(store)
+		failure := errors.New("boom")
+
+		// Act
+		err := uow.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), 7000); err != nil {
+				return err
+			}
+			amount, _ := valueobject.NewMoney(3000, valueobject.DefaultCurrency())
+			if err := repos.Transactions.InsertTransaction(ctx, entity.NewTransaction(wallet.ID(), entity.TransactionTypeWithdrawal, amount)); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		// Assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected fn's error, got %v", err)
+		}
+		stored, _ := NewWalletRepository(store).GetWallet(context.Background(), valueobject.DefaultWalletOf(userID))
+		if stored.Balance().Amount() != 10000 {
+			t.Errorf("expected balance 10000, got %d", stored.Balance().Amount())
+		}
+		transactions, _ := NewTransactionRepository(store).ListTransactions(context.Background(), wallet.ID(), repository.TransactionFilter{Limit: 10})
+		if len(transactions) != 0 {
+			t.Errorf("expected no transactions, got %d", len(transactions))
+		}
+	})
+
+	t.Run("should serialise units of work locking the same wallet", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := valueobject.NewUserIDRandom()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		store.AddWallet(userID, balance)
+		uow := NewUnitOfWork(store)
+		amount, _ := valueobject.NewMoney(100, valueobject.DefaultCurrency())
+
+		// Act
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = uow.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+					wallet, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.DefaultWalletOf(userID))
+					if err != nil {
+						return err
+					}
+					if err := wallet.Withdraw(amount); err != nil {
+						return err
+					}
+					return repos.Wallets.UpdateWalletBalance(ctx, wallet.ID(), wallet.Balance().Amount())
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		stored, _ := NewWalletRepository(store).GetWallet(context.Background(), valueobject.DefaultWalletOf(userID))
+		if stored.Balance().Amount() != 5000 {
+			t.Errorf("expected balance 5000, got %d", stored.Balance().Amount())
+		}
+	})
+
+	t.Run("should stop waiting for a lock when the context is cancelled", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := valueobject.NewUserIDRandom()
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		store.AddWallet(userID, balance)
+		uow := NewUnitOfWork(store)
+
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		go func() {
+			_ = uow.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+				if _, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.DefaultWalletOf(userID)); err != nil {
+					return err
+				}
+				close(locked)
+				<-release
+				return nil
+			})
+		}()
+		<-locked
+		defer close(release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		err := uow.RunInTx(ctx, func(ctx context.Context, repos repository.Repositories) error {
+			_, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.DefaultWalletOf(userID))
+			return err
+		})
+
+		// Assert
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
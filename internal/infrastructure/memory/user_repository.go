@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+type UserRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{
+		store: store,
+	}
+}
+
+// CreateUser enforces the uniqueness of external references like the index
+// on the users table. Units of work creating users with the same reference
+// are serialised so that both cannot pass the check.
+func (r *UserRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	if ref := user.ExternalRef(); ref != "" {
+		if r.tx != nil {
+			if err := r.store.locks.acquire(ctx, r.tx, "user-ref:"+ref); err != nil {
+				return err
+			}
+		}
+
+		r.store.mu.RLock()
+		taken := r.hasExternalRef(ref)
+		r.store.mu.RUnlock()
+		if taken {
+			return domain.ErrUserAlreadyExists
+		}
+	}
+
+	stored := *user
+	r.store.write(r.tx, func(p *pending) {
+		p.users = append(p.users, &stored)
+	})
+	return nil
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, userID valueobject.UserID) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user := lookupUser(r.store, r.tx, userID)
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// hasExternalRef reports whether a committed user or one created by this
+// unit of work has ref. The caller must hold r.store.mu.
+func (r *UserRepository) hasExternalRef(ref string) bool {
+	if _, ok := r.store.userRefs[ref]; ok {
+		return true
+	}
+	if r.tx != nil {
+		for _, user := range r.tx.users {
+			if user.ExternalRef() == ref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupUser finds a committed user or one created by tx, or nil. The caller
+// must hold store.mu.
+func lookupUser(store *Store, tx *pending, userID valueobject.UserID) *entity.User {
+	if user, ok := store.users[userID.String()]; ok {
+		return user
+	}
+	if tx != nil {
+		for _, user := range tx.users {
+			if user.ID().Equals(userID) {
+				return user
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestUserRepository(t *testing.T) {
+	t.Run("should find a created user", func(t *testing.T) {
+		// Arrange
+		repo := NewUserRepository(NewStore())
+		user, _ := entity.NewUser("Ada", "crm-1", time.Now())
+
+		// Act
+		err := repo.CreateUser(context.Background(), user)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		found, err := repo.GetUser(context.Background(), user.ID())
+		if err != nil || found.Name() != "Ada" || found.ExternalRef() != "crm-1" {
+			t.Errorf("expected to find Ada with crm-1, got %v (%v)", found, err)
+		}
+	})
+
+	t.Run("should reject a duplicate external reference", func(t *testing.T) {
+		// Arrange
+		repo := NewUserRepository(NewStore())
+		first, _ := entity.NewUser("Ada", "crm-1", time.Now())
+		second, _ := entity.NewUser("Grace", "crm-1", time.Now())
+		_ = repo.CreateUser(context.Background(), first)
+
+		// Act
+		err := repo.CreateUser(context.Background(), second)
+
+		// Assert
+		if !errors.Is(err, domain.ErrUserAlreadyExists) {
+			t.Errorf("expected ErrUserAlreadyExists, got %v", err)
+		}
+	})
+
+	t.Run("should allow several users without an external reference", func(t *testing.T) {
+		// Arrange
+		repo := NewUserRepository(NewStore())
+		first, _ := entity.NewUser("Ada", "", time.Now())
+		second, _ := entity.NewUser("Grace", "", time.Now())
+		_ = repo.CreateUser(context.Background(), first)
+
+		// Act
+		err := repo.CreateUser(context.Background(), second)
+
+		// Assert
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("should report a missing user", func(t *testing.T) {
+		// Act
+		_, err := NewUserRepository(NewStore()).GetUser(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should create a user and their wallet together or not at all", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		user, _ := entity.NewUser("Ada", "crm-1", time.Now())
+		wallet, _ := entity.NewWallet(user.ID(), entity.DefaultWalletName, valueobject.DefaultCurrency())
+		wallet.MarkDefault()
+		failure := errors.New("boom")
+
+		// Act
+		err := NewUnitOfWork(store).RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := repos.Users.CreateUser(ctx, user); err != nil {
+				return err
+			}
+			if err := repos.Wallets.CreateWallet(ctx, wallet); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		// Assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected fn's error, got %v", err)
+		}
+		if _, err := NewUserRepository(store).GetUser(context.Background(), user.ID()); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound after rollback, got %v", err)
+		}
+		retry, _ := entity.NewUser("Ada", "crm-1", time.Now())
+		if err := NewUserRepository(store).CreateUser(context.Background(), retry); err != nil {
+			t.Errorf("expected the reference to be free after rollback, got %v", err)
+		}
+	})
+}
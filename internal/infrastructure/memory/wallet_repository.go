@@ -0,0 +1,339 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type WalletRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewWalletRepository(store *Store) *WalletRepository {
+	return &WalletRepository{
+		store: store,
+	}
+}
+
+func (r *WalletRepository) GetWallet(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	row := r.resolve(ref)
+	if row == nil {
+		return nil, domain.ErrWalletNotFound
+	}
+	return r.load(row)
+}
+
+// GetWalletForUpdate locks the wallet for the rest of the unit of work,
+// waiting while another unit of work holds it.
+func (r *WalletRepository) GetWalletForUpdate(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error) {
+	r.store.mu.RLock()
+	row := r.resolve(ref)
+	r.store.mu.RUnlock()
+	if row == nil {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "wallet:"+row.id.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.load(row)
+}
+
+func (r *WalletRepository) ListWallets(ctx context.Context, userID valueobject.UserID) ([]*entity.Wallet, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var wallets []*entity.Wallet
+	for _, row := range r.rows() {
+		if !row.userID.Equals(userID) {
+			continue
+		}
+		wallet, err := r.load(row)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		if wallets[i].IsDefault() != wallets[j].IsDefault() {
+			return wallets[i].IsDefault()
+		}
+		return wallets[i].Name() < wallets[j].Name()
+	})
+	return wallets, nil
+}
+
+func (r *WalletRepository) SearchWallets(ctx context.Context, filter repository.WalletFilter) ([]*entity.Wallet, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var wallets []*entity.Wallet
+	for _, row := range r.rows() {
+		if filter.UserID != nil && !row.userID.Equals(*filter.UserID) {
+			continue
+		}
+		if filter.Currency != nil && !row.currency.Equals(*filter.Currency) {
+			continue
+		}
+		if filter.Name != "" && !strings.Contains(row.name, filter.Name) {
+			continue
+		}
+		if filter.After != nil && row.id.String() <= filter.After.String() {
+			continue
+		}
+
+		// Status and tier may have been changed by this unit of work.
+		wallet, err := r.load(row)
+		if err != nil {
+			return nil, err
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, wallet.Status()) {
+			continue
+		}
+		if len(filter.Tiers) > 0 && !slices.Contains(filter.Tiers, wallet.Tier()) {
+			continue
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].ID().String() < wallets[j].ID().String()
+	})
+	if filter.Limit > 0 && len(wallets) > filter.Limit {
+		wallets = wallets[:filter.Limit]
+	}
+	return wallets, nil
+}
+
+// CreateWallet enforces the existence of the user and the uniqueness of
+// wallet names and default wallets per user, like the constraints on the
+// wallets table. Units of work creating wallets for the same user are
+// serialised so that both cannot pass the check.
+func (r *WalletRepository) CreateWallet(ctx context.Context, wallet *entity.Wallet) error {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "user-wallets:"+wallet.UserID().String()); err != nil {
+			return err
+		}
+	}
+
+	r.store.mu.RLock()
+	if lookupUser(r.store, r.tx, wallet.UserID()) == nil {
+		r.store.mu.RUnlock()
+		return domain.ErrUserNotFound
+	}
+	for _, row := range r.rows() {
+		if row.userID.Equals(wallet.UserID()) && (row.name == wallet.Name() || (row.isDefault && wallet.IsDefault())) {
+			r.store.mu.RUnlock()
+			return domain.ErrWalletAlreadyExists
+		}
+	}
+	r.store.mu.RUnlock()
+
+	r.store.write(r.tx, func(p *pending) {
+		p.wallets = append(p.wallets, newWalletRow(wallet))
+	})
+	return nil
+}
+
+func (r *WalletRepository) UpdateWalletBalance(ctx context.Context, walletID valueobject.UserID, newBalance int64) error {
+	r.store.mu.RLock()
+	row := r.resolve(valueobject.WalletByID(walletID))
+	r.store.mu.RUnlock()
+	if row == nil {
+		return domain.ErrWalletNotFound
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.balances[walletID.String()] = newBalance
+	})
+	return nil
+}
+
+func (r *WalletRepository) UpdateWalletHeldBalance(ctx context.Context, walletID valueobject.UserID, newHeldBalance int64) error {
+	r.store.mu.RLock()
+	row := r.resolve(valueobject.WalletByID(walletID))
+	r.store.mu.RUnlock()
+	if row == nil {
+		return domain.ErrWalletNotFound
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.heldBalances[walletID.String()] = newHeldBalance
+	})
+	return nil
+}
+
+func (r *WalletRepository) UpdateWalletTier(ctx context.Context, walletID valueobject.UserID, tier entity.WalletTier) error {
+	r.store.mu.RLock()
+	row := r.resolve(valueobject.WalletByID(walletID))
+	r.store.mu.RUnlock()
+	if row == nil {
+		return domain.ErrWalletNotFound
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.tiers[walletID.String()] = tier
+	})
+	return nil
+}
+
+func (r *WalletRepository) UpdateWalletOverdraftLimit(ctx context.Context, walletID valueobject.UserID, overdraftLimit int64) error {
+	r.store.mu.RLock()
+	row := r.resolve(valueobject.WalletByID(walletID))
+	r.store.mu.RUnlock()
+	if row == nil {
+		return domain.ErrWalletNotFound
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.overdrafts[walletID.String()] = overdraftLimit
+	})
+	return nil
+}
+
+func (r *WalletRepository) UpdateWalletStatus(ctx context.Context, walletID valueobject.UserID, status entity.WalletStatus) error {
+	r.store.mu.RLock()
+	row := r.resolve(valueobject.WalletByID(walletID))
+	r.store.mu.RUnlock()
+	if row == nil {
+		return domain.ErrWalletNotFound
+	}
+
+	r.store.write(r.tx, func(p *pending) {
+		p.statuses[walletID.String()] = status
+	})
+	return nil
+}
+
+func (r *WalletRepository) InsertWalletStatusChange(ctx context.Context, change *entity.WalletStatusChange) error {
+	stored := *change
+	r.store.write(r.tx, func(p *pending) {
+		p.statusChanges = append(p.statusChanges, &stored)
+	})
+	return nil
+}
+
+// ListWalletStatusChanges returns the wallet's status changes, oldest first.
+func (r *WalletRepository) ListWalletStatusChanges(ctx context.Context, walletID valueobject.UserID) ([]*entity.WalletStatusChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	changes := r.store.statusChanges
+	if r.tx != nil {
+		changes = append(changes[:len(changes):len(changes)], r.tx.statusChanges...)
+	}
+
+	var result []*entity.WalletStatusChange
+	for _, change := range changes {
+		if change.WalletID().Equals(walletID) {
+			copied := *change
+			result = append(result, &copied)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ChangedAt().Before(result[j].ChangedAt())
+	})
+	return result, nil
+}
+
+// rows returns the committed wallets followed by those created by this unit
+// of work. The caller must hold r.store.mu.
+func (r *WalletRepository) rows() []*walletRow {
+	rows := make([]*walletRow, 0, len(r.store.wallets))
+	for _, row := range r.store.wallets {
+		rows = append(rows, row)
+	}
+	if r.tx != nil {
+		rows = append(rows, r.tx.wallets...)
+	}
+	return rows
+}
+
+// resolve finds the wallet a reference points to, or nil. The caller must
+// hold r.store.mu.
+func (r *WalletRepository) resolve(ref valueobject.WalletRef) *walletRow {
+	if userID, byUser := ref.UserID(); byUser {
+		if walletID, ok := r.store.defaultWallets[userID.String()]; ok {
+			return r.store.wallets[walletID]
+		}
+		if r.tx != nil {
+			for _, row := range r.tx.wallets {
+				if row.isDefault && row.userID.Equals(userID) {
+					return row
+				}
+			}
+		}
+		return nil
+	}
+
+	walletID, _ := ref.WalletID()
+	if row, ok := r.store.wallets[walletID.String()]; ok {
+		return row
+	}
+	if r.tx != nil {
+		for _, row := range r.tx.wallets {
+			if row.id.Equals(walletID) {
+				return row
+			}
+		}
+	}
+	return nil
+}
+
+// load builds the wallet as seen by this repository. The caller must hold
+// r.store.mu.
+func (r *WalletRepository) load(row *walletRow) (*entity.Wallet, error) {
+	balance, held, overdraft, tier, status := row.balance, row.held, row.overdraft, row.tier, row.status
+	if r.tx != nil {
+		if pendingBalance, ok := r.tx.balances[row.id.String()]; ok {
+			balance = pendingBalance
+		}
+		if pendingHeld, ok := r.tx.heldBalances[row.id.String()]; ok {
+			held = pendingHeld
+		}
+		if pendingOverdraft, ok := r.tx.overdrafts[row.id.String()]; ok {
+			overdraft = pendingOverdraft
+		}
+		if pendingTier, ok := r.tx.tiers[row.id.String()]; ok {
+			tier = pendingTier
+		}
+		if pendingStatus, ok := r.tx.statuses[row.id.String()]; ok {
+			status = pendingStatus
+		}
+	}
+
+	balanceVO, err := valueobject.NewBalance(balance, row.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	heldVO, err := valueobject.NewMoney(held, row.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	overdraftVO, err := valueobject.NewMoney(overdraft, row.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructWallet(row.id, row.userID, row.name, row.isDefault, tier, status, balanceVO, heldVO, overdraftVO), nil
+}
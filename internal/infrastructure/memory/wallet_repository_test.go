@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestWalletRepositoryCreateWallet(t *testing.T) {
+	t.Run("should resolve wallets by ID and the default wallet by user", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := addUser(store)
+		balance, _ := valueobject.NewMoney(10000, valueobject.DefaultCurrency())
+		mainWallet := store.AddWallet(userID, balance)
+		eur, _ := valueobject.NewCurrency("EUR")
+		savings, _ := entity.NewWallet(userID, "savings", eur)
+		repo := NewWalletRepository(store)
+
+		// Act
+		err := repo.CreateWallet(context.Background(), savings)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		byUser, _ := repo.GetWallet(context.Background(), valueobject.DefaultWalletOf(userID))
+		if byUser == nil || !byUser.ID().Equals(mainWallet.ID()) {
+			t.Errorf("expected the default wallet %s, got %v", mainWallet.ID(), byUser)
+		}
+		byID, _ := repo.GetWallet(context.Background(), valueobject.WalletByID(savings.ID()))
+		if byID == nil || byID.Currency().Code() != "EUR" || byID.IsDefault() {
+			t.Errorf("expected the non-default EUR savings wallet, got %v", byID)
+		}
+		wallets, _ := repo.ListWallets(context.Background(), userID)
+		if len(wallets) != 2 || wallets[0].Name() != entity.DefaultWalletName || wallets[1].Name() != "savings" {
+			t.Errorf("expected the default wallet followed by savings, got %d wallets", len(wallets))
+		}
+	})
+
+	t.Run("should reject a duplicate name or a second default wallet", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := addUser(store)
+		balance, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+		store.AddWallet(userID, balance)
+		duplicate, _ := entity.NewWallet(userID, entity.DefaultWalletName, valueobject.DefaultCurrency())
+		secondDefault, _ := entity.NewWallet(userID, "other", valueobject.DefaultCurrency())
+		secondDefault.MarkDefault()
+		repo := NewWalletRepository(store)
+
+		// Act
+		duplicateErr := repo.CreateWallet(context.Background(), duplicate)
+		defaultErr := repo.CreateWallet(context.Background(), secondDefault)
+
+		// Assert
+		if !errors.Is(duplicateErr, domain.ErrWalletAlreadyExists) {
+			t.Errorf("expected ErrWalletAlreadyExists for a duplicate name, got %v", duplicateErr)
+		}
+		if !errors.Is(defaultErr, domain.ErrWalletAlreadyExists) {
+			t.Errorf("expected ErrWalletAlreadyExists for a second default, got %v", defaultErr)
+		}
+	})
+
+	t.Run("should only publish a wallet created in a unit of work on commit", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		userID := addUser(store)
+		wallet, _ := entity.NewWallet(userID, "savings", valueobject.DefaultCurrency())
+		uow := NewUnitOfWork(store)
+		failure := errors.New("boom")
+
+		// Act
+		err := uow.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := repos.Wallets.CreateWallet(ctx, wallet); err != nil {
+				return err
+			}
+			if _, err := repos.Wallets.GetWalletForUpdate(ctx, valueobject.WalletByID(wallet.ID())); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		// Assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected fn's error, got %v", err)
+		}
+		if _, err := NewWalletRepository(store).GetWallet(context.Background(), valueobject.WalletByID(wallet.ID())); !errors.Is(err, domain.ErrWalletNotFound) {
+			t.Errorf("expected ErrWalletNotFound after rollback, got %v", err)
+		}
+	})
+}
+
+func TestWalletRepositoryCreateWalletUnknownUser(t *testing.T) {
+	t.Run("should reject a wallet for an unknown user", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		wallet, _ := entity.NewWallet(valueobject.NewUserIDRandom(), "savings", valueobject.DefaultCurrency())
+
+		// Act
+		err := NewWalletRepository(store).CreateWallet(context.Background(), wallet)
+
+		// Assert
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("expected ErrUserNotFound, got %v", err)
+		}
+	})
+}
+
+// addUser stores a user and returns their ID.
+func addUser(store *Store) valueobject.UserID {
+	user, _ := entity.NewUser("test user", "", time.Now())
+	store.AddUser(user)
+	return user.ID()
+}
+
+func TestWalletRepositoryStatus(t *testing.T) {
+	t.Run("should store the status and its history only once committed", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		balance, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+		wallet := store.AddWallet(valueobject.NewUserIDRandom(), balance)
+		repo := NewWalletRepository(store)
+		unitOfWork := NewUnitOfWork(store)
+		failure := errors.New("rolled back")
+
+		// Act
+		err := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			change, err := wallet.ChangeStatus(entity.WalletStatusFrozen, "fraud review", time.Now())
+			if err != nil {
+				return err
+			}
+			_ = repos.Wallets.UpdateWalletStatus(ctx, wallet.ID(), wallet.Status())
+			_ = repos.Wallets.InsertWalletStatusChange(ctx, change)
+
+			inTx, _ := repos.Wallets.GetWallet(ctx, valueobject.WalletByID(wallet.ID()))
+			if inTx.Status() != entity.WalletStatusFrozen {
+				t.Errorf("expected the unit of work to see FROZEN, got %s", inTx.Status())
+			}
+			return failure
+		})
+		rolledBack, _ := repo.GetWallet(context.Background(), valueobject.WalletByID(wallet.ID()))
+		rolledBackHistory, _ := repo.ListWalletStatusChanges(context.Background(), wallet.ID())
+		rolledBackStatus := rolledBack.Status()
+
+		change, _ := rolledBack.ChangeStatus(entity.WalletStatusClosed, "customer request", time.Now())
+		_ = repo.UpdateWalletStatus(context.Background(), wallet.ID(), rolledBack.Status())
+		_ = repo.InsertWalletStatusChange(context.Background(), change)
+
+		// Assert
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected the unit of work to fail, got %v", err)
+		}
+		if rolledBackStatus != entity.WalletStatusActive || len(rolledBackHistory) != 0 {
+			t.Errorf("expected the rolled back change to be discarded, got %s with %d changes", rolledBackStatus, len(rolledBackHistory))
+		}
+		stored, _ := repo.GetWallet(context.Background(), valueobject.WalletByID(wallet.ID()))
+		if stored.Status() != entity.WalletStatusClosed {
+			t.Errorf("expected status CLOSED, got %s", stored.Status())
+		}
+		history, _ := repo.ListWalletStatusChanges(context.Background(), wallet.ID())
+		if len(history) != 1 || history[0].From() != entity.WalletStatusActive || history[0].Reason() != "customer request" {
+			t.Errorf("expected the close from ACTIVE to be recorded, got %d changes", len(history))
+		}
+	})
+}
+
+func TestWalletRepositorySearchWallets(t *testing.T) {
+	t.Run("should filter by status as seen by the unit of work and page by ID", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		balance, _ := valueobject.NewMoney(0, valueobject.DefaultCurrency())
+		frozen := store.AddWallet(valueobject.NewUserIDRandom(), balance)
+		for i := 0; i < 3; i++ {
+			store.AddWallet(valueobject.NewUserIDRandom(), balance)
+		}
+		filter := repository.WalletFilter{Statuses: []entity.WalletStatus{entity.WalletStatusFrozen}}
+
+		// Act
+		var inTx []*entity.Wallet
+		_ = NewUnitOfWork(store).RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			_ = repos.Wallets.UpdateWalletStatus(ctx, frozen.ID(), entity.WalletStatusFrozen)
+			inTx, _ = repos.Wallets.SearchWallets(ctx, filter)
+			return errors.New("rolled back")
+		})
+		committed, _ := NewWalletRepository(store).SearchWallets(context.Background(), filter)
+		firstPage, _ := NewWalletRepository(store).SearchWallets(context.Background(), repository.WalletFilter{Limit: 2})
+		after := firstPage[1].ID()
+		secondPage, _ := NewWalletRepository(store).SearchWallets(context.Background(), repository.WalletFilter{After: &after, Limit: 2})
+
+		// Assert
+		if len(inTx) != 1 || !inTx[0].ID().Equals(frozen.ID()) {
+			t.Errorf("expected the unit of work to find the frozen wallet, got %d wallets", len(inTx))
+		}
+		if len(committed) != 0 {
+			t.Errorf("expected no frozen wallet after the rollback, got %d", len(committed))
+		}
+		if len(firstPage) != 2 || len(secondPage) != 2 || firstPage[0].ID().String() >= firstPage[1].ID().String() ||
+			secondPage[0].ID().String() <= after.String() {
+			t.Errorf("expected two pages of two wallets ordered by ID, got %d and %d", len(firstPage), len(secondPage))
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+type WithdrawalRequestRepository struct {
+	store *Store
+	tx    *pending
+}
+
+func NewWithdrawalRequestRepository(store *Store) *WithdrawalRequestRepository {
+	return &WithdrawalRequestRepository{
+		store: store,
+	}
+}
+
+func (r *WithdrawalRequestRepository) InsertWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error {
+	r.save(request)
+	return nil
+}
+
+func (r *WithdrawalRequestRepository) GetWithdrawalRequest(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	request := r.lookup(requestID.String())
+	if request == nil {
+		return nil, domain.ErrWithdrawalRequestNotFound
+	}
+	return copyWithdrawalRequest(request), nil
+}
+
+// GetWithdrawalRequestForUpdate locks the request for the rest of the unit of
+// work and returns a copy of it, so that changes only take effect through
+// UpdateWithdrawalRequest.
+func (r *WithdrawalRequestRepository) GetWithdrawalRequestForUpdate(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error) {
+	if r.tx != nil {
+		if err := r.store.locks.acquire(ctx, r.tx, "withdrawal-request:"+requestID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.GetWithdrawalRequest(ctx, requestID)
+}
+
+func (r *WithdrawalRequestRepository) UpdateWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error {
+	r.store.mu.RLock()
+	existing := r.lookup(request.ID().String())
+	r.store.mu.RUnlock()
+	if existing == nil {
+		return domain.ErrWithdrawalRequestNotFound
+	}
+
+	r.save(request)
+	return nil
+}
+
+// ListWithdrawalRequests returns the matching requests newest first, starting
+// after the filter's cursor.
+func (r *WithdrawalRequestRepository) ListWithdrawalRequests(ctx context.Context, filter repository.WithdrawalRequestFilter) ([]*entity.WithdrawalRequest, error) {
+	r.store.mu.RLock()
+	var requests []*entity.WithdrawalRequest
+	for requestID := range r.visibleIDs() {
+		request := r.lookup(requestID)
+		if matchesWithdrawalRequestFilter(request, filter) {
+			requests = append(requests, copyWithdrawalRequest(request))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(requests, func(a, b *entity.WithdrawalRequest) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		switch {
+		case a.ID().String() > b.ID().String():
+			return -1
+		case a.ID().String() < b.ID().String():
+			return 1
+		}
+		return 0
+	})
+
+	if filter.Limit > 0 && len(requests) > filter.Limit {
+		requests = requests[:filter.Limit]
+	}
+	return requests, nil
+}
+
+func (r *WithdrawalRequestRepository) ListExpiredWithdrawalRequests(ctx context.Context, now time.Time, limit int) ([]*entity.WithdrawalRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var expired []*entity.WithdrawalRequest
+	for requestID := range r.visibleIDs() {
+		request := r.lookup(requestID)
+		if request.Status() == entity.WithdrawalRequestStatusPending && request.IsExpired(now) {
+			expired = append(expired, copyWithdrawalRequest(request))
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt().Before(expired[j].ExpiresAt())
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+func (r *WithdrawalRequestRepository) InsertWithdrawalRequestEvent(ctx context.Context, event *entity.WithdrawalRequestEvent) error {
+	r.store.write(r.tx, func(p *pending) {
+		p.withdrawalEvents = append(p.withdrawalEvents, event)
+	})
+	return nil
+}
+
+func (r *WithdrawalRequestRepository) ListWithdrawalRequestEvents(ctx context.Context, requestID valueobject.UserID) ([]*entity.WithdrawalRequestEvent, error) {
+	r.store.mu.RLock()
+	candidates := slices.Clone(r.store.withdrawalEvents)
+	r.store.mu.RUnlock()
+
+	if r.tx != nil {
+		candidates = append(candidates, r.tx.withdrawalEvents...)
+	}
+
+	var events []*entity.WithdrawalRequestEvent
+	for _, event := range candidates {
+		if event.RequestID().Equals(requestID) {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt().Before(events[j].OccurredAt())
+	})
+	return events, nil
+}
+
+// visibleIDs returns the IDs of the requests this repository can see, those
+// inserted by its own unit of work included. The caller must hold
+// r.store.mu.
+func (r *WithdrawalRequestRepository) visibleIDs() map[string]struct{} {
+	ids := make(map[string]struct{}, len(r.store.withdrawalRequests))
+	for requestID := range r.store.withdrawalRequests {
+		ids[requestID] = struct{}{}
+	}
+	if r.tx != nil {
+		for requestID := range r.tx.withdrawalRequests {
+			ids[requestID] = struct{}{}
+		}
+	}
+	return ids
+}
+
+// lookup returns the request as seen by this repository, or nil. The caller
+// must hold r.store.mu.
+func (r *WithdrawalRequestRepository) lookup(requestID string) *entity.WithdrawalRequest {
+	if r.tx != nil {
+		if request, ok := r.tx.withdrawalRequests[requestID]; ok {
+			return request
+		}
+	}
+	return r.store.withdrawalRequests[requestID]
+}
+
+func (r *WithdrawalRequestRepository) save(request *entity.WithdrawalRequest) {
+	stored := copyWithdrawalRequest(request)
+
+	r.store.write(r.tx, func(p *pending) {
+		p.withdrawalRequests[stored.ID().String()] = stored
+	})
+}
+
+func matchesWithdrawalRequestFilter(request *entity.WithdrawalRequest, filter repository.WithdrawalRequestFilter) bool {
+	if filter.WalletID != nil && !request.WalletID().Equals(*filter.WalletID) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, request.Status()) {
+		return false
+	}
+	if filter.RequestedBy != "" && request.RequestedBy() != filter.RequestedBy {
+		return false
+	}
+
+	if filter.After != nil {
+		// Keep only rows where (created_at, id) < (cursor.CreatedAt, cursor.ID).
+		if c := request.CreatedAt().Compare(filter.After.CreatedAt); c > 0 || (c == 0 && request.ID().String() >= filter.After.ID.String()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyWithdrawalRequest(request *entity.WithdrawalRequest) *entity.WithdrawalRequest {
+	return entity.ReconstructWithdrawalRequest(
+		request.ID(),
+		request.WalletID(),
+		request.Amount(),
+		request.Status(),
+		request.RequestedBy(),
+		request.DecidedBy(),
+		request.TransactionID(),
+		request.ExpiresAt(),
+		request.CreatedAt(),
+		request.UpdatedAt(),
+	)
+}
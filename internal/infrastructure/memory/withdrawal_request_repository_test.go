@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+func TestWithdrawalRequestRepository(t *testing.T) {
+	newRequest := func(t *testing.T, walletID valueobject.UserID, ttl time.Duration) (*entity.WithdrawalRequest, *entity.WithdrawalRequestEvent) {
+		t.Helper()
+		amount, _ := valueobject.NewMoney(1500000, valueobject.DefaultCurrency())
+		request, event, err := entity.NewWithdrawalRequest(walletID, amount, "customer-1", time.Now(), ttl)
+		if err != nil {
+			t.Fatalf("unexpected error creating withdrawal request: %v", err)
+		}
+		return request, event
+	}
+
+	t.Run("should keep a decision and its history only when the unit of work commits", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewWithdrawalRequestRepository(store)
+		request, requested := newRequest(t, valueobject.NewUserIDRandom(), time.Hour)
+		_ = repo.InsertWithdrawalRequest(context.Background(), request)
+		_ = repo.InsertWithdrawalRequestEvent(context.Background(), requested)
+		unitOfWork := NewUnitOfWork(store)
+		reject := func(ctx context.Context, repos repository.Repositories) error {
+			stored, err := repos.WithdrawalRequests.GetWithdrawalRequestForUpdate(ctx, request.ID())
+			if err != nil {
+				return err
+			}
+			event, err := stored.Reject("ops-1", "not verified", time.Now())
+			if err != nil {
+				return err
+			}
+			if err := repos.WithdrawalRequests.UpdateWithdrawalRequest(ctx, stored); err != nil {
+				return err
+			}
+			return repos.WithdrawalRequests.InsertWithdrawalRequestEvent(ctx, event)
+		}
+
+		// Act
+		rollbackErr := errors.New("rollback")
+		rolledBack := unitOfWork.RunInTx(context.Background(), func(ctx context.Context, repos repository.Repositories) error {
+			if err := reject(ctx, repos); err != nil {
+				return err
+			}
+			return rollbackErr
+		})
+		afterRollback, _ := repo.GetWithdrawalRequest(context.Background(), request.ID())
+		committed := unitOfWork.RunInTx(context.Background(), reject)
+		afterCommit, _ := repo.GetWithdrawalRequest(context.Background(), request.ID())
+		history, _ := repo.ListWithdrawalRequestEvents(context.Background(), request.ID())
+
+		// Assert
+		if !errors.Is(rolledBack, rollbackErr) {
+			t.Fatalf("expected the rollback error, got %v", rolledBack)
+		}
+		if afterRollback.Status() != entity.WithdrawalRequestStatusPending {
+			t.Errorf("expected the request to stay pending after a rollback, got %s", afterRollback.Status())
+		}
+		if committed != nil {
+			t.Fatalf("expected the rejection to commit, got %v", committed)
+		}
+		if afterCommit.Status() != entity.WithdrawalRequestStatusRejected {
+			t.Errorf("expected the request to be rejected, got %s", afterCommit.Status())
+		}
+		if len(history) != 2 || history[0].Action() != entity.WithdrawalRequestActionRequested || history[1].Action() != entity.WithdrawalRequestActionRejected {
+			t.Errorf("expected the request and its rejection in the history, got %d events", len(history))
+		}
+	})
+
+	t.Run("should filter by wallet and status, newest first", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewWithdrawalRequestRepository(store)
+		walletID := valueobject.NewUserIDRandom()
+		older, _ := newRequest(t, walletID, time.Hour)
+		time.Sleep(time.Millisecond)
+		newer, _ := newRequest(t, walletID, time.Hour)
+		rejected, _ := newRequest(t, walletID, time.Hour)
+		_, _ = rejected.Reject("ops-1", "not verified", time.Now())
+		other, _ := newRequest(t, valueobject.NewUserIDRandom(), time.Hour)
+		for _, request := range []*entity.WithdrawalRequest{older, newer, rejected, other} {
+			_ = repo.InsertWithdrawalRequest(context.Background(), request)
+		}
+
+		// Act
+		requests, err := repo.ListWithdrawalRequests(context.Background(), repository.WithdrawalRequestFilter{
+			WalletID: &walletID,
+			Statuses: []entity.WithdrawalRequestStatus{entity.WithdrawalRequestStatusPending},
+			Limit:    10,
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(requests) != 2 || !requests[0].ID().Equals(newer.ID()) || !requests[1].ID().Equals(older.ID()) {
+			t.Errorf("expected the two pending requests of the wallet newest first, got %d", len(requests))
+		}
+	})
+
+	t.Run("should list only pending requests past their expiry", func(t *testing.T) {
+		// Arrange
+		store := NewStore()
+		repo := NewWithdrawalRequestRepository(store)
+		expired, _ := newRequest(t, valueobject.NewUserIDRandom(), time.Millisecond)
+		pending, _ := newRequest(t, valueobject.NewUserIDRandom(), time.Hour)
+		rejected, _ := newRequest(t, valueobject.NewUserIDRandom(), time.Millisecond)
+		_, _ = rejected.Reject("ops-1", "not verified", time.Now())
+		for _, request := range []*entity.WithdrawalRequest{expired, pending, rejected} {
+			_ = repo.InsertWithdrawalRequest(context.Background(), request)
+		}
+
+		// Act
+		requests, err := repo.ListExpiredWithdrawalRequests(context.Background(), time.Now().Add(time.Second), 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(requests) != 1 || !requests[0].ID().Equals(expired.ID()) {
+			t.Errorf("expected only the expired request, got %d", len(requests))
+		}
+	})
+
+	t.Run("should report a missing request", func(t *testing.T) {
+		// Arrange
+		repo := NewWithdrawalRequestRepository(NewStore())
+
+		// Act
+		_, err := repo.GetWithdrawalRequest(context.Background(), valueobject.NewUserIDRandom())
+
+		// Assert
+		if !errors.Is(err, domain.ErrWithdrawalRequestNotFound) {
+			t.Errorf("expected ErrWithdrawalRequestNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+package persistence
+
+import (
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"bank/internal/infrastructure/signing"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKeyRepository stores signing keys sealed by keys, never in clear: a
+// signing key is enough to sign requests as its owner.
+type APIKeyRepository struct {
+	db   queryer
+	keys *signing.KeyCipher
+}
+
+func NewAPIKeyRepository(db *sql.DB, keys *signing.KeyCipher) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:   db,
+		keys: keys,
+	}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, user_id, name, signing_key, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	sealed, err := r.keys.Seal(key.ID(), key.SigningKey())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		key.ID().String(),
+		key.UserID().String(),
+		key.Name(),
+		sealed,
+		pq.Array(key.Scopes()),
+		key.CreatedAt(),
+	)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error) {
+	return r.getAPIKey(ctx, keyID, "")
+}
+
+func (r *APIKeyRepository) GetAPIKeyForUpdate(ctx context.Context, keyID valueobject.UserID) (*entity.APIKey, error) {
+	return r.getAPIKey(ctx, keyID, "FOR UPDATE")
+}
+
+func (r *APIKeyRepository) getAPIKey(ctx context.Context, keyID valueobject.UserID, lockClause string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, signing_key, scopes, created_at, revoked_at
+		FROM api_keys
+		WHERE id = $1
+		` + lockClause + `;
+	`
+
+	key, err := r.scanAPIKey(r.db.QueryRowContext(ctx, query, keyID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (r *APIKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $1
+		WHERE id = $2;
+	`
+
+	result, err := r.db.ExecContext(ctx, query, key.RevokedAt(), key.ID().String())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, userID valueobject.UserID) ([]*entity.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, signing_key, scopes, created_at, revoked_at
+		FROM api_keys
+		WHERE user_id = $1
+		ORDER BY created_at;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		key, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var id, userID, name string
+	var sealed []byte
+	var scopes []string
+	var createdAt time.Time
+	var revokedAt sql.NullTime
+
+	if err := row.Scan(&id, &userID, &name, &sealed, pq.Array(&scopes), &createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	idVO, err := valueobject.NewUserID(id)
+	if err != nil {
+		return nil, err
+	}
+	userIDVO, err := valueobject.NewUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := r.keys.Open(idVO, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked *time.Time
+	if revokedAt.Valid {
+		revoked = &revokedAt.Time
+	}
+
+	return entity.ReconstructAPIKey(idVO, userIDVO, name, signingKey, scopes, createdAt, revoked), nil
+}
+
+// SealClearSigningKeys seals the signing keys stored in clear before
+// migration 0018, and returns how many it sealed. It runs at startup; a key
+// sealed concurrently by another instance is left alone.
+func (r *APIKeyRepository) SealClearSigningKeys(ctx context.Context) (int, error) {
+	clearKeys, err := r.listClearSigningKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE api_keys
+		SET signing_key = $1
+		WHERE id = $2 AND signing_key = $3;
+	`
+
+	sealedCount := 0
+	for id, signingKey := range clearKeys {
+		sealed, err := r.keys.Seal(id, signingKey)
+		if err != nil {
+			return sealedCount, err
+		}
+
+		result, err := r.db.ExecContext(ctx, query, sealed, id.String(), signingKey)
+		if err != nil {
+			return sealedCount, err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return sealedCount, err
+		}
+		sealedCount += int(rows)
+	}
+
+	return sealedCount, nil
+}
+
+func (r *APIKeyRepository) listClearSigningKeys(ctx context.Context) (map[valueobject.UserID][]byte, error) {
+	query := `
+		SELECT id, signing_key
+		FROM api_keys
+		WHERE octet_length(signing_key) = $1;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, entity.APIKeySigningKeySize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clearKeys := make(map[valueobject.UserID][]byte)
+	for rows.Next() {
+		var id string
+		var signingKey []byte
+		if err := rows.Scan(&id, &signingKey); err != nil {
+			return nil, err
+		}
+
+		idVO, err := valueobject.NewUserID(id)
+		if err != nil {
+			return nil, err
+		}
+		clearKeys[idVO] = signingKey
+	}
+
+	return clearKeys, rows.Err()
+}
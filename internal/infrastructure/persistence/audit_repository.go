@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AuditRepository struct {
+	db queryer
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) InsertAuditEntry(ctx context.Context, entry *entity.AuditEntry) error {
+	query := `
+		INSERT INTO admin_audit_log (id, actor, action, target_type, target_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`
+
+	details, err := json.Marshal(entry.Details())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		entry.ID().String(),
+		entry.Actor(),
+		string(entry.Action()),
+		string(entry.TargetType()),
+		entry.TargetID(),
+		entry.Reason(),
+		details,
+		entry.CreatedAt(),
+	)
+	return err
+}
+
+func (r *AuditRepository) ListAuditEntries(ctx context.Context, filter repository.AuditFilter) ([]*entity.AuditEntry, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+
+	if len(filter.Actions) > 0 {
+		actions := make([]string, len(filter.Actions))
+		for i, action := range filter.Actions {
+			actions[i] = string(action)
+		}
+		addCondition("action = ANY($%d)", pq.Array(actions))
+	}
+
+	if filter.TargetID != "" {
+		addCondition("target_id = $%d", filter.TargetID)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID.String())
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT id, actor, action, target_type, target_id, reason, details, created_at
+		FROM admin_audit_log
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d;
+	`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.AuditEntry
+	for rows.Next() {
+		var id, actor, action, targetType, targetID, reason string
+		var details []byte
+		var createdAt time.Time
+		if err := rows.Scan(&id, &actor, &action, &targetType, &targetID, &reason, &details, &createdAt); err != nil {
+			return nil, err
+		}
+
+		idVO, err := valueobject.NewUserID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		var detailsMap map[string]string
+		if err := json.Unmarshal(details, &detailsMap); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entity.ReconstructAuditEntry(
+			idVO, actor, entity.AuditAction(action), entity.AuditTargetType(targetType), targetID, reason, detailsMap, createdAt,
+		))
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,149 @@
+package persistence
+
+import (
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type ExchangeQuoteRepository struct {
+	db queryer
+}
+
+func NewExchangeQuoteRepository(db *sql.DB) *ExchangeQuoteRepository {
+	return &ExchangeQuoteRepository{
+		db: db,
+	}
+}
+
+func (r *ExchangeQuoteRepository) InsertQuote(ctx context.Context, quote *entity.ExchangeQuote) error {
+	query := `
+		INSERT INTO exchange_quotes (
+			id, user_id, from_wallet_id, to_wallet_id, rate,
+			source_amount, source_currency, fee, target_amount, target_currency,
+			created_at, expires_at, used_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+	`
+
+	var usedAt sql.NullTime
+	if t := quote.UsedAt(); t != nil {
+		usedAt = sql.NullTime{Time: *t, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		quote.ID().String(),
+		quote.UserID().String(),
+		quote.FromWalletID().String(),
+		quote.ToWalletID().String(),
+		quote.Rate().String(),
+		quote.SourceAmount().Amount(),
+		quote.SourceAmount().Currency().Code(),
+		quote.Fee().Amount(),
+		quote.TargetAmount().Amount(),
+		quote.TargetAmount().Currency().Code(),
+		quote.CreatedAt(),
+		quote.ExpiresAt(),
+		usedAt,
+	)
+	return err
+}
+
+func (r *ExchangeQuoteRepository) GetQuoteForUpdate(ctx context.Context, quoteID valueobject.UserID) (*entity.ExchangeQuote, error) {
+	query := `
+		SELECT id, user_id, from_wallet_id, to_wallet_id, rate,
+			source_amount, source_currency, fee, target_amount, target_currency,
+			created_at, expires_at, used_at
+		FROM exchange_quotes
+		WHERE id = $1
+		FOR UPDATE;
+	`
+
+	var id, userID, fromWalletID, toWalletID, rate string
+	var sourceAmount, fee, targetAmount int64
+	var sourceCurrency, targetCurrency string
+	var createdAt, expiresAt time.Time
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, quoteID.String()).Scan(
+		&id, &userID, &fromWalletID, &toWalletID, &rate,
+		&sourceAmount, &sourceCurrency, &fee, &targetAmount, &targetCurrency,
+		&createdAt, &expiresAt, &usedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrQuoteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]valueobject.UserID, 0, 4)
+	for _, raw := range []string{id, userID, fromWalletID, toWalletID} {
+		idVO, err := valueobject.NewUserID(raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, idVO)
+	}
+
+	sourceCurrencyVO, err := valueobject.NewCurrency(sourceCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	targetCurrencyVO, err := valueobject.NewCurrency(targetCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	rateVO, err := valueobject.NewExchangeRate(sourceCurrencyVO, targetCurrencyVO, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceAmountVO, err := valueobject.NewMoney(sourceAmount, sourceCurrencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	feeVO, err := valueobject.NewMoney(fee, sourceCurrencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	targetAmountVO, err := valueobject.NewMoney(targetAmount, targetCurrencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	var usedAtPtr *time.Time
+	if usedAt.Valid {
+		usedAtPtr = &usedAt.Time
+	}
+
+	return entity.ReconstructExchangeQuote(
+		ids[0], ids[1], ids[2], ids[3],
+		rateVO,
+		sourceAmountVO,
+		feeVO,
+		targetAmountVO,
+		createdAt,
+		expiresAt,
+		usedAtPtr,
+	), nil
+}
+
+func (r *ExchangeQuoteRepository) MarkQuoteUsed(ctx context.Context, quote *entity.ExchangeQuote) error {
+	query := `
+		UPDATE exchange_quotes
+		SET used_at = $1
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, quote.UsedAt(), quote.ID().String())
+	return err
+}
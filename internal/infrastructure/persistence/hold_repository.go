@@ -0,0 +1,196 @@
+package persistence
+
+import (
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type HoldRepository struct {
+	db queryer
+}
+
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{
+		db: db,
+	}
+}
+
+func (r *HoldRepository) InsertHold(ctx context.Context, hold *entity.Hold) error {
+	query := `
+		INSERT INTO holds (
+			id, wallet_id, amount, captured_amount, currency, status,
+			transaction_id, expires_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	`
+
+	var transactionID sql.NullString
+	if id := hold.TransactionID(); id != nil {
+		transactionID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		hold.ID().String(),
+		hold.WalletID().String(),
+		hold.Amount().Amount(),
+		hold.CapturedAmount().Amount(),
+		hold.Amount().Currency().Code(),
+		string(hold.Status()),
+		transactionID,
+		hold.ExpiresAt(),
+		hold.CreatedAt(),
+		hold.UpdatedAt(),
+	)
+	return err
+}
+
+func (r *HoldRepository) GetHold(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error) {
+	return r.getHold(ctx, holdID, "")
+}
+
+func (r *HoldRepository) GetHoldForUpdate(ctx context.Context, holdID valueobject.UserID) (*entity.Hold, error) {
+	return r.getHold(ctx, holdID, "FOR UPDATE")
+}
+
+func (r *HoldRepository) getHold(ctx context.Context, holdID valueobject.UserID, lockClause string) (*entity.Hold, error) {
+	query := `
+		SELECT id, wallet_id, amount, captured_amount, currency, status,
+			transaction_id, expires_at, created_at, updated_at
+		FROM holds
+		WHERE id = $1
+		` + lockClause + `;
+	`
+
+	hold, err := scanHold(r.db.QueryRowContext(ctx, query, holdID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrHoldNotFound
+	}
+	return hold, err
+}
+
+func (r *HoldRepository) UpdateHold(ctx context.Context, hold *entity.Hold) error {
+	query := `
+		UPDATE holds
+		SET captured_amount = $1, status = $2, transaction_id = $3, updated_at = $4
+		WHERE id = $5;
+	`
+
+	var transactionID sql.NullString
+	if id := hold.TransactionID(); id != nil {
+		transactionID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		hold.CapturedAmount().Amount(),
+		string(hold.Status()),
+		transactionID,
+		hold.UpdatedAt(),
+		hold.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrHoldNotFound
+	}
+	return nil
+}
+
+func (r *HoldRepository) ListExpiredHolds(ctx context.Context, now time.Time, limit int) ([]*entity.Hold, error) {
+	query := `
+		SELECT id, wallet_id, amount, captured_amount, currency, status,
+			transaction_id, expires_at, created_at, updated_at
+		FROM holds
+		WHERE status = 'ACTIVE' AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holds []*entity.Hold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, rows.Err()
+}
+
+func scanHold(row rowScanner) (*entity.Hold, error) {
+	var id, walletID string
+	var amount, capturedAmount int64
+	var currency, status string
+	var transactionID sql.NullString
+	var expiresAt, createdAt, updatedAt time.Time
+
+	if err := row.Scan(
+		&id, &walletID, &amount, &capturedAmount, &currency, &status,
+		&transactionID, &expiresAt, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	idVO, err := valueobject.NewUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyVO, err := valueobject.NewCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	amountVO, err := valueobject.NewMoney(amount, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	capturedAmountVO, err := valueobject.NewMoney(capturedAmount, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionIDVO *valueobject.UserID
+	if transactionID.Valid {
+		parsed, err := valueobject.NewUserID(transactionID.String)
+		if err != nil {
+			return nil, err
+		}
+		transactionIDVO = &parsed
+	}
+
+	return entity.ReconstructHold(
+		idVO,
+		walletIDVO,
+		amountVO,
+		capturedAmountVO,
+		entity.HoldStatus(status),
+		transactionIDVO,
+		expiresAt,
+		createdAt,
+		updatedAt,
+	), nil
+}
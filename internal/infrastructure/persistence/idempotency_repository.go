@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"bank/internal/domain/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db queryer
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+// GetIdempotencyRecordForUpdate takes a transaction-scoped advisory lock on the
+// key before reading it. A row lock is not enough because the first request
+// for a key has no row to lock yet.
+func (r *IdempotencyRepository) GetIdempotencyRecordForUpdate(ctx context.Context, key string) (*entity.IdempotencyRecord, error) {
+	if _, err := r.db.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1));`, key); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT key, request_hash, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1;
+	`
+
+	var dbKey string
+	var requestHash string
+	var responseBody []byte
+	var createdAt time.Time
+	var expiresAt time.Time
+
+	err := r.db.QueryRowContext(ctx, query, key).Scan(
+		&dbKey,
+		&requestHash,
+		&responseBody,
+		&createdAt,
+		&expiresAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entity.ReconstructIdempotencyRecord(dbKey, requestHash, responseBody, createdAt, expiresAt), nil
+}
+
+// SaveIdempotencyRecord inserts the record, replacing an expired record that
+// used the same key.
+func (r *IdempotencyRepository) SaveIdempotencyRecord(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash, response_body, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			response_body = EXCLUDED.response_body,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at;
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.Key(),
+		record.RequestHash(),
+		record.ResponseBody(),
+		record.CreatedAt(),
+		record.ExpiresAt(),
+	)
+
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyRecords(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= $1;
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
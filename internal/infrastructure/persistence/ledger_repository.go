@@ -0,0 +1,180 @@
+package persistence
+
+import (
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type LedgerRepository struct {
+	db queryer
+}
+
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{
+		db: db,
+	}
+}
+
+func (r *LedgerRepository) GetWalletAccount(ctx context.Context, walletID valueobject.UserID) (*entity.LedgerAccount, error) {
+	query := `
+		SELECT id, code, kind, wallet_id, created_at
+		FROM ledger_accounts
+		WHERE wallet_id = $1;
+	`
+
+	account, err := scanLedgerAccount(r.db.QueryRowContext(ctx, query, walletID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return account, err
+}
+
+func (r *LedgerRepository) GetSystemAccount(ctx context.Context, code string) (*entity.LedgerAccount, error) {
+	query := `
+		SELECT id, code, kind, wallet_id, created_at
+		FROM ledger_accounts
+		WHERE code = $1 AND kind = 'SYSTEM';
+	`
+
+	account, err := scanLedgerAccount(r.db.QueryRowContext(ctx, query, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("system ledger account %s not found", code)
+	}
+	return account, err
+}
+
+func (r *LedgerRepository) CreateLedgerAccount(ctx context.Context, account *entity.LedgerAccount) error {
+	query := `
+		INSERT INTO ledger_accounts (id, code, kind, wallet_id, created_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	var walletID sql.NullString
+	if id := account.WalletID(); id != nil {
+		walletID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		account.ID().String(),
+		account.Code(),
+		string(account.Kind()),
+		walletID,
+		account.CreatedAt(),
+	)
+
+	return err
+}
+
+// InsertJournalEntry writes the entry header and its postings. The database
+// re-checks at commit time that the postings of the entry balance.
+func (r *LedgerRepository) InsertJournalEntry(ctx context.Context, entry *entity.JournalEntry) error {
+	entryQuery := `
+		INSERT INTO journal_entries (id, reference, description, created_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	if _, err := r.db.ExecContext(ctx, entryQuery,
+		entry.ID().String(),
+		entry.Reference(),
+		entry.Description(),
+		entry.CreatedAt(),
+	); err != nil {
+		return err
+	}
+
+	postingQuery := `
+		INSERT INTO postings (journal_entry_id, account_id, direction, amount, currency)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	for _, posting := range entry.Postings() {
+		if _, err := r.db.ExecContext(ctx, postingQuery,
+			entry.ID().String(),
+			posting.AccountID().String(),
+			string(posting.Direction()),
+			posting.Amount().Amount(),
+			posting.Amount().Currency().Code(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *LedgerRepository) GetAccountTotals(ctx context.Context, accountID valueobject.UserID) (int64, int64, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'DEBIT'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'CREDIT'), 0)
+		FROM postings
+		WHERE account_id = $1;
+	`
+
+	var debits, credits int64
+	err := r.db.QueryRowContext(ctx, query, accountID.String()).Scan(&debits, &credits)
+	return debits, credits, err
+}
+
+func (r *LedgerRepository) GetLedgerTotals(ctx context.Context) ([]repository.LedgerTotals, error) {
+	query := `
+		SELECT
+			currency,
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'DEBIT'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'CREDIT'), 0)
+		FROM postings
+		GROUP BY currency
+		ORDER BY currency;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []repository.LedgerTotals
+	for rows.Next() {
+		var total repository.LedgerTotals
+		if err := rows.Scan(&total.Currency, &total.Debits, &total.Credits); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, rows.Err()
+}
+
+func scanLedgerAccount(row *sql.Row) (*entity.LedgerAccount, error) {
+	var id string
+	var code string
+	var kind string
+	var walletID sql.NullString
+	var createdAt time.Time
+
+	if err := row.Scan(&id, &code, &kind, &walletID, &createdAt); err != nil {
+		return nil, err
+	}
+
+	idVO, err := valueobject.NewUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var walletIDVO *valueobject.UserID
+	if walletID.Valid {
+		parsed, err := valueobject.NewUserID(walletID.String)
+		if err != nil {
+			return nil, err
+		}
+		walletIDVO = &parsed
+	}
+
+	return entity.ReconstructLedgerAccount(idVO, code, entity.LedgerAccountKind(kind), walletIDVO, createdAt), nil
+}
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type LimitRepository struct {
+	db queryer
+}
+
+func NewLimitRepository(db *sql.DB) *LimitRepository {
+	return &LimitRepository{
+		db: db,
+	}
+}
+
+func (r *LimitRepository) GetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency) (entity.WithdrawalLimits, error) {
+	query := `
+		SELECT min_amount, per_transaction, daily, monthly
+		FROM tier_limits
+		WHERE tier = $1 AND currency = $2;
+	`
+
+	limits, err := scanLimits(r.db.QueryRowContext(ctx, query, string(tier), currency.Code()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WithdrawalLimits{}, nil
+	}
+	return limits, err
+}
+
+func (r *LimitRepository) SetTierLimits(ctx context.Context, tier entity.WalletTier, currency valueobject.Currency, limits entity.WithdrawalLimits) error {
+	query := `
+		INSERT INTO tier_limits (tier, currency, min_amount, per_transaction, daily, monthly)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tier, currency) DO UPDATE
+		SET min_amount = EXCLUDED.min_amount,
+			per_transaction = EXCLUDED.per_transaction,
+			daily = EXCLUDED.daily,
+			monthly = EXCLUDED.monthly,
+			updated_at = NOW();
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		string(tier),
+		currency.Code(),
+		nullLimit(limits.MinAmount),
+		nullLimit(limits.PerTransaction),
+		nullLimit(limits.Daily),
+		nullLimit(limits.Monthly),
+	)
+	return err
+}
+
+func (r *LimitRepository) ListTierLimits(ctx context.Context) (map[entity.WalletTier]map[string]entity.WithdrawalLimits, error) {
+	query := `
+		SELECT tier, currency, min_amount, per_transaction, daily, monthly
+		FROM tier_limits
+		ORDER BY tier, currency;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[entity.WalletTier]map[string]entity.WithdrawalLimits)
+	for rows.Next() {
+		var tier, currency string
+		var minAmount, perTransaction, daily, monthly sql.NullInt64
+		if err := rows.Scan(&tier, &currency, &minAmount, &perTransaction, &daily, &monthly); err != nil {
+			return nil, err
+		}
+
+		walletTier := entity.WalletTier(tier)
+		if result[walletTier] == nil {
+			result[walletTier] = make(map[string]entity.WithdrawalLimits)
+		}
+		result[walletTier][currency] = entity.WithdrawalLimits{
+			MinAmount:      limitValue(minAmount),
+			PerTransaction: limitValue(perTransaction),
+			Daily:          limitValue(daily),
+			Monthly:        limitValue(monthly),
+		}
+	}
+
+	return result, rows.Err()
+}
+
+func (r *LimitRepository) GetWalletLimits(ctx context.Context, walletID valueobject.UserID) (entity.WithdrawalLimits, error) {
+	query := `
+		SELECT min_amount, per_transaction, daily, monthly
+		FROM wallet_limits
+		WHERE wallet_id = $1;
+	`
+
+	limits, err := scanLimits(r.db.QueryRowContext(ctx, query, walletID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.WithdrawalLimits{}, nil
+	}
+	return limits, err
+}
+
+func (r *LimitRepository) SetWalletLimits(ctx context.Context, walletID valueobject.UserID, limits entity.WithdrawalLimits) error {
+	if limits.IsZero() {
+		_, err := r.db.ExecContext(ctx, `DELETE FROM wallet_limits WHERE wallet_id = $1;`, walletID.String())
+		return err
+	}
+
+	query := `
+		INSERT INTO wallet_limits (wallet_id, min_amount, per_transaction, daily, monthly)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (wallet_id) DO UPDATE
+		SET min_amount = EXCLUDED.min_amount,
+			per_transaction = EXCLUDED.per_transaction,
+			daily = EXCLUDED.daily,
+			monthly = EXCLUDED.monthly,
+			updated_at = NOW();
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		walletID.String(),
+		nullLimit(limits.MinAmount),
+		nullLimit(limits.PerTransaction),
+		nullLimit(limits.Daily),
+		nullLimit(limits.Monthly),
+	)
+	return err
+}
+
+func scanLimits(row rowScanner) (entity.WithdrawalLimits, error) {
+	var minAmount, perTransaction, daily, monthly sql.NullInt64
+	if err := row.Scan(&minAmount, &perTransaction, &daily, &monthly); err != nil {
+		return entity.WithdrawalLimits{}, err
+	}
+
+	return entity.WithdrawalLimits{
+		MinAmount:      limitValue(minAmount),
+		PerTransaction: limitValue(perTransaction),
+		Daily:          limitValue(daily),
+		Monthly:        limitValue(monthly),
+	}, nil
+}
+
+func nullLimit(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+func limitValue(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
@@ -1,13 +1,22 @@
 package persistence
 
 import (
+	"bank/internal/domain"
 	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type TransactionRepository struct {
-	db *sql.DB
+	db queryer
 }
 
 func NewTransactionRepository(db *sql.DB) *TransactionRepository {
@@ -16,19 +25,251 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	}
 }
 
-// InsertTransaction inserts transaction record inside a transaction
-func (r *TransactionRepository) InsertTransaction(ctx context.Context, tx *sql.Tx, transaction *entity.Transaction) error {
+// InsertTransaction inserts a transaction record
+func (r *TransactionRepository) InsertTransaction(ctx context.Context, transaction *entity.Transaction) error {
 	query := `
-		INSERT INTO transactions (id, wallet_id, amount, transaction_type, created_at)
-		VALUES ($1, $2, $3, $4, NOW());
+		INSERT INTO transactions (id, wallet_id, amount, currency, transaction_type, status, failure_reason, transfer_id, reversal_of, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 	`
 
-	_, err := tx.ExecContext(ctx, query,
+	var failureReason sql.NullString
+	if reason := transaction.FailureReason(); reason != "" {
+		failureReason = sql.NullString{String: reason, Valid: true}
+	}
+
+	var transferID sql.NullString
+	if id := transaction.TransferID(); id != nil {
+		transferID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	var reversalOf sql.NullString
+	if id := transaction.ReversalOf(); id != nil {
+		reversalOf = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	var reason sql.NullString
+	if transaction.Reason() != "" {
+		reason = sql.NullString{String: transaction.Reason(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
 		transaction.ID().String(),
 		transaction.WalletID().String(),
 		transaction.Amount().Amount(),
+		transaction.Amount().Currency().Code(),
 		string(transaction.Type()),
+		string(transaction.Status()),
+		failureReason,
+		transferID,
+		reversalOf,
+		reason,
+		transaction.CreatedAt(),
 	)
 
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation && transaction.ReversalOf() != nil {
+		return domain.ErrTransactionAlreadyReversed
+	}
 	return err
 }
+
+func (r *TransactionRepository) GetTransaction(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	return r.getTransaction(ctx, transactionID, "")
+}
+
+func (r *TransactionRepository) GetTransactionForUpdate(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	return r.getTransaction(ctx, transactionID, "FOR UPDATE")
+}
+
+func (r *TransactionRepository) getTransaction(ctx context.Context, transactionID valueobject.UserID, lockClause string) (*entity.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE id = $1
+		` + lockClause + `;
+	`
+
+	transaction, err := scanTransaction(r.db.QueryRowContext(ctx, query, transactionID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTransactionNotFound
+	}
+	return transaction, err
+}
+
+func (r *TransactionRepository) GetReversal(ctx context.Context, transactionID valueobject.UserID) (*entity.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE reversal_of = $1;
+	`
+
+	transaction, err := scanTransaction(r.db.QueryRowContext(ctx, query, transactionID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return transaction, err
+}
+
+// ListTransactions returns a wallet's transactions newest first, applying the
+// filter and starting after the filter's cursor.
+func (r *TransactionRepository) ListTransactions(ctx context.Context, walletID valueobject.UserID, filter repository.TransactionFilter) ([]*entity.Transaction, error) {
+	conditions := []string{"wallet_id = $1"}
+	args := []any{walletID.String()}
+
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, txType := range filter.Types {
+			types[i] = string(txType)
+		}
+		addCondition("transaction_type = ANY($%d)", pq.Array(types))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		addCondition("status = ANY($%d)", pq.Array(statuses))
+	}
+
+	if filter.MinAmount != nil {
+		addCondition("amount >= $%d", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		addCondition("amount <= $%d", *filter.MaxAmount)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID.String())
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT `+transactionColumns+`
+		FROM transactions
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d;
+	`, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*entity.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+// SumWithdrawals totals the completed withdrawals and outgoing transfers of a
+// wallet created in [from, to), the usage the withdrawal limits are checked
+// against.
+func (r *TransactionRepository) SumWithdrawals(ctx context.Context, walletID valueobject.UserID, from, to time.Time) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE wallet_id = $1
+			AND transaction_type IN ('WITHDRAWAL', 'TRANSFER_OUT')
+			AND status = 'COMPLETED'
+			AND created_at >= $2
+			AND created_at < $3;
+	`
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, query, walletID.String(), from, to).Scan(&total)
+	return total, err
+}
+
+// transactionColumns is the select list read by scanTransaction.
+const transactionColumns = `id, wallet_id, transaction_type, amount, currency, status, COALESCE(failure_reason, ''),
+			transfer_id, reversal_of, COALESCE(reason, ''), created_at`
+
+func scanTransaction(row rowScanner) (*entity.Transaction, error) {
+	var id string
+	var walletID string
+	var txType string
+	var amount int64
+	var currency string
+	var status string
+	var failureReason string
+	var transferID sql.NullString
+	var reversalOf sql.NullString
+	var reason string
+	var createdAt time.Time
+
+	if err := row.Scan(&id, &walletID, &txType, &amount, &currency, &status, &failureReason, &transferID, &reversalOf, &reason, &createdAt); err != nil {
+		return nil, err
+	}
+
+	idVO, err := valueobject.NewUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyVO, err := valueobject.NewCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	amountVO, err := valueobject.NewMoney(amount, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	var transferIDVO *valueobject.UserID
+	if transferID.Valid {
+		parsed, err := valueobject.NewUserID(transferID.String)
+		if err != nil {
+			return nil, err
+		}
+		transferIDVO = &parsed
+	}
+
+	var reversalOfVO *valueobject.UserID
+	if reversalOf.Valid {
+		parsed, err := valueobject.NewUserID(reversalOf.String)
+		if err != nil {
+			return nil, err
+		}
+		reversalOfVO = &parsed
+	}
+
+	return entity.ReconstructTransaction(
+		idVO,
+		walletIDVO,
+		entity.TransactionType(txType),
+		amountVO,
+		entity.TransactionStatus(status),
+		createdAt.UTC().Format(time.RFC3339Nano),
+		failureReason,
+		transferIDVO,
+		reversalOfVO,
+		reason,
+	), nil
+}
@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"bank/internal/domain/repository"
+	"bank/internal/infrastructure/signing"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+)
+
+// queryer is the part of *sql.DB and *sql.Tx the repositories use, so the same
+// repository code runs standalone or inside a unit of work.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// UnitOfWork is the Postgres implementation of repository.UnitOfWork. Each
+// call to RunInTx opens one database transaction and binds a fresh set of
+// repositories to it. keys seals the signing keys of API keys.
+type UnitOfWork struct {
+	db   *sql.DB
+	keys *signing.KeyCipher
+}
+
+func NewUnitOfWork(db *sql.DB, keys *signing.KeyCipher) *UnitOfWork {
+	return &UnitOfWork{
+		db:   db,
+		keys: keys,
+	}
+}
+
+func (u *UnitOfWork) RunInTx(ctx context.Context, fn func(ctx context.Context, repos repository.Repositories) error) (err error) {
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			rollback(tx)
+			panic(p)
+		}
+		if err != nil {
+			rollback(tx)
+		}
+	}()
+
+	if err = fn(ctx, repository.Repositories{
+		Users:        &UserRepository{db: tx},
+		APIKeys:      &APIKeyRepository{db: tx, keys: u.keys},
+		Wallets:      &WalletRepository{db: tx},
+		Transactions: &TransactionRepository{db: tx},
+		Idempotency:  &IdempotencyRepository{db: tx},
+		Ledger:       &LedgerRepository{db: tx},
+		Quotes:       &ExchangeQuoteRepository{db: tx},
+		Holds:        &HoldRepository{db: tx},
+		Limits:       &LimitRepository{db: tx},
+		Audit:        &AuditRepository{db: tx},
+
+		WithdrawalRequests: &WithdrawalRequestRepository{db: tx},
+	}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Printf("❌ Failed to commit transaction: %v", err)
+	}
+	return err
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		log.Printf("❌ Failed to rollback transaction: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type UserRepository struct {
+	db queryer
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	query := `
+		INSERT INTO users (id, name, external_ref, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4);
+	`
+
+	var externalRef sql.NullString
+	if ref := user.ExternalRef(); ref != "" {
+		externalRef = sql.NullString{String: ref, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		user.ID().String(),
+		user.Name(),
+		externalRef,
+		user.CreatedAt(),
+	)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return domain.ErrUserAlreadyExists
+	}
+	return err
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, userID valueobject.UserID) (*entity.User, error) {
+	query := `
+		SELECT name, external_ref, created_at
+		FROM users
+		WHERE id = $1;
+	`
+
+	// Users inserted before names were required may have none.
+	var name sql.NullString
+	var externalRef sql.NullString
+	var createdAt time.Time
+
+	err := r.db.QueryRowContext(ctx, query, userID.String()).Scan(&name, &externalRef, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructUser(userID, name.String, externalRef.String, createdAt), nil
+}
@@ -1,19 +1,32 @@
 package persistence
 
 import (
+	"bank/internal/domain"
 	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
 	"bank/internal/domain/valueobject"
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
-var (
-	ErrWalletNotFound = errors.New("wallet not found")
+// PostgreSQL error codes raised by the wallets constraints.
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
 )
 
+// likeEscaper escapes the wildcards of a LIKE pattern, so that a name filter
+// matches its text literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type WalletRepository struct {
-	db *sql.DB
+	db queryer
 }
 
 func NewWalletRepository(db *sql.DB) *WalletRepository {
@@ -22,71 +35,295 @@ func NewWalletRepository(db *sql.DB) *WalletRepository {
 	}
 }
 
-func (r *WalletRepository) GetWallet(ctx context.Context, userID valueobject.UserID) (*entity.Wallet, error) {
+func (r *WalletRepository) GetWallet(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error) {
+	return r.getWallet(ctx, ref, "")
+}
+
+func (r *WalletRepository) GetWalletForUpdate(ctx context.Context, ref valueobject.WalletRef) (*entity.Wallet, error) {
+	return r.getWallet(ctx, ref, "FOR UPDATE")
+}
+
+func (r *WalletRepository) getWallet(ctx context.Context, ref valueobject.WalletRef, lockClause string) (*entity.Wallet, error) {
+	condition := "id = $1"
+	id, _ := ref.WalletID()
+	if userID, byUser := ref.UserID(); byUser {
+		condition = "user_id = $1 AND is_default"
+		id = userID
+	}
+
 	query := `
-		SELECT id, user_id, balance
+		SELECT id, user_id, name, is_default, tier, status, balance, held_balance, overdraft_limit, currency
 		FROM wallets
-		WHERE user_id = $1;
+		WHERE ` + condition + `
+		` + lockClause + `;
 	`
 
-	var walletID string
-	var dbUserID string
-	var balance int64
+	wallet, err := scanWallet(r.db.QueryRowContext(ctx, query, id.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWalletNotFound
+	}
+	return wallet, err
+}
 
-	err := r.db.QueryRowContext(ctx, query, userID.String()).Scan(
-		&walletID,
-		&dbUserID,
-		&balance,
-	)
+func (r *WalletRepository) ListWallets(ctx context.Context, userID valueobject.UserID) ([]*entity.Wallet, error) {
+	query := `
+		SELECT id, user_id, name, is_default, tier, status, balance, held_balance, overdraft_limit, currency
+		FROM wallets
+		WHERE user_id = $1
+		ORDER BY is_default DESC, name;
+	`
 
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrWalletNotFound
-		}
 		return nil, err
 	}
+	defer rows.Close()
 
-	// Convert to domain entities
-	walletIDVO, err := valueobject.NewUserID(walletID)
-	if err != nil {
-		return nil, err
+	var wallets []*entity.Wallet
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
 	}
 
-	userIDVO, err := valueobject.NewUserID(dbUserID)
-	if err != nil {
-		return nil, err
+	return wallets, rows.Err()
+}
+
+func (r *WalletRepository) SearchWallets(ctx context.Context, filter repository.WalletFilter) ([]*entity.Wallet, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
 	}
 
-	balanceVO, err := valueobject.NewMoney(balance)
+	if filter.UserID != nil {
+		addCondition("user_id = $%d", filter.UserID.String())
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		addCondition("status = ANY($%d)", pq.Array(statuses))
+	}
+
+	if len(filter.Tiers) > 0 {
+		tiers := make([]string, len(filter.Tiers))
+		for i, tier := range filter.Tiers {
+			tiers[i] = string(tier)
+		}
+		addCondition("tier = ANY($%d)", pq.Array(tiers))
+	}
+
+	if filter.Currency != nil {
+		addCondition("currency = $%d", filter.Currency.Code())
+	}
+	if filter.Name != "" {
+		addCondition("name LIKE '%%' || $%d || '%%'", likeEscaper.Replace(filter.Name))
+	}
+	if filter.After != nil {
+		addCondition("id > $%d", filter.After.String())
+	}
+
+	limit := "ALL"
+	if filter.Limit > 0 {
+		limit = fmt.Sprint(filter.Limit)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, name, is_default, tier, status, balance, held_balance, overdraft_limit, currency
+		FROM wallets
+		WHERE %s
+		ORDER BY id
+		LIMIT %s;
+	`, strings.Join(conditions, " AND "), limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	var wallets []*entity.Wallet
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
 
-	return entity.ReconstructWallet(walletIDVO, userIDVO, balanceVO), nil
+	return wallets, rows.Err()
 }
 
-func (r *WalletRepository) GetWalletForUpdate(ctx context.Context, tx *sql.Tx, userID valueobject.UserID) (*entity.Wallet, error) {
+func (r *WalletRepository) CreateWallet(ctx context.Context, wallet *entity.Wallet) error {
 	query := `
-		SELECT id, user_id, balance
-		FROM wallets
-		WHERE user_id = $1
-		FOR UPDATE;
+		INSERT INTO wallets (id, user_id, name, is_default, tier, status, balance, overdraft_limit, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 	`
 
-	var walletID string
-	var dbUserID string
-	var balance int64
+	_, err := r.db.ExecContext(ctx, query,
+		wallet.ID().String(),
+		wallet.UserID().String(),
+		wallet.Name(),
+		wallet.IsDefault(),
+		string(wallet.Tier()),
+		string(wallet.Status()),
+		wallet.Balance().Amount(),
+		wallet.OverdraftLimit().Amount(),
+		wallet.Currency().Code(),
+	)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return domain.ErrWalletAlreadyExists
+		case pqForeignKeyViolation:
+			return domain.ErrUserNotFound
+		}
+	}
+	return err
+}
+
+func (r *WalletRepository) UpdateWalletBalance(ctx context.Context, walletID valueobject.UserID, newBalance int64) error {
+	query := `
+		UPDATE wallets
+		SET balance = $1, updated_at = NOW()
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, newBalance, walletID.String())
+	return err
+}
+
+func (r *WalletRepository) UpdateWalletHeldBalance(ctx context.Context, walletID valueobject.UserID, newHeldBalance int64) error {
+	query := `
+		UPDATE wallets
+		SET held_balance = $1, updated_at = NOW()
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, newHeldBalance, walletID.String())
+	return err
+}
 
-	err := tx.QueryRowContext(ctx, query, userID.String()).Scan(
-		&walletID,
-		&dbUserID,
-		&balance,
+func (r *WalletRepository) UpdateWalletTier(ctx context.Context, walletID valueobject.UserID, tier entity.WalletTier) error {
+	query := `
+		UPDATE wallets
+		SET tier = $1, updated_at = NOW()
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, string(tier), walletID.String())
+	return err
+}
+
+func (r *WalletRepository) UpdateWalletOverdraftLimit(ctx context.Context, walletID valueobject.UserID, overdraftLimit int64) error {
+	query := `
+		UPDATE wallets
+		SET overdraft_limit = $1, updated_at = NOW()
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, overdraftLimit, walletID.String())
+	return err
+}
+
+func (r *WalletRepository) UpdateWalletStatus(ctx context.Context, walletID valueobject.UserID, status entity.WalletStatus) error {
+	query := `
+		UPDATE wallets
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2;
+	`
+
+	_, err := r.db.ExecContext(ctx, query, string(status), walletID.String())
+	return err
+}
+
+func (r *WalletRepository) InsertWalletStatusChange(ctx context.Context, change *entity.WalletStatusChange) error {
+	query := `
+		INSERT INTO wallet_status_changes (id, wallet_id, from_status, to_status, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		change.ID().String(),
+		change.WalletID().String(),
+		string(change.From()),
+		string(change.To()),
+		change.Reason(),
+		change.ChangedAt(),
 	)
+	return err
+}
 
+func (r *WalletRepository) ListWalletStatusChanges(ctx context.Context, walletID valueobject.UserID) ([]*entity.WalletStatusChange, error) {
+	query := `
+		SELECT id, from_status, to_status, reason, created_at
+		FROM wallet_status_changes
+		WHERE wallet_id = $1
+		ORDER BY created_at, id;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, walletID.String())
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrWalletNotFound
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*entity.WalletStatusChange
+	for rows.Next() {
+		var id, from, to, reason string
+		var changedAt time.Time
+		if err := rows.Scan(&id, &from, &to, &reason, &changedAt); err != nil {
+			return nil, err
+		}
+
+		idVO, err := valueobject.NewUserID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		fromVO, err := entity.ParseWalletStatus(from)
+		if err != nil {
+			return nil, err
 		}
+
+		toVO, err := entity.ParseWalletStatus(to)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, entity.ReconstructWalletStatusChange(idVO, walletID, fromVO, toVO, reason, changedAt))
+	}
+
+	return changes, rows.Err()
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWallet(row rowScanner) (*entity.Wallet, error) {
+	var walletID string
+	var dbUserID string
+	var name string
+	var isDefault bool
+	var tier string
+	var status string
+	var balance int64
+	var heldBalance int64
+	var overdraftLimit int64
+	var currency string
+
+	if err := row.Scan(&walletID, &dbUserID, &name, &isDefault, &tier, &status, &balance, &heldBalance, &overdraftLimit, &currency); err != nil {
 		return nil, err
 	}
 
@@ -101,21 +338,35 @@ func (r *WalletRepository) GetWalletForUpdate(ctx context.Context, tx *sql.Tx, u
 		return nil, err
 	}
 
-	balanceVO, err := valueobject.NewMoney(balance)
+	tierVO, err := entity.ParseWalletTier(tier)
 	if err != nil {
 		return nil, err
 	}
 
-	return entity.ReconstructWallet(walletIDVO, userIDVO, balanceVO), nil
-}
+	statusVO, err := entity.ParseWalletStatus(status)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *WalletRepository) UpdateWalletBalance(ctx context.Context, tx *sql.Tx, walletID valueobject.UserID, newBalance int64) error {
-	query := `
-		UPDATE wallets
-		SET balance = $1, updated_at = NOW()
-		WHERE id = $2;
-	`
+	currencyVO, err := valueobject.NewCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := tx.ExecContext(ctx, query, newBalance, walletID.String())
-	return err
+	balanceVO, err := valueobject.NewBalance(balance, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	heldBalanceVO, err := valueobject.NewMoney(heldBalance, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	overdraftLimitVO, err := valueobject.NewMoney(overdraftLimit, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructWallet(walletIDVO, userIDVO, name, isDefault, tierVO, statusVO, balanceVO, heldBalanceVO, overdraftLimitVO), nil
 }
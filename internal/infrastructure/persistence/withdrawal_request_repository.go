@@ -0,0 +1,296 @@
+package persistence
+
+import (
+	"bank/internal/domain"
+	"bank/internal/domain/entity"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WithdrawalRequestRepository struct {
+	db queryer
+}
+
+func NewWithdrawalRequestRepository(db *sql.DB) *WithdrawalRequestRepository {
+	return &WithdrawalRequestRepository{
+		db: db,
+	}
+}
+
+const withdrawalRequestColumns = `
+	id, wallet_id, amount, currency, status, requested_by, decided_by,
+	transaction_id, expires_at, created_at, updated_at
+`
+
+func (r *WithdrawalRequestRepository) InsertWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error {
+	query := `
+		INSERT INTO withdrawal_requests (` + withdrawalRequestColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+	`
+
+	var transactionID sql.NullString
+	if id := request.TransactionID(); id != nil {
+		transactionID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		request.ID().String(),
+		request.WalletID().String(),
+		request.Amount().Amount(),
+		request.Amount().Currency().Code(),
+		string(request.Status()),
+		request.RequestedBy(),
+		request.DecidedBy(),
+		transactionID,
+		request.ExpiresAt(),
+		request.CreatedAt(),
+		request.UpdatedAt(),
+	)
+	return err
+}
+
+func (r *WithdrawalRequestRepository) GetWithdrawalRequest(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error) {
+	return r.getWithdrawalRequest(ctx, requestID, "")
+}
+
+func (r *WithdrawalRequestRepository) GetWithdrawalRequestForUpdate(ctx context.Context, requestID valueobject.UserID) (*entity.WithdrawalRequest, error) {
+	return r.getWithdrawalRequest(ctx, requestID, "FOR UPDATE")
+}
+
+func (r *WithdrawalRequestRepository) getWithdrawalRequest(ctx context.Context, requestID valueobject.UserID, lockClause string) (*entity.WithdrawalRequest, error) {
+	query := `
+		SELECT ` + withdrawalRequestColumns + `
+		FROM withdrawal_requests
+		WHERE id = $1
+		` + lockClause + `;
+	`
+
+	request, err := scanWithdrawalRequest(r.db.QueryRowContext(ctx, query, requestID.String()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWithdrawalRequestNotFound
+	}
+	return request, err
+}
+
+func (r *WithdrawalRequestRepository) UpdateWithdrawalRequest(ctx context.Context, request *entity.WithdrawalRequest) error {
+	query := `
+		UPDATE withdrawal_requests
+		SET status = $1, decided_by = $2, transaction_id = $3, updated_at = $4
+		WHERE id = $5;
+	`
+
+	var transactionID sql.NullString
+	if id := request.TransactionID(); id != nil {
+		transactionID = sql.NullString{String: id.String(), Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		string(request.Status()),
+		request.DecidedBy(),
+		transactionID,
+		request.UpdatedAt(),
+		request.ID().String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrWithdrawalRequestNotFound
+	}
+	return nil
+}
+
+func (r *WithdrawalRequestRepository) ListWithdrawalRequests(ctx context.Context, filter repository.WithdrawalRequestFilter) ([]*entity.WithdrawalRequest, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+
+	addCondition := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.WalletID != nil {
+		addCondition("wallet_id = $%d", filter.WalletID.String())
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		addCondition("status = ANY($%d)", pq.Array(statuses))
+	}
+
+	if filter.RequestedBy != "" {
+		addCondition("requested_by = $%d", filter.RequestedBy)
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID.String())
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM withdrawal_requests
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d;
+	`, withdrawalRequestColumns, strings.Join(conditions, " AND "), len(args))
+
+	return r.queryWithdrawalRequests(ctx, query, args...)
+}
+
+func (r *WithdrawalRequestRepository) ListExpiredWithdrawalRequests(ctx context.Context, now time.Time, limit int) ([]*entity.WithdrawalRequest, error) {
+	query := `
+		SELECT ` + withdrawalRequestColumns + `
+		FROM withdrawal_requests
+		WHERE status = 'PENDING' AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2;
+	`
+
+	return r.queryWithdrawalRequests(ctx, query, now, limit)
+}
+
+func (r *WithdrawalRequestRepository) queryWithdrawalRequests(ctx context.Context, query string, args ...any) ([]*entity.WithdrawalRequest, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*entity.WithdrawalRequest
+	for rows.Next() {
+		request, err := scanWithdrawalRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+func (r *WithdrawalRequestRepository) InsertWithdrawalRequestEvent(ctx context.Context, event *entity.WithdrawalRequestEvent) error {
+	query := `
+		INSERT INTO withdrawal_request_events (id, request_id, action, actor, reason, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID().String(),
+		event.RequestID().String(),
+		string(event.Action()),
+		event.Actor(),
+		event.Reason(),
+		event.OccurredAt(),
+	)
+	return err
+}
+
+func (r *WithdrawalRequestRepository) ListWithdrawalRequestEvents(ctx context.Context, requestID valueobject.UserID) ([]*entity.WithdrawalRequestEvent, error) {
+	query := `
+		SELECT id, action, actor, reason, occurred_at
+		FROM withdrawal_request_events
+		WHERE request_id = $1
+		ORDER BY occurred_at, id;
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, requestID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entity.WithdrawalRequestEvent
+	for rows.Next() {
+		var id, action, actor, reason string
+		var occurredAt time.Time
+		if err := rows.Scan(&id, &action, &actor, &reason, &occurredAt); err != nil {
+			return nil, err
+		}
+
+		idVO, err := valueobject.NewUserID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, entity.ReconstructWithdrawalRequestEvent(
+			idVO, requestID, entity.WithdrawalRequestAction(action), actor, reason, occurredAt,
+		))
+	}
+
+	return events, rows.Err()
+}
+
+func scanWithdrawalRequest(row rowScanner) (*entity.WithdrawalRequest, error) {
+	var id, walletID string
+	var amount int64
+	var currency, status, requestedBy, decidedBy string
+	var transactionID sql.NullString
+	var expiresAt, createdAt, updatedAt time.Time
+
+	if err := row.Scan(
+		&id, &walletID, &amount, &currency, &status, &requestedBy, &decidedBy,
+		&transactionID, &expiresAt, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	idVO, err := valueobject.NewUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	walletIDVO, err := valueobject.NewUserID(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyVO, err := valueobject.NewCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	amountVO, err := valueobject.NewMoney(amount, currencyVO)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionIDVO *valueobject.UserID
+	if transactionID.Valid {
+		parsed, err := valueobject.NewUserID(transactionID.String)
+		if err != nil {
+			return nil, err
+		}
+		transactionIDVO = &parsed
+	}
+
+	return entity.ReconstructWithdrawalRequest(
+		idVO,
+		walletIDVO,
+		amountVO,
+		entity.WithdrawalRequestStatus(status),
+		requestedBy,
+		decidedBy,
+		transactionIDVO,
+		expiresAt,
+		createdAt,
+		updatedAt,
+	), nil
+}
@@ -0,0 +1,199 @@
+// Package ratelimit throttles callers with token buckets. Each bucket holds at
+// most Limit.Requests tokens and refills evenly over Limit.Period; a request
+// takes one token and is refused when none is left. Buckets are kept per
+// route, both for the caller and for each wallet the caller addresses, in a
+// Store.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"bank/internal/domain"
+)
+
+const (
+	// DefaultClientLimit is applied to each caller on every route no rule
+	// names.
+	DefaultClientLimit = "600/1m"
+
+	// DefaultRules guard the routes that move money out of a wallet.
+	DefaultRules = "POST /withdraw client=60/1m wallet=10/1m; POST /transfers client=60/1m wallet=10/1m"
+
+	// Off disables a limit or the rules in the configuration.
+	Off = "off"
+)
+
+// Limit allows Requests requests per Period, in bursts of up to Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit reads a limit written as requests/period, such as "10/1m".
+func ParseLimit(value string) (Limit, error) {
+	requests, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, domain.NewValidationError("limit", fmt.Sprintf("rate limit %q must be written as requests/period, e.g. 10/1m", value))
+	}
+
+	limit := Limit{}
+	var err error
+	if limit.Requests, err = strconv.Atoi(requests); err != nil || limit.Requests <= 0 {
+		return Limit{}, domain.NewValidationError("limit", fmt.Sprintf("rate limit %q must allow a positive number of requests", value))
+	}
+	if limit.Period, err = time.ParseDuration(period); err != nil || limit.Period <= 0 {
+		return Limit{}, domain.NewValidationError("limit", fmt.Sprintf("rate limit %q must have a positive period", value))
+	}
+	return limit, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// refillInterval is how long the bucket takes to regain one token.
+func (l Limit) refillInterval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// Rule sets the limits of one route, named by method and path template such
+// as "POST /withdraw". Client limits each caller and Wallet each caller on
+// each wallet the request addresses; either may be nil. Wallet buckets are
+// not shared between callers: the wallet is named by the request before
+// anyone checked the caller may use it, and a shared bucket would let anyone
+// throttle someone else's wallet.
+type Rule struct {
+	Route  string
+	Client *Limit
+	Wallet *Limit
+}
+
+// ParseRules reads semicolon separated rules, each a route followed by its
+// limits:
+//
+//	POST /withdraw client=60/1m wallet=10/1m; POST /transfers wallet=10/1m
+//
+// "off" or an empty value means no rules.
+func ParseRules(value string) ([]Rule, error) {
+	if strings.TrimSpace(value) == Off {
+		return nil, nil
+	}
+
+	var rules []Rule
+	for _, raw := range strings.Split(value, ";") {
+		fields := strings.Fields(raw)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, domain.NewValidationError("rules", fmt.Sprintf("rate limit rule %q must name a method, a route and at least one limit", strings.TrimSpace(raw)))
+		}
+
+		rule := Rule{Route: strings.ToUpper(fields[0]) + " " + fields[1]}
+		for _, field := range fields[2:] {
+			kind, rawLimit, _ := strings.Cut(field, "=")
+			limit, err := ParseLimit(rawLimit)
+			if err != nil {
+				return nil, err
+			}
+
+			switch kind {
+			case "client":
+				rule.Client = &limit
+			case "wallet":
+				rule.Wallet = &limit
+			default:
+				return nil, domain.NewValidationError("rules", fmt.Sprintf("rate limit rule %q has unknown limit %q, expected client or wallet", strings.TrimSpace(raw), kind))
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Decision is the state of a bucket after a request took, or failed to take,
+// a token from it.
+type Decision struct {
+	Allowed bool
+	Limit   Limit
+	// Remaining is the number of whole tokens left.
+	Remaining int
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+	// RetryAfter is how long until the next token, when the request was
+	// refused.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. MemoryStore keeps them in process memory; a store
+// shared by several instances, such as one backed by Redis, only has to take
+// tokens atomically.
+type Store interface {
+	// Take removes a token from the bucket of key, refilled at limit, and
+	// reports the state of the bucket afterwards.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error)
+}
+
+// Limiter applies the rules to requests, and the default client limit to the
+// routes no rule names.
+type Limiter struct {
+	store  Store
+	client *Limit
+	rules  map[string]Rule
+}
+
+// NewLimiter limits requests through store. defaultClient may be nil, in
+// which case routes without a rule are not limited.
+func NewLimiter(store Store, defaultClient *Limit, rules []Rule) *Limiter {
+	byRoute := make(map[string]Rule, len(rules))
+	for _, rule := range rules {
+		byRoute[rule.Route] = rule
+	}
+
+	return &Limiter{
+		store:  store,
+		client: defaultClient,
+		rules:  byRoute,
+	}
+}
+
+// WalletLimited reports whether route limits the wallets it addresses, so
+// that the caller only needs to find the wallet when it is.
+func (l *Limiter) WalletLimited(route string) bool {
+	return l.rules[route].Wallet != nil
+}
+
+// Allow takes a token for client, and for client on wallet unless wallet is
+// empty, on route. The decision is the one that refused the request, or the one with
+// the fewest tokens left. ok is false when no limit applies to the request.
+func (l *Limiter) Allow(ctx context.Context, route, client, wallet string, now time.Time) (decision Decision, ok bool, err error) {
+	rule, named := l.rules[route]
+	if !named {
+		rule = Rule{Route: route, Client: l.client}
+	}
+
+	if rule.Client != nil {
+		decision, err = l.store.Take(ctx, "client:"+route+":"+client, *rule.Client, now)
+		if err != nil || !decision.Allowed {
+			return decision, true, err
+		}
+		ok = true
+	}
+
+	if rule.Wallet != nil && wallet != "" {
+		walletDecision, err := l.store.Take(ctx, "wallet:"+route+":"+client+":"+wallet, *rule.Wallet, now)
+		if err != nil {
+			return walletDecision, true, err
+		}
+		if !ok || !walletDecision.Allowed || walletDecision.Remaining < decision.Remaining {
+			decision = walletDecision
+		}
+		ok = true
+	}
+
+	return decision, ok, nil
+}
@@ -0,0 +1,222 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bank/internal/domain"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Run("should read requests per period", func(t *testing.T) {
+		// Act
+		limit, err := ParseLimit(" 10/1m ")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if limit.Requests != 10 || limit.Period != time.Minute {
+			t.Errorf("expected 10 requests per minute, got %s", limit)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "should reject a missing period", value: "10"},
+		{name: "should reject zero requests", value: "0/1m"},
+		{name: "should reject a negative period", value: "10/-1s"},
+		{name: "should reject an invalid period", value: "10/minute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ParseLimit(tt.value)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	t.Run("should read the limits of each route", func(t *testing.T) {
+		// Act
+		rules, err := ParseRules("post /withdraw client=60/1m wallet=10/1m; ; GET /balance client=5/1s")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(rules) != 2 {
+			t.Fatalf("expected 2 rules, got %d", len(rules))
+		}
+		if rules[0].Route != "POST /withdraw" || rules[0].Client.Requests != 60 || rules[0].Wallet.Requests != 10 {
+			t.Errorf("unexpected first rule %+v", rules[0])
+		}
+		if rules[1].Route != "GET /balance" || rules[1].Client.Period != time.Second || rules[1].Wallet != nil {
+			t.Errorf("unexpected second rule %+v", rules[1])
+		}
+	})
+
+	t.Run("should read the default rules", func(t *testing.T) {
+		// Act
+		rules, err := ParseRules(DefaultRules)
+
+		// Assert
+		if err != nil || len(rules) == 0 {
+			t.Errorf("expected the default rules to parse, got %d rules and %v", len(rules), err)
+		}
+	})
+
+	t.Run("should turn off every rule", func(t *testing.T) {
+		// Act
+		rules, err := ParseRules(Off)
+
+		// Assert
+		if err != nil || rules != nil {
+			t.Errorf("expected no rules, got %v and %v", rules, err)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "should reject a rule without limits", value: "POST /withdraw"},
+		{name: "should reject an unknown limit", value: "POST /withdraw user=10/1m"},
+		{name: "should reject an invalid limit", value: "POST /withdraw client=fast"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ParseRules(tt.value)
+
+			// Assert
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error) {
+	return Decision{}, errors.New("store unavailable")
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	perMinute := func(requests int) *Limit {
+		return &Limit{Requests: requests, Period: time.Minute}
+	}
+
+	t.Run("should refuse a wallet that ran out even when its caller has not", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(NewMemoryStore(), nil, []Rule{{Route: "POST /withdraw", Client: perMinute(5), Wallet: perMinute(2)}})
+
+		// Act
+		first, _, _ := limiter.Allow(ctx, "POST /withdraw", "subject:a", "wallet:1", now)
+		second, _, _ := limiter.Allow(ctx, "POST /withdraw", "subject:a", "wallet:1", now)
+		third, ok, err := limiter.Allow(ctx, "POST /withdraw", "subject:a", "wallet:1", now)
+		otherWallet, _, _ := limiter.Allow(ctx, "POST /withdraw", "subject:a", "wallet:2", now)
+
+		// Assert
+		if err != nil || !ok {
+			t.Fatalf("expected a decision, got ok=%v and %v", ok, err)
+		}
+		if !first.Allowed || !second.Allowed || third.Allowed {
+			t.Errorf("expected two requests on the wallet, got %v, %v, %v", first.Allowed, second.Allowed, third.Allowed)
+		}
+		if third.Limit.Requests != 2 || third.RetryAfter != 30*time.Second {
+			t.Errorf("expected the wallet limit to refuse with a retry in 30s, got %s and %v", third.Limit, third.RetryAfter)
+		}
+		if !otherWallet.Allowed {
+			t.Error("expected another wallet to be allowed")
+		}
+	})
+
+	t.Run("should not let one caller use up the wallet bucket of another", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(NewMemoryStore(), nil, []Rule{{Route: "POST /withdraw", Wallet: perMinute(1)}})
+		_, _, _ = limiter.Allow(ctx, "POST /withdraw", "subject:attacker", "wallet:1", now)
+
+		// Act
+		decision, _, _ := limiter.Allow(ctx, "POST /withdraw", "subject:owner", "wallet:1", now)
+
+		// Assert
+		if !decision.Allowed {
+			t.Error("expected the owner to keep their own bucket on the wallet")
+		}
+	})
+
+	t.Run("should report the limit with the fewest tokens left", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(NewMemoryStore(), nil, []Rule{{Route: "POST /withdraw", Client: perMinute(2), Wallet: perMinute(10)}})
+
+		// Act
+		decision, _, _ := limiter.Allow(ctx, "POST /withdraw", "subject:a", "wallet:1", now)
+
+		// Assert
+		if decision.Limit.Requests != 2 || decision.Remaining != 1 {
+			t.Errorf("expected the client limit with 1 left, got %s with %d left", decision.Limit, decision.Remaining)
+		}
+	})
+
+	t.Run("should apply the default client limit per route", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(NewMemoryStore(), perMinute(1), nil)
+
+		// Act
+		balance, _, _ := limiter.Allow(ctx, "GET /balance", "subject:a", "", now)
+		wallets, _, _ := limiter.Allow(ctx, "GET /wallets/{wallet_id}", "subject:a", "", now)
+		again, _, _ := limiter.Allow(ctx, "GET /balance", "subject:a", "", now)
+
+		// Assert
+		if !balance.Allowed || !wallets.Allowed || again.Allowed {
+			t.Errorf("expected one request per route, got %v, %v, %v", balance.Allowed, wallets.Allowed, again.Allowed)
+		}
+	})
+
+	t.Run("should not limit a route without limits", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(NewMemoryStore(), nil, []Rule{{Route: "POST /withdraw", Wallet: perMinute(1)}})
+
+		// Act
+		_, ok, err := limiter.Allow(ctx, "GET /balance", "subject:a", "wallet:1", now)
+		_, okWithoutWallet, _ := limiter.Allow(ctx, "POST /withdraw", "subject:a", "", now)
+
+		// Assert
+		if err != nil || ok || okWithoutWallet {
+			t.Errorf("expected no limit to apply, got ok=%v, %v and %v", ok, okWithoutWallet, err)
+		}
+		if limiter.WalletLimited("GET /balance") || !limiter.WalletLimited("POST /withdraw") {
+			t.Error("expected only the withdrawal route to limit wallets")
+		}
+	})
+
+	t.Run("should report a store failure", func(t *testing.T) {
+		// Arrange
+		limiter := NewLimiter(failingStore{}, perMinute(1), nil)
+
+		// Act
+		_, _, err := limiter.Allow(ctx, "GET /balance", "subject:a", "", now)
+
+		// Assert
+		if err == nil {
+			t.Error("expected the store error")
+		}
+	})
+}
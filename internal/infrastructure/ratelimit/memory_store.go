@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pruneInterval is how often MemoryStore forgets the buckets that have filled
+// up again, which behave exactly like missing ones.
+const pruneInterval = time.Minute
+
+// MemoryStore keeps the buckets in process memory. Instances behind a load
+// balancer each keep their own, so a caller spreading requests over n
+// instances gets up to n times its limit.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	nextPrune time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // when the bucket will be full again
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.After(s.nextPrune) {
+		for stale, b := range s.buckets {
+			if !now.Before(b.full) {
+				delete(s.buckets, stale)
+			}
+		}
+		s.nextPrune = now.Add(pruneInterval)
+	}
+
+	capacity := float64(limit.Requests)
+	refill := limit.refillInterval()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(capacity, b.tokens+float64(elapsed)/float64(refill))
+		b.updated = now
+	}
+
+	decision := Decision{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - b.tokens) * float64(refill))
+	}
+
+	decision.Remaining = int(b.tokens)
+	decision.ResetAfter = time.Duration((capacity - b.tokens) * float64(refill))
+	b.full = now.Add(decision.ResetAfter)
+	return decision, nil
+}
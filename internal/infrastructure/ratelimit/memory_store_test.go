@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+	now := time.Unix(1700000000, 0)
+
+	t.Run("should allow a burst up to the limit, then refuse until a token is back", func(t *testing.T) {
+		// Arrange
+		store := NewMemoryStore()
+		for i := 0; i < limit.Requests; i++ {
+			if decision, _ := store.Take(ctx, "client", limit, now); !decision.Allowed {
+				t.Fatalf("expected request %d of the burst to be allowed", i+1)
+			}
+		}
+
+		// Act
+		refused, err := store.Take(ctx, "client", limit, now.Add(500*time.Millisecond))
+		refilled, _ := store.Take(ctx, "client", limit, now.Add(time.Second))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if refused.Allowed || refused.Remaining != 0 {
+			t.Errorf("expected the request to be refused with nothing left, got %+v", refused)
+		}
+		if refused.RetryAfter != 500*time.Millisecond {
+			t.Errorf("expected a retry after 500ms, got %v", refused.RetryAfter)
+		}
+		if refused.ResetAfter != 2500*time.Millisecond {
+			t.Errorf("expected a reset after 2.5s, got %v", refused.ResetAfter)
+		}
+		if !refilled.Allowed {
+			t.Error("expected a request to be allowed once a token is back")
+		}
+	})
+
+	t.Run("should keep separate buckets per key", func(t *testing.T) {
+		// Arrange
+		store := NewMemoryStore()
+		one := Limit{Requests: 1, Period: time.Minute}
+		_, _ = store.Take(ctx, "client-a", one, now)
+
+		// Act
+		decision, _ := store.Take(ctx, "client-b", one, now)
+
+		// Assert
+		if !decision.Allowed {
+			t.Error("expected another key to have its own bucket")
+		}
+	})
+
+	t.Run("should forget buckets that filled up again", func(t *testing.T) {
+		// Arrange
+		store := NewMemoryStore()
+		_, _ = store.Take(ctx, "idle", limit, now)
+		_, _ = store.Take(ctx, "busy", limit, now.Add(pruneInterval))
+
+		// Act
+		_, _ = store.Take(ctx, "busy", limit, now.Add(2*pruneInterval))
+
+		// Assert
+		if _, ok := store.buckets["idle"]; ok {
+			t.Error("expected the idle bucket to be pruned")
+		}
+		if _, ok := store.buckets["busy"]; !ok {
+			t.Error("expected the bucket in use to be kept")
+		}
+	})
+}
@@ -0,0 +1,117 @@
+// Package rates implements service.RateProvider from a table of exchange
+// rates kept in a JSON file.
+package rates
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+// defaultRates is used when no rates file is configured. The rates are fixed
+// examples, good enough for local runs and tests but not for real money.
+//
+//go:embed default_rates.json
+var defaultRates []byte
+
+// rateTable is the file format: rates keyed by "BASE/QUOTE", e.g.
+//
+//	{"rates": {"USD/EUR": "0.92"}}
+//
+// Rates are decimal strings so that they are read exactly.
+type rateTable struct {
+	Rates map[string]string `json:"rates"`
+}
+
+// FileProvider serves the rates of a rate table. A pair is also served in the
+// opposite direction, at the inverse rate, unless the table lists both.
+type FileProvider struct {
+	rates map[string]valueobject.ExchangeRate // by "BASE/QUOTE"
+}
+
+// NewFileProvider loads the rate table at path.
+func NewFileProvider(path string) (*FileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rates file: %w", err)
+	}
+
+	provider, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("rates file %s: %w", path, err)
+	}
+	return provider, nil
+}
+
+// NewDefaultProvider serves the example rates built into the binary.
+func NewDefaultProvider() (*FileProvider, error) {
+	return parse(defaultRates)
+}
+
+func parse(data []byte) (*FileProvider, error) {
+	var table rateTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+
+	provider := &FileProvider{
+		rates: make(map[string]valueobject.ExchangeRate),
+	}
+
+	// Listed rates are loaded first so that an inverse never replaces one.
+	inverses := make([]valueobject.ExchangeRate, 0, len(table.Rates))
+	for pair, value := range table.Rates {
+		base, quote, ok := strings.Cut(pair, "/")
+		if !ok {
+			return nil, fmt.Errorf("rate %q is not keyed by BASE/QUOTE", pair)
+		}
+
+		baseCurrency, err := valueobject.NewCurrency(base)
+		if err != nil {
+			return nil, fmt.Errorf("rate %s: %w", pair, err)
+		}
+		quoteCurrency, err := valueobject.NewCurrency(quote)
+		if err != nil {
+			return nil, fmt.Errorf("rate %s: %w", pair, err)
+		}
+
+		rate, err := valueobject.NewExchangeRate(baseCurrency, quoteCurrency, value)
+		if err != nil {
+			return nil, fmt.Errorf("rate %s: %w", pair, err)
+		}
+		provider.rates[pairKey(baseCurrency, quoteCurrency)] = rate
+
+		inverse, err := rate.Inverse()
+		if err != nil {
+			return nil, fmt.Errorf("rate %s: %w", pair, err)
+		}
+		inverses = append(inverses, inverse)
+	}
+
+	for _, inverse := range inverses {
+		key := pairKey(inverse.Base(), inverse.Quote())
+		if _, listed := provider.rates[key]; !listed {
+			provider.rates[key] = inverse
+		}
+	}
+
+	return provider, nil
+}
+
+func (p *FileProvider) GetRate(ctx context.Context, base, quote valueobject.Currency) (valueobject.ExchangeRate, error) {
+	rate, ok := p.rates[pairKey(base, quote)]
+	if !ok {
+		return valueobject.ExchangeRate{}, domain.ErrRateUnavailable
+	}
+	return rate, nil
+}
+
+func pairKey(base, quote valueobject.Currency) string {
+	return base.Code() + "/" + quote.Code()
+}
@@ -0,0 +1,119 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bank/internal/domain"
+	"bank/internal/domain/valueobject"
+)
+
+func currency(t *testing.T, code string) valueobject.Currency {
+	t.Helper()
+	c, err := valueobject.NewCurrency(code)
+	if err != nil {
+		t.Fatalf("unexpected error creating currency %s: %v", code, err)
+	}
+	return c
+}
+
+func TestFileProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve listed rates and their inverses", func(t *testing.T) {
+		// Arrange
+		provider, err := parse([]byte(`{"rates": {"USD/EUR": "0.8", "EUR/GBP": "0.86", "GBP/EUR": "1.15"}}`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		tests := []struct {
+			base, quote, expected string
+		}{
+			{"USD", "EUR", "0.8"},
+			{"EUR", "USD", "1.25"},
+			{"EUR", "GBP", "0.86"},
+			{"GBP", "EUR", "1.15"},
+		}
+
+		for _, tt := range tests {
+			// Act
+			rate, err := provider.GetRate(ctx, currency(t, tt.base), currency(t, tt.quote))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error for %s/%s, got %v", tt.base, tt.quote, err)
+			}
+			if rate.String() != tt.expected {
+				t.Errorf("expected %s/%s at %s, got %s", tt.base, tt.quote, tt.expected, rate.String())
+			}
+		}
+	})
+
+	t.Run("should report a pair it has no rate for", func(t *testing.T) {
+		// Arrange
+		provider, _ := parse([]byte(`{"rates": {"USD/EUR": "0.8"}}`))
+
+		// Act
+		_, err := provider.GetRate(ctx, currency(t, "USD"), currency(t, "JPY"))
+
+		// Assert
+		if !errors.Is(err, domain.ErrRateUnavailable) {
+			t.Errorf("expected ErrRateUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("should reject malformed tables", func(t *testing.T) {
+		tables := []string{
+			`{"rates": {"USDEUR": "0.8"}}`,
+			`{"rates": {"USD/XYZ": "0.8"}}`,
+			`{"rates": {"USD/EUR": "-0.8"}}`,
+			`not json`,
+		}
+
+		for _, table := range tables {
+			// Act
+			_, err := parse([]byte(table))
+
+			// Assert
+			if err == nil {
+				t.Errorf("expected an error for %s", table)
+			}
+		}
+	})
+
+	t.Run("should load the built-in rates", func(t *testing.T) {
+		// Act
+		provider, err := NewDefaultProvider()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := provider.GetRate(ctx, currency(t, "EUR"), currency(t, "USD")); err != nil {
+			t.Errorf("expected a EUR/USD rate, got %v", err)
+		}
+	})
+
+	t.Run("should load a rates file", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "rates.json")
+		if err := os.WriteFile(path, []byte(`{"rates": {"USD/JPY": "150"}}`), 0o600); err != nil {
+			t.Fatalf("failed to write rates file: %v", err)
+		}
+
+		// Act
+		provider, err := NewFileProvider(path)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := provider.GetRate(ctx, currency(t, "JPY"), currency(t, "USD")); err != nil {
+			t.Errorf("expected a JPY/USD rate, got %v", err)
+		}
+	})
+}
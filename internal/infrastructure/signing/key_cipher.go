@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+// KeyCipherKeySize is the size of the key signing keys are encrypted with.
+const KeyCipherKeySize = 32
+
+// SealedSigningKeySize is the size of a sealed signing key: the nonce, the
+// encrypted key and the authentication tag.
+const SealedSigningKeySize = 12 + entity.APIKeySigningKeySize + 16
+
+// ErrSealedKeyInvalid is returned for a stored signing key that cannot be
+// opened, because it was not sealed, was sealed for another key ID, or under
+// another encryption key.
+var ErrSealedKeyInvalid = errors.New("sealed signing key cannot be opened")
+
+// KeyCipher encrypts the signing keys of API keys at rest with AES-256-GCM,
+// under a key the service holds outside the database. A signing key is
+// enough to sign requests, so a copy of the database must not reveal it.
+type KeyCipher struct {
+	aead cipher.AEAD
+}
+
+// NewKeyCipher encrypts with key, which must be KeyCipherKeySize bytes.
+func NewKeyCipher(key []byte) (*KeyCipher, error) {
+	if len(key) != KeyCipherKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeyCipherKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyCipher{
+		aead: aead,
+	}, nil
+}
+
+// ParseKeyCipher reads a hex encoded key, such as the output of
+// openssl rand -hex 32.
+func ParseKeyCipher(hexKey string) (*KeyCipher, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, errors.New("encryption key must be hex encoded")
+	}
+	return NewKeyCipher(key)
+}
+
+// Seal encrypts the signing key of keyID. The key ID is authenticated along
+// with it, so a sealed key copied to another row does not open.
+func (c *KeyCipher) Seal(keyID valueobject.UserID, signingKey []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize(), SealedSigningKeySize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, signingKey, []byte(keyID.String())), nil
+}
+
+// Open decrypts a signing key sealed for keyID.
+func (c *KeyCipher) Open(keyID valueobject.UserID, sealed []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize+c.aead.Overhead() {
+		return nil, fmt.Errorf("%w: key %s", ErrSealedKeyInvalid, keyID.String())
+	}
+
+	signingKey, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(keyID.String()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %s", ErrSealedKeyInvalid, keyID.String())
+	}
+	return signingKey, nil
+}
@@ -0,0 +1,97 @@
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"bank/internal/domain/entity"
+	"bank/internal/domain/valueobject"
+)
+
+func TestKeyCipher(t *testing.T) {
+	keys, err := ParseKeyCipher(strings.Repeat("ab", KeyCipherKeySize))
+	if err != nil {
+		t.Fatalf("unexpected error creating cipher: %v", err)
+	}
+	signingKey := entity.DeriveAPIKeySigningKey(testSecret)
+	keyID := valueobject.NewUserIDRandom()
+
+	t.Run("should open what it sealed, without storing the signing key in clear", func(t *testing.T) {
+		// Act
+		sealed, err := keys.Seal(keyID, signingKey)
+		opened, openErr := keys.Open(keyID, sealed)
+
+		// Assert
+		if err != nil || openErr != nil {
+			t.Fatalf("expected no error, got %v and %v", err, openErr)
+		}
+		if len(sealed) != SealedSigningKeySize {
+			t.Errorf("expected %d sealed bytes, got %d", SealedSigningKeySize, len(sealed))
+		}
+		if bytes.Contains(sealed, signingKey) {
+			t.Error("expected the sealed key not to contain the signing key")
+		}
+		if !bytes.Equal(opened, signingKey) {
+			t.Error("expected the opened key to be the signing key")
+		}
+	})
+
+	t.Run("should not open a key sealed for another key ID", func(t *testing.T) {
+		// Arrange
+		sealed, _ := keys.Seal(keyID, signingKey)
+
+		// Act
+		_, err := keys.Open(valueobject.NewUserIDRandom(), sealed)
+
+		// Assert
+		if !errors.Is(err, ErrSealedKeyInvalid) {
+			t.Errorf("expected ErrSealedKeyInvalid, got %v", err)
+		}
+	})
+
+	t.Run("should not open a key sealed under another encryption key", func(t *testing.T) {
+		// Arrange
+		other, _ := ParseKeyCipher(strings.Repeat("cd", KeyCipherKeySize))
+		sealed, _ := other.Seal(keyID, signingKey)
+
+		// Act
+		_, err := keys.Open(keyID, sealed)
+
+		// Assert
+		if !errors.Is(err, ErrSealedKeyInvalid) {
+			t.Errorf("expected ErrSealedKeyInvalid, got %v", err)
+		}
+	})
+
+	t.Run("should not open a signing key stored in clear", func(t *testing.T) {
+		// Act
+		_, err := keys.Open(keyID, signingKey)
+
+		// Assert
+		if !errors.Is(err, ErrSealedKeyInvalid) {
+			t.Errorf("expected ErrSealedKeyInvalid, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "should reject a key that is not hex", key: strings.Repeat("zz", KeyCipherKeySize)},
+		{name: "should reject a short key", key: strings.Repeat("ab", 16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ParseKeyCipher(tt.key)
+
+			// Assert
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
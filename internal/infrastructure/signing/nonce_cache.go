@@ -0,0 +1,46 @@
+package signing
+
+import (
+	"sync"
+	"time"
+)
+
+// NonceCache remembers used nonces for a while so that a signed request
+// cannot be replayed. It lives in process memory: instances behind a load
+// balancer each keep their own, so a replay is only caught by the instance
+// that saw the original request within the same window.
+type NonceCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	expiries  map[string]time.Time // nonce to the time it may be forgotten
+	nextPrune time.Time
+}
+
+func NewNonceCache(ttl time.Duration) *NonceCache {
+	return &NonceCache{
+		ttl:      ttl,
+		expiries: make(map[string]time.Time),
+	}
+}
+
+// Use records nonce as used at now. It reports false when the nonce was
+// already used and has not expired yet.
+func (c *NonceCache) Use(nonce string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.After(c.nextPrune) {
+		for used, expiry := range c.expiries {
+			if now.After(expiry) {
+				delete(c.expiries, used)
+			}
+		}
+		c.nextPrune = now.Add(c.ttl)
+	}
+
+	if expiry, ok := c.expiries[nonce]; ok && !now.After(expiry) {
+		return false
+	}
+	c.expiries[nonce] = now.Add(c.ttl)
+	return true
+}
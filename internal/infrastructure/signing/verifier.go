@@ -0,0 +1,138 @@
+// Package signing verifies requests signed with an API key. A client signs
+// each request with HMAC-SHA256, keyed with the key's signing key, over
+//
+//	METHOD \n PATH \n TIMESTAMP \n NONCE \n hex(SHA-256(BODY))
+//
+// where PATH includes the query string and TIMESTAMP is in Unix seconds. The
+// key ID, timestamp, nonce and hex encoded signature travel in the headers
+// below. A request is only accepted within a window around its timestamp and
+// only once per nonce.
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"bank/internal/application/auth"
+	"bank/internal/domain"
+	"bank/internal/domain/repository"
+	"bank/internal/domain/valueobject"
+)
+
+const (
+	HeaderKeyID     = "X-API-Key"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderNonce     = "X-Nonce"
+	HeaderSignature = "X-Signature"
+
+	// DefaultWindow is how far a request's timestamp may be from the
+	// service's clock.
+	DefaultWindow = 5 * time.Minute
+)
+
+var noncePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,128}$`)
+
+// ErrInvalidSignature is returned for every signed request that is not
+// accepted; the wrapped message says why.
+var ErrInvalidSignature = errors.New("invalid request signature")
+
+// Verifier checks signed requests against the stored API keys.
+type Verifier struct {
+	keys   repository.APIKeyRepository
+	nonces *NonceCache
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewVerifier accepts requests whose timestamp is at most window away from
+// now. Nonces are remembered for twice the window, long enough to outlive
+// every request that could still be accepted.
+func NewVerifier(keys repository.APIKeyRepository, window time.Duration) *Verifier {
+	return &Verifier{
+		keys:   keys,
+		nonces: NewNonceCache(2 * window),
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// StringToSign is what a request's signature covers.
+func StringToSign(method, path, timestamp, nonce string, body []byte) string {
+	digest := sha256.Sum256(body)
+	return method + "\n" + path + "\n" + timestamp + "\n" + nonce + "\n" + hex.EncodeToString(digest[:])
+}
+
+// Sign returns the hex encoded signature of a request.
+func Sign(signingKey []byte, method, path, timestamp, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(StringToSign(method, path, timestamp, nonce, body)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequest checks the signature of r, whose body has been read into
+// body, and returns the principal of the API key that signed it. Refused
+// requests fail with ErrInvalidSignature; other errors come from loading the
+// key.
+func (v *Verifier) VerifyRequest(ctx context.Context, r *http.Request, body []byte) (auth.Principal, error) {
+	keyID, err := valueobject.NewUserID(r.Header.Get(HeaderKeyID))
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: malformed key ID", ErrInvalidSignature)
+	}
+
+	timestamp := r.Header.Get(HeaderTimestamp)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	now := v.now()
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > v.window || skew < -v.window {
+		return auth.Principal{}, fmt.Errorf("%w: timestamp outside the %s window", ErrInvalidSignature, v.window)
+	}
+
+	nonce := r.Header.Get(HeaderNonce)
+	if !noncePattern.MatchString(nonce) {
+		return auth.Principal{}, fmt.Errorf("%w: nonce must be 16 to 128 letters, digits, dashes or underscores", ErrInvalidSignature)
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(HeaderSignature))
+	if err != nil {
+		return auth.Principal{}, fmt.Errorf("%w: signature is not hex encoded", ErrInvalidSignature)
+	}
+
+	key, err := v.keys.GetAPIKey(ctx, keyID)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return auth.Principal{}, fmt.Errorf("%w: unknown key %s", ErrInvalidSignature, keyID.String())
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	if key.IsRevoked() {
+		return auth.Principal{}, fmt.Errorf("%w: key %s is revoked", ErrInvalidSignature, keyID.String())
+	}
+
+	mac := hmac.New(sha256.New, key.SigningKey())
+	mac.Write([]byte(StringToSign(r.Method, r.URL.RequestURI(), timestamp, nonce, body)))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return auth.Principal{}, fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+
+	// Only signed requests reach the cache, so nobody without the key can
+	// fill it.
+	if !v.nonces.Use(keyID.String()+":"+nonce, now) {
+		return auth.Principal{}, fmt.Errorf("%w: nonce already used", ErrInvalidSignature)
+	}
+
+	return auth.Principal{
+		Subject: key.UserID().String(),
+		Scopes:  key.Scopes(),
+		KeyID:   keyID.String(),
+	}, nil
+}
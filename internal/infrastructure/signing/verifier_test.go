@@ -0,0 +1,192 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"bank/internal/domain/entity"
+	"bank/internal/infrastructure/memory"
+)
+
+const testSecret = "wsk_test-secret"
+
+type fixture struct {
+	verifier *Verifier
+	keys     *memory.APIKeyRepository
+	key      *entity.APIKey
+	now      time.Time
+}
+
+func newFixture(t *testing.T, scopes ...string) *fixture {
+	t.Helper()
+	store := memory.NewStore()
+	user, _ := entity.NewUser("integration", "", time.Now())
+	store.AddUser(user)
+
+	keys := memory.NewAPIKeyRepository(store)
+	key, err := entity.NewAPIKey(user.ID(), "backend", scopes, entity.DeriveAPIKeySigningKey(testSecret), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error creating API key: %v", err)
+	}
+	if err := keys.CreateAPIKey(context.Background(), key); err != nil {
+		t.Fatalf("unexpected error storing API key: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	verifier := NewVerifier(keys, DefaultWindow)
+	verifier.now = func() time.Time { return now }
+	return &fixture{verifier: verifier, keys: keys, key: key, now: now}
+}
+
+type signedRequest struct {
+	method, path, body string
+	keyID, nonce       string
+	timestamp          time.Time
+	secret             string
+}
+
+func (f *fixture) request(changes func(*signedRequest)) signedRequest {
+	req := signedRequest{
+		method:    "POST",
+		path:      "/withdraw?dry_run=false",
+		body:      `{"amount":100}`,
+		keyID:     f.key.ID().String(),
+		nonce:     "0123456789abcdef",
+		timestamp: f.now,
+		secret:    testSecret,
+	}
+	if changes != nil {
+		changes(&req)
+	}
+	return req
+}
+
+func (f *fixture) verify(req signedRequest, tamper func(*signedRequest)) error {
+	timestamp := strconv.FormatInt(req.timestamp.Unix(), 10)
+	signature := Sign(entity.DeriveAPIKeySigningKey(req.secret), req.method, req.path, timestamp, req.nonce, []byte(req.body))
+	if tamper != nil {
+		tamper(&req)
+	}
+
+	r := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+	r.Header.Set(HeaderKeyID, req.keyID)
+	r.Header.Set(HeaderTimestamp, timestamp)
+	r.Header.Set(HeaderNonce, req.nonce)
+	r.Header.Set(HeaderSignature, signature)
+
+	_, err := f.verifier.VerifyRequest(context.Background(), r, []byte(req.body))
+	return err
+}
+
+func TestVerifier(t *testing.T) {
+	t.Run("should return the key's principal for a valid signature", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, entity.APIKeyScopeWithdraw)
+		req := f.request(nil)
+		timestamp := strconv.FormatInt(req.timestamp.Unix(), 10)
+		r := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+		r.Header.Set(HeaderKeyID, req.keyID)
+		r.Header.Set(HeaderTimestamp, timestamp)
+		r.Header.Set(HeaderNonce, req.nonce)
+		r.Header.Set(HeaderSignature, Sign(f.key.SigningKey(), req.method, req.path, timestamp, req.nonce, []byte(req.body)))
+
+		// Act
+		principal, err := f.verifier.VerifyRequest(context.Background(), r, []byte(req.body))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if principal.Subject != f.key.UserID().String() || principal.KeyID != f.key.ID().String() || !principal.HasScope(entity.APIKeyScopeWithdraw) {
+			t.Errorf("unexpected principal %+v", principal)
+		}
+	})
+
+	t.Run("should refuse a replayed nonce", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, entity.APIKeyScopeWithdraw)
+		req := f.request(nil)
+		_ = f.verify(req, nil)
+
+		// Act
+		err := f.verify(req, nil)
+
+		// Assert
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("should refuse a revoked key", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, entity.APIKeyScopeWithdraw)
+		_ = f.key.Revoke(time.Now())
+		_ = f.keys.UpdateAPIKey(context.Background(), f.key)
+
+		// Act
+		err := f.verify(f.request(nil), nil)
+
+		// Assert
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		changes func(*signedRequest)
+		tamper  func(*signedRequest)
+	}{
+		{name: "should refuse a stale timestamp", changes: func(r *signedRequest) { r.timestamp = r.timestamp.Add(-DefaultWindow - time.Second) }},
+		{name: "should refuse a timestamp from the future", changes: func(r *signedRequest) { r.timestamp = r.timestamp.Add(DefaultWindow + time.Second) }},
+		{name: "should refuse a short nonce", changes: func(r *signedRequest) { r.nonce = "abc" }},
+		{name: "should refuse an unknown key", changes: func(r *signedRequest) { r.keyID = "550e8400-e29b-41d4-a716-446655440000" }},
+		{name: "should refuse a malformed key ID", changes: func(r *signedRequest) { r.keyID = "key-1" }},
+		{name: "should refuse the wrong secret", changes: func(r *signedRequest) { r.secret = "wsk_other-secret" }},
+		{name: "should refuse a changed body", tamper: func(r *signedRequest) { r.body = `{"amount":100000}` }},
+		{name: "should refuse a changed path", tamper: func(r *signedRequest) { r.path = "/withdraw?dry_run=true" }},
+		{name: "should refuse a changed method", tamper: func(r *signedRequest) { r.method = "PUT" }},
+		{name: "should refuse a changed nonce", tamper: func(r *signedRequest) { r.nonce = "fedcba9876543210" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFixture(t, entity.APIKeyScopeWithdraw)
+
+			// Act
+			err := f.verify(f.request(tt.changes), tt.tamper)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNonceCache(t *testing.T) {
+	t.Run("should accept a nonce again once it expired", func(t *testing.T) {
+		// Arrange
+		cache := NewNonceCache(time.Minute)
+		now := time.Now()
+		cache.Use("nonce", now)
+
+		// Act
+		replayed := cache.Use("nonce", now.Add(30*time.Second))
+		expired := cache.Use("nonce", now.Add(2*time.Minute))
+
+		// Assert
+		if replayed {
+			t.Error("expected the nonce to be refused within its lifetime")
+		}
+		if !expired {
+			t.Error("expected the nonce to be accepted after it expired")
+		}
+	})
+}